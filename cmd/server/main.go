@@ -2,14 +2,23 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+	"github.com/yhonda-ohishi/db-handler-server/internal/gateway"
+	applogger "github.com/yhonda-ohishi/db-handler-server/internal/logger"
+	"github.com/yhonda-ohishi/db-handler-server/internal/services"
+	"github.com/yhonda-ohishi/db-handler-server/internal/validation"
 )
 
 var (
@@ -19,16 +28,26 @@ var (
 )
 
 func main() {
+	configPath := flag.String("config", os.Getenv(config.ConfigFileEnv), "path to a YAML config file (defaults to $CONFIG_FILE)")
+	flag.Parse()
+
 	// Print banner
 	printBanner()
 
-	// Load configuration
-	cfg, err := config.Load()
+	// Load configuration; environment variables override file values
+	cfg, err := config.LoadFile(*configPath)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := validateConfig(cfg); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
-	// Logger initialization would go here for production use
+	// Initialize structured logging, including the optional rotating file
+	if err := applogger.InitializeFromAppConfig(cfg); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer applogger.Close()
 
 	fmt.Printf("Starting gRPC-First Multi-Protocol Gateway (version: %s, mode: %s)\n",
 		version, cfg.Deployment.Mode)
@@ -37,62 +56,78 @@ func main() {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 
-	// Start server based on deployment mode
-	errCh := make(chan error, 1)
-	go func() {
+	// Start server based on deployment mode and wait until it listens
+	gw, err := waitForStartup(func() (*gateway.SimpleGateway, error) {
 		switch cfg.Deployment.Mode {
 		case "single":
-			errCh <- RunSingleMode(cfg)
+			return RunSingleMode(cfg)
 		case "separate":
-			errCh <- RunSeparateMode(cfg)
+			return RunSeparateMode(cfg)
 		default:
-			errCh <- fmt.Errorf("unknown deployment mode: %s", cfg.Deployment.Mode)
+			return nil, fmt.Errorf("unknown deployment mode: %s", cfg.Deployment.Mode)
 		}
+	}, cfg.Server.StartupTimeout)
+	if err != nil {
+		fmt.Printf("Server failed to start: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Server started successfully, waiting for shutdown signal...")
+
+	// Reload hot-swappable settings on SIGHUP
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	go watchConfigReload(hupCh, *configPath, gw)
+
+	// Wait for shutdown signal
+	sig := <-sigCh
+	fmt.Printf("Received shutdown signal: %v\n", sig)
+
+	// Graceful shutdown with timeout
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer shutdownCancel()
+
+	fmt.Println("Starting graceful shutdown...")
+
+	if err := gw.Shutdown(shutdownCtx); err != nil {
+		fmt.Printf("Graceful shutdown failed: %v\n", err)
+		return
+	}
+	fmt.Println("Graceful shutdown completed")
+}
+
+// waitForStartup runs start in the background and returns the gateway once
+// its listeners are bound, or an error if start fails or the gateway is not
+// ready within timeout
+func waitForStartup(start func() (*gateway.SimpleGateway, error), timeout time.Duration) (*gateway.SimpleGateway, error) {
+	type result struct {
+		gw  *gateway.SimpleGateway
+		err error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		gw, err := start()
+		resultCh <- result{gw, err}
 	}()
 
-	// Give the server time to start
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	var gw *gateway.SimpleGateway
 	select {
-	case err := <-errCh:
-		if err != nil {
-			fmt.Printf("Server failed to start: %v\n", err)
-			os.Exit(1)
+	case res := <-resultCh:
+		if res.err != nil {
+			return nil, res.err
 		}
-	case <-time.After(2 * time.Second):
-		// Server started successfully, continue to wait for signals
-		fmt.Println("Server started successfully, waiting for shutdown signal...")
+		gw = res.gw
+	case <-deadline.C:
+		return nil, fmt.Errorf("timed out after %s waiting for gateway", timeout)
 	}
 
-	// Wait for shutdown signal
 	select {
-	case err := <-errCh:
-		if err != nil {
-			fmt.Printf("Server error: %v\n", err)
-			os.Exit(1)
-		}
-	case sig := <-sigCh:
-		fmt.Printf("Received shutdown signal: %v\n", sig)
-
-		// Graceful shutdown with timeout
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer shutdownCancel()
-
-		fmt.Println("Starting graceful shutdown...")
-
-		// Wait for shutdown to complete or timeout
-		done := make(chan bool, 1)
-		go func() {
-			// Here you would typically call shutdown methods on your services
-			// For now, we'll just wait a moment to simulate cleanup
-			time.Sleep(1 * time.Second)
-			done <- true
-		}()
-
-		select {
-		case <-done:
-			fmt.Println("Graceful shutdown completed")
-		case <-shutdownCtx.Done():
-			fmt.Println("Graceful shutdown timed out, forcing exit")
-		}
+	case <-gw.Ready():
+		return gw, nil
+	case <-deadline.C:
+		return nil, fmt.Errorf("timed out after %s waiting for the gateway to listen", timeout)
 	}
 }
 
@@ -112,21 +147,230 @@ func printBanner() {
 	fmt.Printf(banner, version)
 }
 
-// Configuration validation
+// validateConfig checks cfg for invalid or conflicting settings, returning
+// a single error that lists every problem found
 func validateConfig(cfg *config.Config) error {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if !validPort(cfg.Server.HTTPPort) {
+		addProblem("server.http_port %d is out of range 1-65535", cfg.Server.HTTPPort)
+	}
+	if !validPort(cfg.Server.GRPCPort) {
+		addProblem("server.grpc_port %d is out of range 1-65535", cfg.Server.GRPCPort)
+	}
 	if cfg.Server.HTTPPort == cfg.Server.GRPCPort {
-		return fmt.Errorf("HTTP and gRPC ports cannot be the same")
+		addProblem("HTTP and gRPC ports cannot be the same")
+	}
+	if (cfg.Monitoring.MetricsEnabled || cfg.Monitoring.AdminListener) && !validPort(cfg.Monitoring.MetricsPort) {
+		addProblem("monitoring.metrics_port %d is out of range 1-65535", cfg.Monitoring.MetricsPort)
+	}
+	if cfg.Monitoring.AdminListener && cfg.Monitoring.MetricsPort == cfg.Server.HTTPPort {
+		addProblem("monitoring.metrics_port cannot be the same as the HTTP port when monitoring.admin_listener is set")
+	}
+	if cfg.Performance.RateLimit < 0 {
+		addProblem("performance.rate_limit %d must not be negative", cfg.Performance.RateLimit)
+	}
+	if cfg.Performance.CacheDuration < 0 {
+		addProblem("performance.cache_duration %s must not be negative", cfg.Performance.CacheDuration)
+	}
+	if cfg.Monitoring.DecompressRequests && !cfg.Monitoring.MetricsEnabled {
+		addProblem("monitoring.decompress_requests requires monitoring.metrics_enabled")
+	}
+	histograms := []struct {
+		key     string
+		buckets []float64
+	}{
+		{"monitoring.duration_buckets", cfg.Monitoring.DurationBuckets},
+		{"monitoring.grpc_duration_buckets", cfg.Monitoring.GRPCDurationBuckets},
+	}
+	for _, histogram := range histograms {
+		for i, bucket := range histogram.buckets {
+			if bucket <= 0 || (i > 0 && bucket <= histogram.buckets[i-1]) {
+				addProblem("%s must be positive and increasing, got %v", histogram.key, histogram.buckets)
+				break
+			}
+		}
+	}
+
+	switch cfg.Deployment.Mode {
+	case "single":
+	case "separate":
+		urls := []struct{ name, address string }{
+			{"external.database_grpc_url", cfg.External.DatabaseGRPCURL},
+			{"external.handlers_grpc_url", cfg.External.HandlersGRPCURL},
+			{"external.db_service_url", cfg.External.DBServiceURL},
+		}
+		configured := 0
+		for _, url := range urls {
+			if url.address == "" {
+				continue
+			}
+			configured++
+			if err := validateAddress(url.address); err != nil {
+				addProblem("%s %q is not a valid host:port address: %v", url.name, url.address, err)
+			}
+		}
+		if configured == 0 {
+			addProblem("at least one external service URL must be configured in separate mode")
+		}
+	default:
+		addProblem("deployment.mode %q must be single or separate", cfg.Deployment.Mode)
+	}
+
+	if cfg.Server.MaxBodyBytes < 0 {
+		addProblem("server.max_body_bytes %d must not be negative", cfg.Server.MaxBodyBytes)
+	}
+	if _, err := validation.PhonePattern(cfg.Server.PhoneFormat); err != nil {
+		addProblem("server.phone_format: %v", err)
+	}
+	if _, err := cfg.Server.StatementLocation(); err != nil {
+		addProblem("server.statement_time_zone %q: %v", cfg.Server.StatementTimeZone, err)
 	}
+	if cfg.Server.TLS.Enabled && (cfg.Server.TLS.CertFile == "" || cfg.Server.TLS.KeyFile == "") {
+		addProblem("server.tls requires cert_file and key_file")
+	}
+	clientTLS := []struct {
+		key string
+		cfg config.ClientTLSConfig
+	}{
+		{"external.db_service_tls", cfg.External.DBServiceTLS},
+		{"external.handlers_tls", cfg.External.HandlersTLS},
+	}
+	for _, backend := range clientTLS {
+		if backend.cfg.Enabled && (backend.cfg.CertFile == "") != (backend.cfg.KeyFile == "") {
+			addProblem("%s client certificate requires both cert_file and key_file", backend.key)
+		}
+	}
+	for _, deprecation := range cfg.Server.Deprecations {
+		if !strings.HasPrefix(deprecation.Path, "/") {
+			addProblem("server.deprecations path %q must start with /", deprecation.Path)
+		} else if _, err := deprecation.SunsetTime(); err != nil {
+			addProblem("server.deprecations sunset %q for %s is not RFC 3339 or YYYY-MM-DD", deprecation.Sunset, deprecation.Path)
+		}
+	}
+	for path, limit := range cfg.Server.BodyLimits {
+		if limit <= 0 {
+			addProblem("server.body_limits[%s] %d must be positive", path, limit)
+		}
+	}
+	if cfg.Server.RequestTimeout < 0 {
+		addProblem("server.request_timeout %s must not be negative", cfg.Server.RequestTimeout)
+	}
+	for path, timeout := range cfg.Server.RequestTimeouts {
+		if timeout < 0 {
+			addProblem("server.request_timeouts[%s] %s must not be negative", path, timeout)
+		}
+	}
+	if cfg.Server.MaxPageSize < 0 {
+		addProblem("server.max_page_size %d must not be negative", cfg.Server.MaxPageSize)
+	}
+	if cfg.Server.MaxBatchSize < 0 {
+		addProblem("server.max_batch_size %d must not be negative", cfg.Server.MaxBatchSize)
+	}
+	if cfg.Server.AuditMaxPageSize < 0 {
+		addProblem("server.audit_max_page_size %d must not be negative", cfg.Server.AuditMaxPageSize)
+	}
+	for name, max := range cfg.Server.MaxPageSizes {
+		if !isServiceName(name) {
+			addProblem("server.max_page_sizes[%s] is not a service (%s)", name, strings.Join(services.ServiceNames, ", "))
+		} else if max <= 0 {
+			addProblem("server.max_page_sizes[%s] %d must be positive", name, max)
+		}
+	}
+	if cfg.Server.StartupTimeout <= 0 {
+		addProblem("server.startup_timeout %s must be positive", cfg.Server.StartupTimeout)
+	}
+	if cfg.Database.MaxConnections <= 0 {
+		addProblem("database.max_connections %d must be positive", cfg.Database.MaxConnections)
+	} else if cfg.Database.IdleConnections < 0 || cfg.Database.IdleConnections > cfg.Database.MaxConnections {
+		addProblem("database.idle_connections %d must be between 0 and database.max_connections", cfg.Database.IdleConnections)
+	}
+	if cfg.Database.ConnMaxLifetime < 0 {
+		addProblem("database.conn_max_lifetime %s must not be negative", cfg.Database.ConnMaxLifetime)
+	}
+	if cfg.CORS.MaxAge < 0 {
+		addProblem("cors.max_age %d must not be negative", cfg.CORS.MaxAge)
+	}
+	if cfg.CORS.AllowCredentials {
+		for _, origin := range cfg.CORS.Origins {
+			if origin == "*" {
+				addProblem("cors.allow_credentials cannot be combined with the wildcard origin")
+				break
+			}
+		}
+	}
+	if cfg.Diagnostics.MaxConcurrency < 0 {
+		addProblem("diagnostics.max_concurrency %d must not be negative", cfg.Diagnostics.MaxConcurrency)
+	}
+	if cfg.Diagnostics.Timeout < 0 {
+		addProblem("diagnostics.timeout %s must not be negative", cfg.Diagnostics.Timeout)
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%d configuration problem(s):\n  - %s", len(problems), strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
 
-	if cfg.Deployment.Mode == "separate" {
-		if cfg.External.DatabaseGRPCURL == "" && cfg.External.HandlersGRPCURL == "" {
-			return fmt.Errorf("at least one external service URL must be configured in separate mode")
+// isServiceName reports whether name is one of services.ServiceNames
+func isServiceName(name string) bool {
+	for _, service := range services.ServiceNames {
+		if service == name {
+			return true
 		}
 	}
+	return false
+}
+
+// validPort reports whether port is a usable TCP port number
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
 
+// validateAddress checks that address looks like a dialable host:port
+func validateAddress(address string) error {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		return fmt.Errorf("missing host")
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || !validPort(port) {
+		return fmt.Errorf("invalid port %q", portStr)
+	}
 	return nil
 }
 
+// reloadConfig reloads the config file at path and applies its hot-swappable
+// settings to gw
+func reloadConfig(path string, gw gateway.ConfigApplier) error {
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := validateConfig(cfg); err != nil {
+		return err
+	}
+	return gw.ApplyConfig(cfg)
+}
+
+// watchConfigReload reloads the configuration each time a signal arrives on hupCh
+func watchConfigReload(hupCh <-chan os.Signal, path string, gw gateway.ConfigApplier) {
+	for range hupCh {
+		if err := reloadConfig(path, gw); err != nil {
+			log.Printf("Config reload failed: %v", err)
+			continue
+		}
+		log.Printf("Configuration reloaded")
+	}
+}
+
 // Health check endpoint for deployment monitoring
 func healthCheck() error {
 	// This could be called by deployment tools to check if the server is ready
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+	"github.com/yhonda-ohishi/db-handler-server/internal/gateway"
+	applogger "github.com/yhonda-ohishi/db-handler-server/internal/logger"
+)
+
+func TestConfigReloadAppliesLogLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(level string) {
+		t.Helper()
+		yaml := "logging:\n  level: " + level + "\n"
+		if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+	}
+
+	writeConfig("info")
+	cfg, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if err := applogger.SetLevel(cfg.Logging.Level); err != nil {
+		t.Fatalf("failed to set log level: %v", err)
+	}
+	t.Cleanup(func() { _ = applogger.SetLevel("info") })
+	gw := gateway.NewSimpleGateway(cfg)
+
+	hupCh := make(chan os.Signal, 1)
+	go watchConfigReload(hupCh, path, gw)
+	defer close(hupCh)
+
+	// Simulate editing the file and sending SIGHUP
+	writeConfig("debug")
+	hupCh <- syscall.SIGHUP
+
+	deadline := time.Now().Add(time.Second)
+	for applogger.GetLevel() != "debug" && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if level := applogger.GetLevel(); level != "debug" {
+		t.Errorf("expected log level debug after reload, got %s", level)
+	}
+}
+
+// validTestConfig returns a fully valid separate-mode configuration
+func validTestConfig() *config.Config {
+	return &config.Config{
+		Deployment: config.DeploymentConfig{Mode: "separate"},
+		Server: config.ServerConfig{
+			HTTPPort:       8080,
+			GRPCPort:       9090,
+			MaxBodyBytes:   1 << 20,
+			BodyLimits:     map[string]int{"/api/v1/upload": 10 << 20},
+			StartupTimeout: 10 * time.Second,
+		},
+		Database:    config.DatabaseConfig{MaxConnections: 25, IdleConnections: 5},
+		CORS:        config.CORSConfig{MaxAge: 600},
+		External:    config.ExternalConfig{DBServiceURL: "db-service:9090"},
+		Monitoring:  config.MonitoringConfig{MetricsEnabled: true, MetricsPort: 9091},
+		Diagnostics: config.DiagnosticsConfig{MaxConcurrency: 4, Timeout: 5 * time.Second},
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	if err := validateConfig(validTestConfig()); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(cfg *config.Config)
+		wantErr string
+	}{
+		{"HTTP port out of range", func(cfg *config.Config) { cfg.Server.HTTPPort = 0 }, "server.http_port 0"},
+		{"gRPC port out of range", func(cfg *config.Config) { cfg.Server.GRPCPort = 70000 }, "server.grpc_port 70000"},
+		{"same ports", func(cfg *config.Config) { cfg.Server.GRPCPort = cfg.Server.HTTPPort }, "cannot be the same"},
+		{"metrics port out of range", func(cfg *config.Config) { cfg.Monitoring.MetricsPort = -1 }, "monitoring.metrics_port -1"},
+		{"admin listener on HTTP port", func(cfg *config.Config) {
+			cfg.Monitoring.AdminListener = true
+			cfg.Monitoring.MetricsPort = cfg.Server.HTTPPort
+		}, "monitoring.admin_listener"},
+		{"unknown mode", func(cfg *config.Config) { cfg.Deployment.Mode = "cluster" }, `deployment.mode "cluster"`},
+		{"separate mode without URLs", func(cfg *config.Config) { cfg.External = config.ExternalConfig{} }, "at least one external service URL"},
+		{"URL without port", func(cfg *config.Config) { cfg.External.DBServiceURL = "db-service" }, "external.db_service_url"},
+		{"URL with bad port", func(cfg *config.Config) { cfg.External.HandlersGRPCURL = "handlers:http" }, "external.handlers_grpc_url"},
+		{"URL without host", func(cfg *config.Config) { cfg.External.DatabaseGRPCURL = ":50051" }, "external.database_grpc_url"},
+		{"negative max body bytes", func(cfg *config.Config) { cfg.Server.MaxBodyBytes = -1 }, "server.max_body_bytes"},
+		{"unknown phone format", func(cfg *config.Config) { cfg.Server.PhoneFormat = "us" }, "server.phone_format"},
+		{"unknown statement time zone", func(cfg *config.Config) { cfg.Server.StatementTimeZone = "Mars/Olympus" }, "server.statement_time_zone"},
+		{"TLS without key file", func(cfg *config.Config) {
+			cfg.Server.TLS = config.TLSConfig{Enabled: true, CertFile: "/tls/server.crt"}
+		}, "server.tls"},
+		{"db_service client cert without key", func(cfg *config.Config) {
+			cfg.External.DBServiceTLS = config.ClientTLSConfig{Enabled: true, CertFile: "/tls/client.crt"}
+		}, "external.db_service_tls"},
+		{"handlers client key without cert", func(cfg *config.Config) {
+			cfg.External.HandlersTLS = config.ClientTLSConfig{Enabled: true, KeyFile: "/tls/client.key"}
+		}, "external.handlers_tls"},
+		{"relative deprecation path", func(cfg *config.Config) {
+			cfg.Server.Deprecations = []config.DeprecationConfig{{Path: "api/v1/users"}}
+		}, `server.deprecations path "api/v1/users"`},
+		{"invalid deprecation sunset", func(cfg *config.Config) {
+			cfg.Server.Deprecations = []config.DeprecationConfig{{Path: "/api/v1/users", Sunset: "next year"}}
+		}, `server.deprecations sunset "next year"`},
+		{"non-positive body limit", func(cfg *config.Config) { cfg.Server.BodyLimits["/api/v1/upload"] = 0 }, "server.body_limits[/api/v1/upload]"},
+		{"negative max page size", func(cfg *config.Config) { cfg.Server.MaxPageSize = -1 }, "server.max_page_size"},
+		{"negative max batch size", func(cfg *config.Config) { cfg.Server.MaxBatchSize = -1 }, "server.max_batch_size"},
+		{"negative audit max page size", func(cfg *config.Config) { cfg.Server.AuditMaxPageSize = -1 }, "server.audit_max_page_size"},
+		{"unknown page size service", func(cfg *config.Config) { cfg.Server.MaxPageSizes = map[string]int{"orders": 50} }, "server.max_page_sizes[orders]"},
+		{"non-positive page size override", func(cfg *config.Config) { cfg.Server.MaxPageSizes = map[string]int{"etc": 0} }, "server.max_page_sizes[etc]"},
+		{"no startup timeout", func(cfg *config.Config) { cfg.Server.StartupTimeout = 0 }, "server.startup_timeout"},
+		{"no database connections", func(cfg *config.Config) { cfg.Database.MaxConnections = 0 }, "database.max_connections"},
+		{"too many idle connections", func(cfg *config.Config) { cfg.Database.IdleConnections = 30 }, "database.idle_connections"},
+		{"unsorted duration buckets", func(cfg *config.Config) { cfg.Monitoring.DurationBuckets = []float64{0.01, 0.005} }, "monitoring.duration_buckets"},
+		{"negative rate limit", func(cfg *config.Config) { cfg.Performance.RateLimit = -1 }, "performance.rate_limit"},
+		{"negative cache duration", func(cfg *config.Config) { cfg.Performance.CacheDuration = -time.Second }, "performance.cache_duration"},
+		{"decompression without metrics", func(cfg *config.Config) {
+			cfg.Monitoring.MetricsEnabled = false
+			cfg.Monitoring.DecompressRequests = true
+		}, "monitoring.decompress_requests"},
+		{"non-positive grpc duration buckets", func(cfg *config.Config) { cfg.Monitoring.GRPCDurationBuckets = []float64{0, 0.005} }, "monitoring.grpc_duration_buckets"},
+		{"negative CORS max age", func(cfg *config.Config) { cfg.CORS.MaxAge = -1 }, "cors.max_age"},
+		{"credentials with wildcard origin", func(cfg *config.Config) {
+			cfg.CORS.AllowCredentials = true
+			cfg.CORS.Origins = []string{"*"}
+		}, "cors.allow_credentials"},
+		{"negative diagnostics concurrency", func(cfg *config.Config) { cfg.Diagnostics.MaxConcurrency = -1 }, "diagnostics.max_concurrency"},
+		{"negative diagnostics timeout", func(cfg *config.Config) { cfg.Diagnostics.Timeout = -time.Second }, "diagnostics.timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validTestConfig()
+			tt.mutate(cfg)
+			err := validateConfig(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateConfigReportsEveryProblem(t *testing.T) {
+	cfg := validTestConfig()
+	cfg.Server.HTTPPort = -1
+	cfg.Deployment.Mode = "cluster"
+	cfg.Database.MaxConnections = 0
+	cfg.Server.PhoneFormat = "us"
+
+	err := validateConfig(cfg)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"4 configuration problem(s)", "server.http_port", "deployment.mode", "database.max_connections", "server.phone_format"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %v", want, err)
+		}
+	}
+}
+
+func TestWaitForStartupWaitsForReadySignal(t *testing.T) {
+	newGateway := func() *gateway.SimpleGateway {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("failed to reserve a port: %v", err)
+		}
+		port := listener.Addr().(*net.TCPAddr).Port
+		listener.Close()
+
+		return gateway.NewSimpleGateway(&config.Config{
+			Deployment: config.DeploymentConfig{Mode: "single"},
+			Server:     config.ServerConfig{HTTPPort: port},
+		})
+	}
+
+	t.Run("slow start", func(t *testing.T) {
+		gw := newGateway()
+		t.Cleanup(func() { _ = gw.Stop() })
+
+		// Simulate a slow start, such as loading TLS certificates
+		const delay = 300 * time.Millisecond
+		begin := time.Now()
+		got, err := waitForStartup(func() (*gateway.SimpleGateway, error) {
+			time.Sleep(delay)
+			return gw, gw.Start(context.Background())
+		}, 5*time.Second)
+		if err != nil {
+			t.Fatalf("expected the slow start to succeed, got %v", err)
+		}
+		if got != gw {
+			t.Fatal("expected the started gateway to be returned")
+		}
+		if elapsed := time.Since(begin); elapsed < delay {
+			t.Errorf("expected to wait for the slow start, returned after %s", elapsed)
+		}
+		select {
+		case <-gw.Ready():
+		default:
+			t.Error("expected the gateway to be listening once startup returns")
+		}
+	})
+
+	t.Run("never ready", func(t *testing.T) {
+		// The gateway is returned but its listeners are never bound
+		gw := newGateway()
+		_, err := waitForStartup(func() (*gateway.SimpleGateway, error) {
+			return gw, nil
+		}, 100*time.Millisecond)
+		if err == nil || !strings.Contains(err.Error(), "timed out") {
+			t.Errorf("expected a timeout while waiting for the ready signal, got %v", err)
+		}
+	})
+
+	t.Run("start failure", func(t *testing.T) {
+		_, err := waitForStartup(func() (*gateway.SimpleGateway, error) {
+			return nil, errors.New("load TLS certificate: no such file")
+		}, time.Second)
+		if err == nil || !strings.Contains(err.Error(), "TLS certificate") {
+			t.Errorf("expected the start error, got %v", err)
+		}
+	})
+}
\ No newline at end of file
@@ -9,7 +9,7 @@ import (
 )
 
 // RunSeparateMode runs the server in separate process mode with network connections
-func RunSeparateMode(cfg *config.Config) error {
+func RunSeparateMode(cfg *config.Config) (*gateway.SimpleGateway, error) {
 	fmt.Println("Starting server in separate mode")
 
 	// Create and start the simple gateway
@@ -17,9 +17,9 @@ func RunSeparateMode(cfg *config.Config) error {
 
 	ctx := context.Background()
 	if err := gw.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start gateway: %w", err)
+		return nil, fmt.Errorf("failed to start gateway: %w", err)
 	}
 
 	fmt.Printf("Gateway started successfully on port %d (mode: separate)\n", cfg.Server.HTTPPort)
-	return nil
+	return gw, nil
 }
\ No newline at end of file
@@ -9,7 +9,7 @@ import (
 )
 
 // RunSingleMode runs the server in single process mode with bufconn
-func RunSingleMode(cfg *config.Config) error {
+func RunSingleMode(cfg *config.Config) (*gateway.SimpleGateway, error) {
 	fmt.Println("Starting server in single mode")
 
 	// Create and start the simple gateway
@@ -17,9 +17,9 @@ func RunSingleMode(cfg *config.Config) error {
 
 	ctx := context.Background()
 	if err := gw.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start gateway: %w", err)
+		return nil, fmt.Errorf("failed to start gateway: %w", err)
 	}
 
 	fmt.Printf("Gateway started successfully on port %d (mode: single)\n", cfg.Server.HTTPPort)
-	return nil
+	return gw, nil
 }
\ No newline at end of file
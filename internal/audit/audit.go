@@ -0,0 +1,246 @@
+package audit
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidPageToken is returned when a query page token can't be decoded
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Entry represents a single audit log entry
+type Entry struct {
+	Sequence   uint64    `json:"sequence"`
+	Timestamp  time.Time `json:"timestamp"`
+	Method     string    `json:"method"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	UserID     string    `json:"user_id,omitempty"`
+	// UserIDSource says where UserID came from, e.g. UserIDSourceHeader
+	UserIDSource string `json:"user_id_source,omitempty"`
+}
+
+// UserIDSourceHeader marks a user ID taken from the X-User-ID header or the
+// x-user-id gRPC metadata. The caller sets it freely, so it is a claim, not a
+// verified identity.
+const UserIDSourceHeader = "unverified_header"
+
+// Recorder receives audit entries; services report their create, update and
+// delete operations through it
+type Recorder interface {
+	Record(entry Entry) Entry
+}
+
+// Config holds audit log configuration
+type Config struct {
+	// Capacity is the maximum number of entries kept; the oldest are dropped first
+	Capacity int
+	// DefaultPageSize is used when a query doesn't specify a page size
+	DefaultPageSize int
+	// MaxPageSize caps the page size of a query
+	MaxPageSize int
+}
+
+// DefaultConfig returns default audit log configuration
+func DefaultConfig() Config {
+	return Config{
+		Capacity:        10000,
+		DefaultPageSize: 50,
+		MaxPageSize:     500,
+	}
+}
+
+// Log is a bounded in-memory audit log
+type Log struct {
+	mu      sync.RWMutex
+	entries []Entry
+	nextSeq uint64
+	config  Config
+}
+
+// NewLog creates a new audit log
+func NewLog(config Config) *Log {
+	defaults := DefaultConfig()
+	if config.Capacity <= 0 {
+		config.Capacity = defaults.Capacity
+	}
+	if config.MaxPageSize <= 0 {
+		config.MaxPageSize = defaults.MaxPageSize
+	}
+	if config.DefaultPageSize <= 0 || config.DefaultPageSize > config.MaxPageSize {
+		config.DefaultPageSize = min(defaults.DefaultPageSize, config.MaxPageSize)
+	}
+
+	return &Log{
+		entries: make([]Entry, 0),
+		config:  config,
+	}
+}
+
+// NewLogWithDefaults creates a new audit log with default configuration
+func NewLogWithDefaults() *Log {
+	return NewLog(DefaultConfig())
+}
+
+// Record appends an entry, assigning its sequence number and timestamp if unset
+func (l *Log) Record(entry Entry) Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.nextSeq++
+	entry.Sequence = l.nextSeq
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	l.entries = append(l.entries, entry)
+	if len(l.entries) > l.config.Capacity {
+		l.entries = append(l.entries[:0:0], l.entries[len(l.entries)-l.config.Capacity:]...)
+	}
+
+	return entry
+}
+
+// SetMaxPageSize changes the page size cap of queries, lowering the default
+// page size to fit; a value of zero or less restores the default cap
+func (l *Log) SetMaxPageSize(max int) {
+	defaults := DefaultConfig()
+	if max <= 0 {
+		max = defaults.MaxPageSize
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config.MaxPageSize = max
+	l.config.DefaultPageSize = min(defaults.DefaultPageSize, max)
+}
+
+// Len returns the number of entries currently kept
+func (l *Log) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.entries)
+}
+
+// QueryOptions holds audit query parameters
+type QueryOptions struct {
+	EntityType string
+	PageSize   int
+	PageToken  string
+}
+
+// QueryResult holds a page of audit entries
+type QueryResult struct {
+	Entries       []Entry `json:"entries"`
+	NextPageToken string  `json:"next_page_token"`
+}
+
+// Query returns a page of entries ordered by timestamp then sequence
+func (l *Log) Query(opts QueryOptions) (*QueryResult, error) {
+	var after *cursor
+	if opts.PageToken != "" {
+		c, err := decodeCursor(opts.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		after = c
+	}
+
+	l.mu.RLock()
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = l.config.DefaultPageSize
+	}
+	if pageSize > l.config.MaxPageSize {
+		pageSize = l.config.MaxPageSize
+	}
+
+	matched := make([]Entry, 0, len(l.entries))
+	for _, entry := range l.entries {
+		if opts.EntityType != "" && entry.EntityType != opts.EntityType {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+	l.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		return entryBefore(matched[i], matched[j])
+	})
+
+	// Skip entries up to and including the cursor
+	start := 0
+	if after != nil {
+		start = sort.Search(len(matched), func(i int) bool {
+			return after.before(matched[i])
+		})
+	}
+
+	end := min(start+pageSize, len(matched))
+	result := &QueryResult{
+		Entries: append([]Entry{}, matched[start:end]...),
+	}
+	if end < len(matched) {
+		result.NextPageToken = encodeCursor(matched[end-1])
+	}
+
+	return result, nil
+}
+
+// entryBefore reports whether a sorts before b
+func entryBefore(a, b Entry) bool {
+	ta, tb := a.Timestamp.UnixNano(), b.Timestamp.UnixNano()
+	if ta != tb {
+		return ta < tb
+	}
+	return a.Sequence < b.Sequence
+}
+
+// cursor marks the last entry returned in a page
+type cursor struct {
+	timestamp int64
+	sequence  uint64
+}
+
+// before reports whether the cursor sorts before the entry
+func (c *cursor) before(entry Entry) bool {
+	ts := entry.Timestamp.UnixNano()
+	if ts != c.timestamp {
+		return c.timestamp < ts
+	}
+	return c.sequence < entry.Sequence
+}
+
+func encodeCursor(entry Entry) string {
+	raw := fmt.Sprintf("%d:%d", entry.Timestamp.UnixNano(), entry.Sequence)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(token string) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return nil, ErrInvalidPageToken
+	}
+
+	timestamp, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	sequence, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &cursor{timestamp: timestamp, sequence: sequence}, nil
+}
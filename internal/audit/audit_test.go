@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestQueryPagination(t *testing.T) {
+	log := NewLog(Config{Capacity: 100, DefaultPageSize: 10, MaxPageSize: 7})
+
+	// Several entries share a timestamp so ordering falls back to sequence
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 25; i++ {
+		log.Record(Entry{
+			Timestamp:  base.Add(time.Duration(i/3) * time.Second),
+			Method:     "CreateUser",
+			EntityType: "user",
+			EntityID:   fmt.Sprintf("user-%d", i),
+		})
+	}
+
+	seen := make(map[uint64]bool)
+	var previous *Entry
+	pageToken := ""
+	pages := 0
+
+	for {
+		result, err := log.Query(QueryOptions{PageSize: 100, PageToken: pageToken})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		pages++
+
+		if len(result.Entries) > 7 {
+			t.Fatalf("expected page size to be capped at 7, got %d", len(result.Entries))
+		}
+
+		for i := range result.Entries {
+			entry := result.Entries[i]
+			if seen[entry.Sequence] {
+				t.Errorf("duplicate entry with sequence %d", entry.Sequence)
+			}
+			seen[entry.Sequence] = true
+
+			if previous != nil && !entryBefore(*previous, entry) {
+				t.Errorf("entry %d returned out of order after %d", entry.Sequence, previous.Sequence)
+			}
+			previous = &entry
+		}
+
+		if result.NextPageToken == "" {
+			break
+		}
+		pageToken = result.NextPageToken
+	}
+
+	if len(seen) != 25 {
+		t.Errorf("expected 25 entries across pages, got %d", len(seen))
+	}
+
+	if pages != 4 {
+		t.Errorf("expected 4 pages, got %d", pages)
+	}
+}
+
+func TestQueryFilterAndCapacity(t *testing.T) {
+	log := NewLog(Config{Capacity: 5})
+
+	for i := 0; i < 8; i++ {
+		entityType := "user"
+		if i%2 == 0 {
+			entityType = "card"
+		}
+		log.Record(Entry{Method: "Create", EntityType: entityType, EntityID: fmt.Sprint(i)})
+	}
+
+	if log.Len() != 5 {
+		t.Errorf("expected log to be bounded at 5 entries, got %d", log.Len())
+	}
+
+	result, err := log.Query(QueryOptions{EntityType: "card"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, entry := range result.Entries {
+		if entry.EntityType != "card" {
+			t.Errorf("expected only card entries, got %s", entry.EntityType)
+		}
+	}
+
+	if result.Entries[0].Sequence < 4 {
+		t.Errorf("expected oldest entries to be dropped, got sequence %d", result.Entries[0].Sequence)
+	}
+
+	if _, err := log.Query(QueryOptions{PageToken: "not-a-token"}); err != ErrInvalidPageToken {
+		t.Errorf("expected ErrInvalidPageToken, got %v", err)
+	}
+}
+
+func TestSetMaxPageSize(t *testing.T) {
+	log := NewLogWithDefaults()
+	for i := 0; i < 10; i++ {
+		log.Record(Entry{Method: "Create", EntityType: "user", EntityID: fmt.Sprint(i)})
+	}
+
+	log.SetMaxPageSize(3)
+	result, err := log.Query(QueryOptions{PageSize: 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Entries) != 3 {
+		t.Errorf("expected page size to be capped at 3, got %d", len(result.Entries))
+	}
+
+	// The default page size is lowered to fit the cap
+	result, err = log.Query(QueryOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Entries) != 3 {
+		t.Errorf("expected default page size of 3, got %d", len(result.Entries))
+	}
+
+	log.SetMaxPageSize(0)
+	result, err = log.Query(QueryOptions{PageSize: 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Entries) != 10 {
+		t.Errorf("expected the default cap to be restored, got %d entries", len(result.Entries))
+	}
+}
@@ -2,8 +2,10 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"sync"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -12,11 +14,18 @@ import (
 
 const bufSize = 1024 * 1024
 
+// ErrBufconnClosed is returned by GetConnection after Close
+var ErrBufconnClosed = errors.New("bufconn client is closed")
+
 // BufconnClient manages in-memory gRPC connections using bufconn
 type BufconnClient struct {
 	listener *bufconn.Listener
-	server   *grpc.Server
-	conn     *grpc.ClientConn
+
+	// mu guards server, conn and closed
+	mu     sync.Mutex
+	server *grpc.Server
+	conn   *grpc.ClientConn
+	closed bool
 }
 
 // NewBufconnClient creates a new bufconn client with an in-memory listener
@@ -33,6 +42,9 @@ func (b *BufconnClient) GetListener() *bufconn.Listener {
 
 // StartServer starts the gRPC server with the provided options
 func (b *BufconnClient) StartServer(opts ...grpc.ServerOption) (*grpc.Server, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	if b.server != nil {
 		return b.server, nil
 	}
@@ -49,8 +61,17 @@ func (b *BufconnClient) StartServer(opts ...grpc.ServerOption) (*grpc.Server, er
 	return b.server, nil
 }
 
-// GetConnection returns a client connection to the bufconn server
+// GetConnection returns the client connection to the bufconn server, dialing
+// it on the first call and returning the same connection thereafter (opts
+// only apply to the first call). The connection is shared: callers must not
+// close it; Close tears it down.
 func (b *BufconnClient) GetConnection(ctx context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrBufconnClosed
+	}
 	if b.conn != nil {
 		return b.conn, nil
 	}
@@ -78,8 +99,16 @@ func (b *BufconnClient) GetConnection(ctx context.Context, opts ...grpc.DialOpti
 	return conn, nil
 }
 
-// Close closes the client connection and stops the server
+// Close closes the shared client connection and stops the server
 func (b *BufconnClient) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
 	var errs []error
 
 	if b.conn != nil {
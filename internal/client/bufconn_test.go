@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestBufconnClientReusesConnection(t *testing.T) {
+	b := NewBufconnClient()
+	if _, err := b.StartServer(); err != nil {
+		t.Fatalf("failed to start server: %v", err)
+	}
+
+	ctx := context.Background()
+	first, err := b.GetConnection(ctx)
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+
+	// Concurrent callers share the connection dialed by the first call
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := b.GetConnection(ctx)
+			if err != nil {
+				t.Errorf("failed to get connection: %v", err)
+				return
+			}
+			if conn != first {
+				t.Error("expected GetConnection to return the shared connection")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := b.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+	if _, err := b.GetConnection(ctx); !errors.Is(err, ErrBufconnClosed) {
+		t.Errorf("expected ErrBufconnClosed after Close, got %v", err)
+	}
+	if err := b.Close(); err != nil {
+		t.Errorf("expected repeated Close to succeed, got %v", err)
+	}
+}
\ No newline at end of file
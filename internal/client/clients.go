@@ -0,0 +1,76 @@
+package client
+
+import (
+	"sync"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc"
+)
+
+// Clients provides the typed service clients over a shared connection. Each
+// client is constructed on first use and reused afterwards; Clients is safe
+// for concurrent use.
+type Clients struct {
+	conn *grpc.ClientConn
+
+	userOnce        sync.Once
+	user            pb.UserServiceClient
+	transactionOnce sync.Once
+	transaction     pb.TransactionServiceClient
+	cardOnce        sync.Once
+	card            pb.CardServiceClient
+	paymentOnce     sync.Once
+	payment         pb.PaymentServiceClient
+	etcOnce         sync.Once
+	etc             pb.ETCServiceClient
+}
+
+// NewClients creates the service clients for conn
+func NewClients(conn *grpc.ClientConn) *Clients {
+	return &Clients{conn: conn}
+}
+
+// Conn returns the connection shared by the clients
+func (c *Clients) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// User returns the UserService client
+func (c *Clients) User() pb.UserServiceClient {
+	c.userOnce.Do(func() {
+		c.user = pb.NewUserServiceClient(c.conn)
+	})
+	return c.user
+}
+
+// Transaction returns the TransactionService client
+func (c *Clients) Transaction() pb.TransactionServiceClient {
+	c.transactionOnce.Do(func() {
+		c.transaction = pb.NewTransactionServiceClient(c.conn)
+	})
+	return c.transaction
+}
+
+// Card returns the CardService client
+func (c *Clients) Card() pb.CardServiceClient {
+	c.cardOnce.Do(func() {
+		c.card = pb.NewCardServiceClient(c.conn)
+	})
+	return c.card
+}
+
+// Payment returns the PaymentService client
+func (c *Clients) Payment() pb.PaymentServiceClient {
+	c.paymentOnce.Do(func() {
+		c.payment = pb.NewPaymentServiceClient(c.conn)
+	})
+	return c.payment
+}
+
+// ETC returns the ETCService client
+func (c *Clients) ETC() pb.ETCServiceClient {
+	c.etcOnce.Do(func() {
+		c.etc = pb.NewETCServiceClient(c.conn)
+	})
+	return c.etc
+}
\ No newline at end of file
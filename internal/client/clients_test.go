@@ -0,0 +1,43 @@
+package client
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestClientsReuseServiceClients(t *testing.T) {
+	// Creating clients doesn't dial, so no server is needed
+	conn, err := grpc.NewClient("passthrough:///unused", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create connection: %v", err)
+	}
+	defer conn.Close()
+
+	clients := NewClients(conn)
+	if clients.Conn() != conn {
+		t.Error("expected Conn to return the shared connection")
+	}
+
+	first := clients.User()
+	if first == nil {
+		t.Fatal("expected a UserService client")
+	}
+	if second := clients.User(); second != first {
+		t.Error("expected the UserService client to be reused")
+	}
+
+	if clients.Transaction() != clients.Transaction() {
+		t.Error("expected the TransactionService client to be reused")
+	}
+	if clients.Card() != clients.Card() {
+		t.Error("expected the CardService client to be reused")
+	}
+	if clients.Payment() != clients.Payment() {
+		t.Error("expected the PaymentService client to be reused")
+	}
+	if clients.ETC() != clients.ETC() {
+		t.Error("expected the ETCService client to be reused")
+	}
+}
\ No newline at end of file
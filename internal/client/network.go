@@ -6,9 +6,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/yhonda-ohishi/db-handler-server/internal/logger"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 )
@@ -29,6 +31,8 @@ type NetworkClientConfig struct {
 	KeepAlive       time.Duration
 	MaxMessageSize  int
 	WithInsecure    bool
+	// TransportCredentials secures the connection; it takes precedence over WithInsecure
+	TransportCredentials credentials.TransportCredentials
 	BackoffMultiplier float64
 }
 
@@ -60,7 +64,9 @@ func buildDialOptions(config *NetworkClientConfig) []grpc.DialOption {
 	opts := []grpc.DialOption{}
 
 	// Security
-	if config.WithInsecure {
+	if config.TransportCredentials != nil {
+		opts = append(opts, grpc.WithTransportCredentials(config.TransportCredentials))
+	} else if config.WithInsecure {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
@@ -187,6 +193,8 @@ func unaryClientInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 		start := time.Now()
 
+		// Propagate the request and user IDs for log correlation
+		ctx = logger.OutgoingContextWithRequestMetadata(ctx)
 		err := invoker(ctx, method, req, reply, cc, opts...)
 
 		duration := time.Since(start)
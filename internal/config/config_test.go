@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -47,64 +48,16 @@ func TestLoadFromEnvironment(t *testing.T) {
 	assert.Equal(t, "debug", cfg.Logging.Level)
 }
 
-func TestValidation(t *testing.T) {
-	tests := []struct {
-		name    string
-		cfg     *Config
-		wantErr bool
-	}{
-		{
-			name: "valid single mode",
-			cfg: &Config{
-				Deployment: DeploymentConfig{Mode: "single"},
-				Server:     ServerConfig{HTTPPort: 8080, GRPCPort: 9090},
-			},
-			wantErr: false,
-		},
-		{
-			name: "valid separate mode",
-			cfg: &Config{
-				Deployment: DeploymentConfig{Mode: "separate"},
-				Server:     ServerConfig{HTTPPort: 8080, GRPCPort: 9090},
-			},
-			wantErr: false,
-		},
-		{
-			name: "invalid deployment mode",
-			cfg: &Config{
-				Deployment: DeploymentConfig{Mode: "invalid"},
-				Server:     ServerConfig{HTTPPort: 8080, GRPCPort: 9090},
-			},
-			wantErr: true,
-		},
-		{
-			name: "invalid HTTP port",
-			cfg: &Config{
-				Deployment: DeploymentConfig{Mode: "single"},
-				Server:     ServerConfig{HTTPPort: -1, GRPCPort: 9090},
-			},
-			wantErr: true,
-		},
-		{
-			name: "invalid gRPC port",
-			cfg: &Config{
-				Deployment: DeploymentConfig{Mode: "single"},
-				Server:     ServerConfig{HTTPPort: 8080, GRPCPort: 70000},
-			},
-			wantErr: true,
-		},
-	}
+func TestLoadMonitoringFromEnvironment(t *testing.T) {
+	// Environment variables only override keys viper knows a default for
+	t.Setenv("MONITORING_ADMIN_LISTENER", "true")
+	t.Setenv("MONITORING_DECOMPRESS_REQUESTS", "true")
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := validate(tt.cfg)
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
-		})
-	}
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.True(t, cfg.Monitoring.AdminListener)
+	assert.True(t, cfg.Monitoring.DecompressRequests)
 }
 
 func TestModeHelpers(t *testing.T) {
@@ -117,4 +70,65 @@ func TestModeHelpers(t *testing.T) {
 	cfg.Deployment.Mode = "separate"
 	assert.False(t, cfg.IsSingleMode())
 	assert.True(t, cfg.IsSeparateMode())
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.yaml")
+	yaml := `
+deployment:
+  mode: separate
+server:
+  http_port: 8181
+  grpc_port: 9191
+  enabled_services: ["user", "transaction"]
+  tls:
+    enabled: true
+    cert_file: /tls/server.crt
+    key_file: /tls/server.key
+external:
+  db_service_url: "db-service:9090"
+  db_service_tls:
+    enabled: true
+    ca_file: /tls/ca.crt
+  handlers_tls:
+    enabled: true
+    server_name: handlers.internal
+logging:
+  level: warn
+`
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+
+	cfg, err := LoadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "separate", cfg.Deployment.Mode)
+	assert.Equal(t, 8181, cfg.Server.HTTPPort)
+	assert.Equal(t, 9191, cfg.Server.GRPCPort)
+	assert.Equal(t, []string{"user", "transaction"}, cfg.Server.EnabledServices)
+	assert.True(t, cfg.Server.TLS.Enabled)
+	assert.Equal(t, "/tls/server.crt", cfg.Server.TLS.CertFile)
+	assert.Equal(t, "db-service:9090", cfg.External.DBServiceURL)
+	assert.True(t, cfg.External.DBServiceTLS.Enabled)
+	assert.Equal(t, "/tls/ca.crt", cfg.External.DBServiceTLS.CAFile)
+	assert.True(t, cfg.External.HandlersTLS.Enabled)
+	assert.Equal(t, "handlers.internal", cfg.External.HandlersTLS.ServerName)
+	assert.Equal(t, "warn", cfg.Logging.Level)
+	// Unset values keep their defaults
+	assert.Equal(t, "json", cfg.Logging.Format)
+
+	// Environment variables override file values
+	t.Setenv("SERVER_HTTP_PORT", "8282")
+	t.Setenv("EXTERNAL_DB_SERVICE_URL", "db-override:9090")
+	t.Setenv(ConfigFileEnv, path)
+
+	cfg, err = Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, 8282, cfg.Server.HTTPPort)
+	assert.Equal(t, "db-override:9090", cfg.External.DBServiceURL)
+	assert.Equal(t, 9191, cfg.Server.GRPCPort)
+
+	// A named config file must exist
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
 }
\ No newline at end of file
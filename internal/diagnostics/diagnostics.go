@@ -0,0 +1,192 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ProbeStatus represents the outcome of a single protocol probe
+type ProbeStatus string
+
+const (
+	ProbeStatusOK      ProbeStatus = "ok"
+	ProbeStatusFailed  ProbeStatus = "failed"
+	ProbeStatusTimeout ProbeStatus = "timeout"
+	// ProbeStatusSkipped is reported for probes that never started before the timeout
+	ProbeStatusSkipped ProbeStatus = "skipped"
+)
+
+// Probe exercises one protocol and returns a fingerprint of what it observed.
+// Probes of a consistent system return identical fingerprints.
+type Probe struct {
+	Protocol string
+	Run      func(ctx context.Context) (string, error)
+}
+
+// Config holds self-diagnostic configuration
+type Config struct {
+	// MaxConcurrency bounds how many probes run at the same time
+	MaxConcurrency int
+	// Timeout bounds the whole diagnostic run
+	Timeout time.Duration
+}
+
+// DefaultConfig returns default self-diagnostic configuration
+func DefaultConfig() Config {
+	return Config{
+		MaxConcurrency: 4,
+		Timeout:        5 * time.Second,
+	}
+}
+
+// Result holds the outcome of a single probe
+type Result struct {
+	Protocol    string      `json:"protocol"`
+	Status      ProbeStatus `json:"status"`
+	Fingerprint string      `json:"fingerprint,omitempty"`
+	Message     string      `json:"message,omitempty"`
+	DurationMs  int64       `json:"duration_ms"`
+}
+
+// Report holds the outcome of a diagnostic run
+type Report struct {
+	// Consistent is true when every probe succeeded with the same fingerprint
+	Consistent bool     `json:"consistent"`
+	Complete   bool     `json:"complete"`
+	Results    []Result `json:"results"`
+	DurationMs int64    `json:"duration_ms"`
+}
+
+// Runner runs protocol probes concurrently
+type Runner struct {
+	probes []Probe
+	config Config
+}
+
+// NewRunner creates a new diagnostic runner
+func NewRunner(config Config, probes ...Probe) *Runner {
+	defaults := DefaultConfig()
+	if config.MaxConcurrency <= 0 {
+		config.MaxConcurrency = defaults.MaxConcurrency
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+
+	return &Runner{
+		probes: probes,
+		config: config,
+	}
+}
+
+// probeOutcome carries a finished probe back to the runner
+type probeOutcome struct {
+	index       int
+	fingerprint string
+	err         error
+	duration    time.Duration
+}
+
+// Run executes all probes and returns once they finish or the timeout expires.
+// Probes still running at the timeout are reported with a timeout status.
+func (r *Runner) Run(ctx context.Context) *Report {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	defer cancel()
+
+	results := make([]Result, len(r.probes))
+	for i, probe := range r.probes {
+		results[i] = Result{
+			Protocol: probe.Protocol,
+			Status:   ProbeStatusSkipped,
+		}
+	}
+
+	started := make([]time.Time, len(r.probes))
+	sem := make(chan struct{}, r.config.MaxConcurrency)
+	// Buffered so probes finishing after the timeout never block
+	outcomes := make(chan probeOutcome, len(r.probes))
+
+	pending := 0
+	next := 0
+	for next < len(r.probes) || pending > 0 {
+		// A nil channel disables the start case once every probe has started
+		var acquire chan<- struct{}
+		if next < len(r.probes) {
+			acquire = sem
+		}
+
+		select {
+		case acquire <- struct{}{}:
+			started[next] = time.Now()
+			results[next].Status = ProbeStatusTimeout
+			go runProbe(ctx, next, r.probes[next], sem, outcomes)
+			pending++
+			next++
+		case outcome := <-outcomes:
+			pending--
+			result := &results[outcome.index]
+			result.DurationMs = outcome.duration.Milliseconds()
+			if outcome.err != nil {
+				result.Status = ProbeStatusFailed
+				result.Message = outcome.err.Error()
+			} else {
+				result.Status = ProbeStatusOK
+				result.Fingerprint = outcome.fingerprint
+			}
+		case <-ctx.Done():
+			for i := range results {
+				switch results[i].Status {
+				case ProbeStatusTimeout:
+					results[i].DurationMs = time.Since(started[i]).Milliseconds()
+					results[i].Message = fmt.Sprintf("probe did not finish within %s", r.config.Timeout)
+				case ProbeStatusSkipped:
+					results[i].Message = "probe not started before timeout"
+				}
+			}
+			return newReport(results, time.Since(start))
+		}
+	}
+
+	return newReport(results, time.Since(start))
+}
+
+// runProbe runs a probe and releases its concurrency slot when done
+func runProbe(ctx context.Context, index int, probe Probe, sem chan struct{}, outcomes chan<- probeOutcome) {
+	defer func() { <-sem }()
+
+	start := time.Now()
+	fingerprint, err := probe.Run(ctx)
+	outcomes <- probeOutcome{
+		index:       index,
+		fingerprint: fingerprint,
+		err:         err,
+		duration:    time.Since(start),
+	}
+}
+
+// newReport evaluates completeness and consistency of the results
+func newReport(results []Result, duration time.Duration) *Report {
+	report := &Report{
+		Consistent: len(results) > 0,
+		Complete:   true,
+		Results:    results,
+		DurationMs: duration.Milliseconds(),
+	}
+
+	for _, result := range results {
+		if result.Status != ProbeStatusOK {
+			report.Consistent = false
+			if result.Status != ProbeStatusFailed {
+				report.Complete = false
+			}
+			continue
+		}
+		if result.Fingerprint != results[0].Fingerprint {
+			report.Consistent = false
+		}
+	}
+
+	return report
+}
\ No newline at end of file
@@ -0,0 +1,99 @@
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixedProbe(protocol, fingerprint string) Probe {
+	return Probe{
+		Protocol: protocol,
+		Run: func(ctx context.Context) (string, error) {
+			return fingerprint, nil
+		},
+	}
+}
+
+func TestRunnerSlowProtocolTimesOut(t *testing.T) {
+	// The slow probe ignores cancellation to simulate a hung protocol
+	blocked := make(chan struct{})
+	t.Cleanup(func() { close(blocked) })
+
+	runner := NewRunner(Config{MaxConcurrency: 2, Timeout: 100 * time.Millisecond},
+		fixedProbe("grpc", "users:3"),
+		Probe{
+			Protocol: "jsonrpc",
+			Run: func(ctx context.Context) (string, error) {
+				<-blocked
+				return "users:3", nil
+			},
+		},
+	)
+
+	start := time.Now()
+	report := runner.Run(context.Background())
+	assert.Less(t, time.Since(start), time.Second)
+
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, ProbeStatusOK, report.Results[0].Status)
+	assert.Equal(t, "users:3", report.Results[0].Fingerprint)
+	assert.Equal(t, ProbeStatusTimeout, report.Results[1].Status)
+	assert.NotEmpty(t, report.Results[1].Message)
+	assert.False(t, report.Complete)
+	assert.False(t, report.Consistent)
+}
+
+func TestRunnerBoundsConcurrency(t *testing.T) {
+	var running, peak int32
+	probe := Probe{
+		Protocol: "rest",
+		Run: func(ctx context.Context) (string, error) {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				observed := atomic.LoadInt32(&peak)
+				if current <= observed || atomic.CompareAndSwapInt32(&peak, observed, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return "ok", nil
+		},
+	}
+
+	runner := NewRunner(Config{MaxConcurrency: 2, Timeout: time.Second}, probe, probe, probe, probe, probe)
+	report := runner.Run(context.Background())
+
+	assert.True(t, report.Complete)
+	assert.True(t, report.Consistent)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+func TestRunnerConsistency(t *testing.T) {
+	report := NewRunner(DefaultConfig(),
+		fixedProbe("grpc", "users:3"),
+		fixedProbe("jsonrpc", "users:4"),
+	).Run(context.Background())
+	assert.True(t, report.Complete)
+	assert.False(t, report.Consistent)
+
+	report = NewRunner(DefaultConfig(),
+		fixedProbe("grpc", "users:3"),
+		Probe{
+			Protocol: "jsonrpc",
+			Run: func(ctx context.Context) (string, error) {
+				return "", errors.New("connection refused")
+			},
+		},
+	).Run(context.Background())
+	assert.True(t, report.Complete)
+	assert.False(t, report.Consistent)
+	assert.Equal(t, ProbeStatusFailed, report.Results[1].Status)
+	assert.Equal(t, "connection refused", report.Results[1].Message)
+}
\ No newline at end of file
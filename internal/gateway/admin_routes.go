@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"crypto/subtle"
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/audit"
+	"github.com/yhonda-ohishi/db-handler-server/internal/readonly"
+	"github.com/yhonda-ohishi/db-handler-server/internal/services"
+)
+
+// AdminRoutes handles administrative endpoints
+type AdminRoutes struct {
+	readOnly *readonly.Mode
+	registry *services.ServiceRegistry
+	// audit serves GET /admin/audit
+	audit *AuditRoutes
+	// token is the bearer token admin requests must present; when empty the
+	// admin endpoints are disabled
+	token string
+}
+
+// NewAdminRoutes creates a new admin route handler
+func NewAdminRoutes(readOnly *readonly.Mode) *AdminRoutes {
+	return &AdminRoutes{
+		readOnly: readOnly,
+	}
+}
+
+// SetServiceRegistry sets the registry reported by /admin/services
+func (r *AdminRoutes) SetServiceRegistry(registry *services.ServiceRegistry) {
+	r.registry = registry
+}
+
+// SetAuditLog serves log at GET /admin/audit
+func (r *AdminRoutes) SetAuditLog(log *audit.Log) {
+	r.audit = NewAuditRoutes(log)
+}
+
+// SetToken requires admin requests to send "Authorization: Bearer <token>"
+func (r *AdminRoutes) SetToken(token string) {
+	r.token = token
+}
+
+// RegisterRoutes registers the admin endpoints
+func (r *AdminRoutes) RegisterRoutes(app *fiber.App) {
+	if r.token == "" {
+		log.Printf("Warning: server.admin_token is not set; /admin endpoints will answer 503")
+	}
+
+	admin := app.Group("/admin", r.authenticate)
+	admin.Get("/read-only", r.getReadOnly)
+	admin.Put("/read-only", r.setReadOnly)
+	admin.Get("/services", r.getServices)
+	if r.audit != nil {
+		r.audit.RegisterRoutes(admin)
+		app.Group(LegacyAuditPath, r.authenticate).Get("", r.audit.queryAuditDeprecated)
+	}
+}
+
+// authenticate rejects requests without the configured bearer token, and
+// every request when no token is configured
+func (r *AdminRoutes) authenticate(c *fiber.Ctx) error {
+	if r.token == "" {
+		return restError(c, 503, "Admin endpoints are disabled: server.admin_token is not set")
+	}
+	return requireBearerToken(c, r.token)
+}
+
+// newAdminAuthMiddleware rejects requests without "Authorization: Bearer
+// <token>"; token must be set
+func newAdminAuthMiddleware(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return requireBearerToken(c, token)
+	}
+}
+
+// requireBearerToken continues the chain when the request carries token
+func requireBearerToken(c *fiber.Ctx, token string) error {
+	if !hasBearerToken(c, token) {
+		c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+		return restError(c, 401, "Unauthorized")
+	}
+	return c.Next()
+}
+
+// hasBearerToken reports whether the request sends "Authorization: Bearer <token>"
+func hasBearerToken(c *fiber.Ctx, token string) bool {
+	presented, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
+
+// getServices reports per-service metadata and health from the service registry
+func (r *AdminRoutes) getServices(c *fiber.Ctx) error {
+	if r.registry == nil {
+		return serviceUnavailable(c)
+	}
+
+	return c.JSON(fiber.Map{
+		"services": r.registry.GetServiceInfo(),
+		"healthy":  r.registry.IsHealthy(),
+	})
+}
+
+func (r *AdminRoutes) getReadOnly(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"enabled": r.readOnly.Enabled()})
+}
+
+func (r *AdminRoutes) setReadOnly(c *fiber.Ctx) error {
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := c.BodyParser(&body); err != nil || body.Enabled == nil {
+		return restError(c, 400, "Request body must contain an \"enabled\" boolean")
+	}
+
+	r.readOnly.SetEnabled(*body.Enabled)
+	return c.JSON(fiber.Map{"enabled": r.readOnly.Enabled()})
+}
\ No newline at end of file
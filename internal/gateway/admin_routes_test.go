@@ -0,0 +1,244 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doRequest(t *testing.T, app *fiber.App, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, 5000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+// testAdminToken is the admin token of gateways built by newAdminGateway
+const testAdminToken = "s3cret"
+
+// newAdminGateway starts a single-mode gateway with testAdminToken set
+func newAdminGateway(t *testing.T) *fiber.App {
+	t.Helper()
+
+	cfg := newTestConfig("single")
+	cfg.Server.AdminToken = testAdminToken
+	gw := NewSimpleGateway(cfg)
+	require.NoError(t, gw.Initialize())
+	t.Cleanup(func() { _ = gw.Stop() })
+	return gw.GetHTTPHandler()
+}
+
+// doAdminRequest is doRequest with the admin bearer token
+func doAdminRequest(t *testing.T, app *fiber.App, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	resp, err := app.Test(req, 5000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+func TestReadOnlyMode(t *testing.T) {
+	app := newAdminGateway(t)
+
+	createUser := `{"jsonrpc": "2.0", "method": "user.create", "params": {"email": "readonly@example.com", "name": "Read Only"}, "id": 1}`
+	listUsers := `{"jsonrpc": "2.0", "method": "user.list", "params": {}, "id": 2}`
+
+	code, body := doAdminRequest(t, app, "PUT", "/admin/read-only", `{"enabled": true}`)
+	require.Equal(t, fiber.StatusOK, code)
+	assert.Equal(t, true, body["enabled"])
+
+	t.Run("gRPC-backed write blocked", func(t *testing.T) {
+		_, body := doRequest(t, app, "POST", "/jsonrpc", createUser)
+		require.NotNil(t, body["error"])
+		errorObj := body["error"].(map[string]interface{})
+		assert.Equal(t, "FailedPrecondition", errorObj["data"])
+	})
+
+	t.Run("read succeeds", func(t *testing.T) {
+		_, body := doRequest(t, app, "POST", "/jsonrpc", listUsers)
+		assert.Nil(t, body["error"])
+		assert.NotNil(t, body["result"])
+
+		code, _ := doRequest(t, app, "GET", "/api/v1/users", "")
+		assert.Equal(t, fiber.StatusOK, code)
+	})
+
+	t.Run("REST write forbidden", func(t *testing.T) {
+		code, _ := doRequest(t, app, "POST", "/api/v1/db/etc-meisai", `{}`)
+		assert.Equal(t, fiber.StatusForbidden, code)
+	})
+
+	t.Run("writes resume when disabled", func(t *testing.T) {
+		code, body := doAdminRequest(t, app, "PUT", "/admin/read-only", `{"enabled": false}`)
+		require.Equal(t, fiber.StatusOK, code)
+		assert.Equal(t, false, body["enabled"])
+
+		_, body = doRequest(t, app, "POST", "/jsonrpc", createUser)
+		assert.Nil(t, body["error"])
+	})
+
+	t.Run("invalid toggle body", func(t *testing.T) {
+		code, _ := doAdminRequest(t, app, "PUT", "/admin/read-only", `{}`)
+		assert.Equal(t, fiber.StatusBadRequest, code)
+	})
+}
+
+func TestAdminRoutesDisabledWithoutToken(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	for _, route := range []struct{ method, path, body string }{
+		{"GET", "/admin/read-only", ""},
+		{"PUT", "/admin/read-only", `{"enabled": true}`},
+		{"GET", "/admin/services", ""},
+		{"GET", "/admin/audit", ""},
+	} {
+		code, _ := doRequest(t, app, route.method, route.path, route.body)
+		assert.Equal(t, fiber.StatusServiceUnavailable, code, "%s %s", route.method, route.path)
+	}
+
+	// The rejected toggle must not have switched read-only mode on
+	code, _ := doRequest(t, app, "POST", "/api/v1/users", `{"email": "no-token@example.com", "name": "No Token"}`)
+	assert.Equal(t, fiber.StatusCreated, code)
+}
+
+func TestAdminServices(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Server.AdminToken = "s3cret"
+	gw := NewSimpleGateway(cfg)
+	require.NoError(t, gw.Initialize())
+	t.Cleanup(func() { _ = gw.Stop() })
+	app := gw.GetHTTPHandler()
+
+	t.Run("requires the admin token", func(t *testing.T) {
+		code, _ := doRequest(t, app, "GET", "/admin/services", "")
+		assert.Equal(t, fiber.StatusUnauthorized, code)
+
+		req := httptest.NewRequest("GET", "/admin/read-only", nil)
+		req.Header.Set("Authorization", "Bearer wrong")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, "Bearer", resp.Header.Get("WWW-Authenticate"))
+	})
+
+	t.Run("reports service info and health", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/admin/services", nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Services map[string]map[string]interface{} `json:"services"`
+			Healthy  map[string]bool                   `json:"healthy"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+		require.Contains(t, body.Services, "user_service")
+		assert.Greater(t, body.Services["user_service"]["user_count"], float64(0))
+		require.Contains(t, body.Services, "etc_service")
+		assert.Equal(t, "ETCService", body.Services["etc_service"]["name"])
+		assert.Contains(t, body.Services["etc_service"], "record_count")
+		assert.True(t, body.Healthy["etc_service"])
+		assert.True(t, body.Healthy["user_service"])
+	})
+}
+
+func TestAdminAuditLog(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Server.AdminToken = "s3cret"
+	gw := NewSimpleGateway(cfg)
+	require.NoError(t, gw.Initialize())
+	t.Cleanup(func() { _ = gw.Stop() })
+	app := gw.GetHTTPHandler()
+
+	// The acting user reaches the service through the gRPC metadata
+	req := httptest.NewRequest("POST", "/api/v1/users", strings.NewReader(`{"email": "audit@example.com", "name": "Audit"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", "operator-7")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	code, _ := doRequest(t, app, "GET", "/admin/audit?entity=user", "")
+	assert.Equal(t, fiber.StatusUnauthorized, code)
+
+	req = httptest.NewRequest("GET", "/admin/audit?entity=user", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Entries []map[string]interface{} `json:"entries"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Entries, 1)
+	assert.Equal(t, "CreateUser", body.Entries[0]["method"])
+	assert.Equal(t, "user", body.Entries[0]["entity_type"])
+	assert.Equal(t, "operator-7", body.Entries[0]["user_id"])
+	assert.Equal(t, "unverified_header", body.Entries[0]["user_id_source"])
+
+	t.Run("deprecated path", func(t *testing.T) {
+		code, _ := doRequest(t, app, "GET", LegacyAuditPath, "")
+		assert.Equal(t, fiber.StatusUnauthorized, code)
+
+		req := httptest.NewRequest("GET", LegacyAuditPath+"?entity=user", nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "true", resp.Header.Get("Deprecation"))
+		assert.Contains(t, resp.Header.Get("Link"), "</admin/audit>")
+
+		var legacy struct {
+			Entries []map[string]interface{} `json:"entries"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&legacy))
+		assert.Len(t, legacy.Entries, 1)
+	})
+}
+
+func TestAuditPageSizeLimitedByConfig(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Server.AdminToken = testAdminToken
+	cfg.Server.AuditMaxPageSize = 2
+	gw := NewSimpleGateway(cfg)
+	require.NoError(t, gw.Initialize())
+	t.Cleanup(func() { _ = gw.Stop() })
+	app := gw.GetHTTPHandler()
+
+	for _, email := range []string{"page1@example.com", "page2@example.com", "page3@example.com"} {
+		code, _ := doRequest(t, app, "POST", "/api/v1/users", `{"email": "`+email+`", "name": "Page"}`)
+		require.Equal(t, fiber.StatusCreated, code)
+	}
+
+	code, body := doAdminRequest(t, app, "GET", "/admin/audit?entity=user&page_size=100", "")
+	require.Equal(t, fiber.StatusOK, code)
+	assert.Len(t, body["entries"], 2)
+	assert.NotEmpty(t, body["next_page_token"])
+}
\ No newline at end of file
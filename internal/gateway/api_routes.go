@@ -0,0 +1,630 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/services"
+	"github.com/yhonda-ohishi/db-handler-server/internal/validation"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// IdempotencyKeyHeader lets clients safely retry create requests
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// APIRoutes handles REST routes for the user, transaction, card, payment and ETC services.
+// Responses are JSON by default and protobuf for Accept: application/x-protobuf.
+type APIRoutes struct {
+	conn *grpc.ClientConn
+	// etcConn serves the ETC明細 endpoints; it is conn unless SetETCConnection
+	// routes them to another backend
+	etcConn *grpc.ClientConn
+	// multiStatus answers 207 when a bulk request partially fails
+	multiStatus bool
+	// phonePattern validates user phone numbers; nil accepts any value
+	phonePattern *regexp.Regexp
+	// adminToken is the bearer token required by admin-only query flags; when
+	// empty those flags are refused
+	adminToken string
+}
+
+// NewAPIRoutes creates a new REST route handler for the core services
+func NewAPIRoutes(conn *grpc.ClientConn) *APIRoutes {
+	return &APIRoutes{
+		conn:        conn,
+		etcConn:     conn,
+		multiStatus: true,
+	}
+}
+
+// SetETCConnection routes the ETC明細 endpoints to conn, such as db_service
+// in separate mode, while the other endpoints keep using the handlers backend
+func (r *APIRoutes) SetETCConnection(conn *grpc.ClientConn) {
+	r.etcConn = conn
+}
+
+// SetMultiStatus controls whether bulk endpoints answer 207 Multi-Status on
+// partial failure and 400 when every row fails
+func (r *APIRoutes) SetMultiStatus(enabled bool) {
+	r.multiStatus = enabled
+}
+
+// SetAdminToken sets the bearer token requests must send to use admin-only
+// query flags such as include_deleted and hard_delete
+func (r *APIRoutes) SetAdminToken(token string) {
+	r.adminToken = token
+}
+
+// adminQueryFlag reads a boolean query parameter that only admins may set.
+// Like /admin it fails closed: a true value needs the admin bearer token and
+// is refused while server.admin_token is unset.
+func (r *APIRoutes) adminQueryFlag(c *fiber.Ctx, name string) (bool, error) {
+	if !c.QueryBool(name) {
+		return false, nil
+	}
+	if r.adminToken == "" {
+		return false, fiber.NewError(fiber.StatusServiceUnavailable, name+" is disabled: server.admin_token is not set")
+	}
+	if !hasBearerToken(c, r.adminToken) {
+		c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+		return false, fiber.NewError(fiber.StatusUnauthorized, "Unauthorized")
+	}
+	return true, nil
+}
+
+// SetPhonePattern sets the pattern user phone numbers must match before
+// create and update requests are forwarded; nil accepts any value
+func (r *APIRoutes) SetPhonePattern(pattern *regexp.Regexp) {
+	r.phonePattern = pattern
+}
+
+// RegisterRoutes registers the user, transaction, card, payment and ETC REST endpoints
+func (r *APIRoutes) RegisterRoutes(app *fiber.App) {
+	api := app.Group("/api/v1")
+
+	// User endpoints
+	api.Get("/users", r.listUsers)
+	api.Get("/users/search", r.searchUsers)
+	api.Get("/users/:id", r.getUser)
+	api.Post("/users", r.createUser)
+	api.Put("/users/:id", r.updateUser)
+	api.Delete("/users/:id", r.deleteUser)
+
+	// Transaction endpoints
+	api.Get("/transactions", r.listTransactions)
+	api.Get("/transactions/:id", r.getTransaction)
+
+	// Card endpoints
+	api.Get("/cards", r.listCards)
+	api.Get("/cards/:id", r.getCard)
+	api.Post("/cards", r.createCard)
+	api.Put("/cards/:id", r.updateCard)
+
+	// Payment endpoints
+	api.Get("/payments", r.listPayments)
+	api.Get("/payments/statement/:userId/:year/:month/pdf", r.getStatementPDF)
+	api.Get("/payments/:id", r.getPayment)
+	api.Post("/payments", r.createPayment)
+
+	// ETC明細 endpoints
+	api.Post("/etc/meisai/bulk", r.bulkCreateETCMeisai)
+	api.Put("/etc/meisai/bulk", r.bulkUpdateETCMeisai)
+	api.Get("/etc/meisai", r.listETCMeisai)
+	api.Get("/etc/meisai/:id", r.getETCMeisai)
+	api.Put("/etc/meisai/:id", r.updateETCMeisai)
+}
+
+// User handlers
+
+// listUsers lists users, or looks up a single user when ?email= is given
+func (r *APIRoutes) listUsers(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	if email := c.Query("email"); email != "" {
+		user, err := pb.NewUserServiceClient(r.conn).GetUserByEmail(c.UserContext(), &pb.GetUserByEmailRequest{
+			Email: email,
+		})
+		if err != nil {
+			return handleGRPCError(c, err)
+		}
+		return sendProto(c, 200, user)
+	}
+
+	includeDeleted, err := r.adminQueryFlag(c, "include_deleted")
+	if err != nil {
+		return err
+	}
+
+	resp, err := pb.NewUserServiceClient(r.conn).ListUsers(c.UserContext(), &pb.ListUsersRequest{
+		PageSize:       int32(c.QueryInt("page_size")),
+		PageToken:      c.Query("page_token"),
+		IncludeDeleted: includeDeleted,
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+func (r *APIRoutes) searchUsers(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	resp, err := pb.NewUserServiceClient(r.conn).SearchUsers(c.UserContext(), &pb.SearchUsersRequest{
+		Query:     c.Query("q"),
+		PageSize:  int32(c.QueryInt("page_size")),
+		PageToken: c.Query("page_token"),
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+func (r *APIRoutes) getUser(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	includeDeleted, err := r.adminQueryFlag(c, "include_deleted")
+	if err != nil {
+		return err
+	}
+
+	resp, err := pb.NewUserServiceClient(r.conn).GetUser(c.UserContext(), &pb.GetUserRequest{
+		Id:             c.Params("id"),
+		IncludeDeleted: includeDeleted,
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+func (r *APIRoutes) createUser(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	var req pb.CreateUserRequest
+	if err := parseProto(c, &req); err != nil {
+		return invalidRequestBody(c, err)
+	}
+	if err := validation.ValidateCreateUser(&req, r.phonePattern); err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	resp, err := pb.NewUserServiceClient(r.conn).CreateUser(idempotencyContext(c), &req)
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 201, resp)
+}
+
+func (r *APIRoutes) updateUser(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	var req pb.UpdateUserRequest
+	if err := parseProto(c, &req); err != nil {
+		return invalidRequestBody(c, err)
+	}
+	req.Id = c.Params("id")
+	if err := validation.ValidateUpdateUser(&req, r.phonePattern); err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	resp, err := pb.NewUserServiceClient(r.conn).UpdateUser(c.UserContext(), &req)
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+func (r *APIRoutes) deleteUser(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	hardDelete, err := r.adminQueryFlag(c, "hard_delete")
+	if err != nil {
+		return err
+	}
+
+	_, err = pb.NewUserServiceClient(r.conn).DeleteUser(c.UserContext(), &pb.DeleteUserRequest{
+		Id:         c.Params("id"),
+		HardDelete: hardDelete,
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return c.SendStatus(204)
+}
+
+// Transaction handlers
+
+// listTransactions lists transactions filtered by ?status= (comma-separated,
+// e.g. failed,pending), ?start_date= and ?end_date= (RFC 3339) and ?card_id=
+func (r *APIRoutes) listTransactions(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	req := &pb.ListTransactionsRequest{
+		CardId:    c.Query("card_id"),
+		PageSize:  int32(c.QueryInt("page_size")),
+		PageToken: c.Query("page_token"),
+	}
+	if statuses := c.Query("status"); statuses != "" {
+		for _, name := range strings.Split(statuses, ",") {
+			paymentStatus, ok := pb.PaymentStatus_value["PAYMENT_STATUS_"+strings.ToUpper(strings.TrimSpace(name))]
+			if !ok || paymentStatus == int32(pb.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED) {
+				return restError(c, 400, fmt.Sprintf("Invalid status %q: must be pending, completed or failed", name))
+			}
+			req.PaymentStatus = append(req.PaymentStatus, pb.PaymentStatus(paymentStatus))
+		}
+	}
+	for _, date := range []struct {
+		param string
+		dest  **timestamppb.Timestamp
+	}{
+		{"start_date", &req.StartDate},
+		{"end_date", &req.EndDate},
+	} {
+		value := c.Query(date.param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return restError(c, 400, fmt.Sprintf("Invalid %s %q: must be an RFC 3339 timestamp", date.param, value))
+		}
+		*date.dest = timestamppb.New(parsed)
+	}
+
+	resp, err := pb.NewTransactionServiceClient(r.conn).ListTransactions(c.UserContext(), req)
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+func (r *APIRoutes) getTransaction(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	resp, err := pb.NewTransactionServiceClient(r.conn).GetTransaction(c.UserContext(), &pb.GetTransactionRequest{
+		Id: c.Params("id"),
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+// Card handlers
+
+func (r *APIRoutes) listCards(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	resp, err := pb.NewCardServiceClient(r.conn).ListCards(c.UserContext(), &pb.ListCardsRequest{
+		UserId:    c.Query("user_id"),
+		PageSize:  int32(c.QueryInt("page_size")),
+		PageToken: c.Query("page_token"),
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+func (r *APIRoutes) getCard(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	resp, err := pb.NewCardServiceClient(r.conn).GetCard(c.UserContext(), &pb.GetCardRequest{
+		Id: c.Params("id"),
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+func (r *APIRoutes) createCard(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	var req pb.CreateCardRequest
+	if err := parseProto(c, &req); err != nil {
+		return invalidRequestBody(c, err)
+	}
+
+	resp, err := pb.NewCardServiceClient(r.conn).CreateCard(c.UserContext(), &req)
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 201, resp)
+}
+
+func (r *APIRoutes) updateCard(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	var req pb.UpdateCardRequest
+	if err := parseProto(c, &req); err != nil {
+		return invalidRequestBody(c, err)
+	}
+	req.Id = c.Params("id")
+
+	resp, err := pb.NewCardServiceClient(r.conn).UpdateCard(c.UserContext(), &req)
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+// Payment handlers
+
+func (r *APIRoutes) listPayments(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	resp, err := pb.NewPaymentServiceClient(r.conn).ListPayments(c.UserContext(), &pb.ListPaymentsRequest{
+		UserId:    c.Query("user_id"),
+		PageSize:  int32(c.QueryInt("page_size")),
+		PageToken: c.Query("page_token"),
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+func (r *APIRoutes) getPayment(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	resp, err := pb.NewPaymentServiceClient(r.conn).GetPayment(c.UserContext(), &pb.GetPaymentRequest{
+		Id: c.Params("id"),
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+func (r *APIRoutes) createPayment(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	var req pb.CreatePaymentRequest
+	if err := parseProto(c, &req); err != nil {
+		return invalidRequestBody(c, err)
+	}
+
+	resp, err := pb.NewPaymentServiceClient(r.conn).CreatePayment(idempotencyContext(c), &req)
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 201, resp)
+}
+
+// getStatementPDF renders the user's monthly statement as a downloadable PDF
+func (r *APIRoutes) getStatementPDF(c *fiber.Ctx) error {
+	if r.conn == nil {
+		return serviceUnavailable(c)
+	}
+
+	year, yearErr := strconv.Atoi(c.Params("year"))
+	month, monthErr := strconv.Atoi(c.Params("month"))
+	if yearErr != nil || monthErr != nil {
+		return restError(c, 400, "Invalid year or month")
+	}
+
+	statement, err := pb.NewPaymentServiceClient(r.conn).GetMonthlyStatement(c.UserContext(), &pb.GetMonthlyStatementRequest{
+		UserId: c.Params("userId"),
+		Year:   int32(year),
+		Month:  int32(month),
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	data, err := renderStatementPDF(statement)
+	if err != nil {
+		return restError(c, 500, "Failed to render statement")
+	}
+
+	c.Set(fiber.HeaderContentType, StatementPDFContentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+statementFilename(statement)+`"`)
+	return c.Status(200).SendStream(bytes.NewReader(data), len(data))
+}
+
+// ETC明細 handlers
+
+// listETCMeisai lists records, narrowed by ?search= (a case-insensitive
+// substring of the IC names or car number), the date range and user_id
+func (r *APIRoutes) listETCMeisai(c *fiber.Ctx) error {
+	if r.etcConn == nil {
+		return serviceUnavailable(c)
+	}
+
+	resp, err := pb.NewETCServiceClient(r.etcConn).ListETCMeisai(c.UserContext(), &pb.ListETCMeisaiRequest{
+		PageSize:  int32(c.QueryInt("page_size")),
+		PageToken: c.Query("page_token"),
+		Search:    c.Query("search"),
+		StartDate: c.Query("start_date"),
+		EndDate:   c.Query("end_date"),
+		UserId:    c.Query("user_id"),
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, 200, resp)
+}
+
+// getETCMeisai returns a single record with an ETag and answers 304 when the
+// client's If-None-Match still matches
+func (r *APIRoutes) getETCMeisai(c *fiber.Ctx) error {
+	if r.etcConn == nil {
+		return serviceUnavailable(c)
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return restError(c, 400, "Invalid ID format")
+	}
+
+	resp, err := pb.NewETCServiceClient(r.etcConn).GetETCMeisai(c.UserContext(), &pb.GetETCMeisaiRequest{
+		Id: id,
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	etag := recordETag(resp.EtcMeisai.GetHash(), resp.EtcMeisai.GetUpdatedAt())
+	c.Set(fiber.HeaderETag, etag)
+	if ifNoneMatch := c.Get(fiber.HeaderIfNoneMatch); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+		return c.SendStatus(304)
+	}
+
+	return sendProto(c, 200, resp.EtcMeisai)
+}
+
+func (r *APIRoutes) updateETCMeisai(c *fiber.Ctx) error {
+	if r.etcConn == nil {
+		return serviceUnavailable(c)
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return restError(c, 400, "Invalid ID format")
+	}
+
+	var etcMeisai pb.ETCMeisai
+	if err := parseProto(c, &etcMeisai); err != nil {
+		return invalidRequestBody(c, err)
+	}
+
+	resp, err := pb.NewETCServiceClient(r.etcConn).UpdateETCMeisai(c.UserContext(), &pb.UpdateETCMeisaiRequest{
+		Id:        id,
+		EtcMeisai: &etcMeisai,
+	})
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	c.Set(fiber.HeaderETag, recordETag(resp.EtcMeisai.GetHash(), resp.EtcMeisai.GetUpdatedAt()))
+	return sendProto(c, 200, resp.EtcMeisai)
+}
+
+func (r *APIRoutes) bulkCreateETCMeisai(c *fiber.Ctx) error {
+	if r.etcConn == nil {
+		return serviceUnavailable(c)
+	}
+
+	var req pb.BulkCreateETCMeisaiRequest
+	if err := parseProto(c, &req); err != nil {
+		return invalidRequestBody(c, err)
+	}
+
+	resp, err := pb.NewETCServiceClient(r.etcConn).BulkCreateETCMeisai(c.UserContext(), &req)
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, r.bulkStatus(resp.SuccessCount, resp.ErrorCount, 201), resp)
+}
+
+func (r *APIRoutes) bulkUpdateETCMeisai(c *fiber.Ctx) error {
+	if r.etcConn == nil {
+		return serviceUnavailable(c)
+	}
+
+	var req pb.BulkUpdateETCMeisaiRequest
+	if err := parseProto(c, &req); err != nil {
+		return invalidRequestBody(c, err)
+	}
+
+	resp, err := pb.NewETCServiceClient(r.etcConn).BulkUpdateETCMeisai(c.UserContext(), &req)
+	if err != nil {
+		return handleGRPCError(c, err)
+	}
+
+	return sendProto(c, r.bulkStatus(resp.SuccessCount, resp.ErrorCount, 200), resp)
+}
+
+// bulkStatus returns okStatus when every row succeeded, 400 when every row
+// failed and 207 Multi-Status for a mix
+func (r *APIRoutes) bulkStatus(successCount, errorCount int32, okStatus int) int {
+	if !r.multiStatus || errorCount == 0 {
+		return okStatus
+	}
+	if successCount == 0 {
+		return 400
+	}
+	return 207
+}
+
+// idempotencyContext forwards the request's Idempotency-Key header, if any,
+// to the gRPC service as metadata
+func idempotencyContext(c *fiber.Ctx) context.Context {
+	ctx := c.UserContext()
+	if key := c.Get(IdempotencyKeyHeader); key != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, services.IdempotencyKeyMetadataKey, key)
+	}
+	return ctx
+}
+
+func serviceUnavailable(c *fiber.Ctx) error {
+	return restError(c, 503, "Service unavailable")
+}
+
+// invalidRequestBody answers a body parseProto rejected: 415 for an
+// unsupported Content-Type, otherwise 400
+func invalidRequestBody(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errUnsupportedContentType) {
+		return restError(c, 415, "Unsupported Content-Type: send application/json or "+MIMEApplicationProtobuf)
+	}
+	return restError(c, 400, "Invalid request body")
+}
\ No newline at end of file
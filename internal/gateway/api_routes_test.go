@@ -0,0 +1,485 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+func newInitializedGateway(t *testing.T) *fiber.App {
+	t.Helper()
+
+	gw := NewSimpleGateway(newTestConfig("single"))
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+	return gw.GetHTTPHandler()
+}
+
+func TestUserRoundTripProtobuf(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	body, err := proto.Marshal(&pb.CreateUserRequest{
+		Email: "proto@example.com",
+		Name:  "Proto User",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/api/v1/users", bytes.NewReader(body))
+	req.Header.Set("Content-Type", MIMEApplicationProtobuf)
+	req.Header.Set("Accept", MIMEApplicationProtobuf)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	assert.Equal(t, MIMEApplicationProtobuf, resp.Header.Get("Content-Type"))
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var created pb.User
+	require.NoError(t, proto.Unmarshal(data, &created))
+	assert.NotEmpty(t, created.Id)
+	assert.Equal(t, "proto@example.com", created.Email)
+	assert.Equal(t, "Proto User", created.Name)
+
+	// Fetch the same user back in protobuf form
+	req = httptest.NewRequest("GET", "/api/v1/users/"+created.Id, nil)
+	req.Header.Set("Accept", MIMEApplicationProtobuf)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	data, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var fetched pb.User
+	require.NoError(t, proto.Unmarshal(data, &fetched))
+	assert.True(t, proto.Equal(&created, &fetched))
+}
+
+func TestUserDefaultsToJSON(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/users", bytes.NewReader([]byte(`{"email": "json@example.com", "name": "JSON User"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	assert.Equal(t, fiber.MIMEApplicationJSON, resp.Header.Get("Content-Type"))
+
+	var user map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&user))
+	assert.Equal(t, "json@example.com", user["email"])
+	assert.Equal(t, "JSON User", user["name"])
+}
+
+func TestUserInvalidProtobufBody(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/users", bytes.NewReader([]byte{0xff, 0xff, 0xff}))
+	req.Header.Set("Content-Type", MIMEApplicationProtobuf)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestETCMeisaiConditionalGet(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	get := func(ifNoneMatch string) *http.Response {
+		req := httptest.NewRequest("GET", "/api/v1/etc/meisai/1", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := get("")
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	var record map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&record))
+
+	// Unchanged record
+	resp = get(etag)
+	assert.Equal(t, fiber.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Empty(t, body)
+
+	// Updating the record invalidates the ETag
+	record["car_number"] = "品川 500 あ 9999"
+	update, err := json.Marshal(record)
+	require.NoError(t, err)
+	req := httptest.NewRequest("PUT", "/api/v1/etc/meisai/1", bytes.NewReader(update))
+	req.Header.Set("Content-Type", "application/json")
+	updateResp, err := app.Test(req)
+	require.NoError(t, err)
+	defer updateResp.Body.Close()
+	require.Equal(t, fiber.StatusOK, updateResp.StatusCode)
+
+	resp = get(etag)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+	assert.Equal(t, updateResp.Header.Get("ETag"), resp.Header.Get("ETag"))
+}
+
+func TestETagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`"xyz", W/"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`*`, `"abc"`))
+	assert.False(t, etagMatches(`"abd"`, `"abc"`))
+}
+
+func TestBulkCreateETCMeisaiStatus(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	post := func(body string) *http.Response {
+		req := httptest.NewRequest("POST", "/api/v1/etc/meisai/bulk", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("mixed", func(t *testing.T) {
+		resp := post(`{"etc_meisai_list": [
+			{"date": "2024-02-01", "car_number": "bulk ok", "toll_amount": 1200, "final_amount": 1200},
+			{"date": "2024-02-01", "car_number": "bulk bad", "toll_amount": -100}
+		]}`)
+		require.Equal(t, fiber.StatusMultiStatus, resp.StatusCode)
+
+		var result struct {
+			Created       []map[string]interface{} `json:"created_etc_meisai_list"`
+			SuccessCount  int                      `json:"success_count"`
+			ErrorCount    int                      `json:"error_count"`
+			ErrorMessages []string                 `json:"error_messages"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, 1, result.SuccessCount)
+		assert.Equal(t, 1, result.ErrorCount)
+		require.Len(t, result.Created, 1)
+		assert.Equal(t, "bulk ok", result.Created[0]["car_number"])
+		require.Len(t, result.ErrorMessages, 1)
+		assert.Contains(t, result.ErrorMessages[0], "row 1:")
+	})
+
+	t.Run("all succeed", func(t *testing.T) {
+		resp := post(`{"etc_meisai_list": [{"date": "2024-02-02", "toll_amount": 800, "final_amount": 800}]}`)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("all fail", func(t *testing.T) {
+		resp := post(`{"etc_meisai_list": [{"date": "2024-02-03", "toll_amount": -1}]}`)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestGetUserByEmailREST(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/users?email=John.Doe@EXAMPLE.com", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var user map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&user))
+	assert.Equal(t, "john.doe@example.com", user["email"])
+
+	missing, err := app.Test(httptest.NewRequest("GET", "/api/v1/users?email=nobody@example.com", nil))
+	require.NoError(t, err)
+	defer missing.Body.Close()
+	assert.Equal(t, fiber.StatusNotFound, missing.StatusCode)
+}
+
+func TestSoftDeletedUserREST(t *testing.T) {
+	app := newAdminGateway(t)
+
+	createReq := httptest.NewRequest("POST", "/api/v1/users",
+		bytes.NewReader([]byte(`{"email": "rest-soft@example.com", "name": "REST Soft"}`)))
+	createReq.Header.Set("Content-Type", "application/json")
+	createResp, err := app.Test(createReq)
+	require.NoError(t, err)
+	defer createResp.Body.Close()
+	var created map[string]interface{}
+	require.NoError(t, json.NewDecoder(createResp.Body).Decode(&created))
+	id := created["id"].(string)
+
+	deleteResp, err := app.Test(httptest.NewRequest("DELETE", "/api/v1/users/"+id, nil))
+	require.NoError(t, err)
+	defer deleteResp.Body.Close()
+	require.Equal(t, fiber.StatusNoContent, deleteResp.StatusCode)
+
+	getResp, err := app.Test(httptest.NewRequest("GET", "/api/v1/users/"+id, nil))
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+	assert.Equal(t, fiber.StatusNotFound, getResp.StatusCode)
+
+	// include_deleted is admin-only
+	code, _ := doRequest(t, app, "GET", "/api/v1/users/"+id+"?include_deleted=true", "")
+	assert.Equal(t, fiber.StatusUnauthorized, code)
+	code, _ = doRequest(t, app, "GET", "/api/v1/users?include_deleted=true", "")
+	assert.Equal(t, fiber.StatusUnauthorized, code)
+
+	code, user := doAdminRequest(t, app, "GET", "/api/v1/users/"+id+"?include_deleted=true", "")
+	require.Equal(t, fiber.StatusOK, code)
+	assert.Equal(t, "USER_STATUS_DELETED", user["status"])
+	assert.NotEmpty(t, user["deleted_at"])
+
+	// So is hard_delete; a rejected request leaves the user in place
+	code, _ = doRequest(t, app, "DELETE", "/api/v1/users/"+id+"?hard_delete=true", "")
+	assert.Equal(t, fiber.StatusUnauthorized, code)
+	code, _ = doAdminRequest(t, app, "GET", "/api/v1/users/"+id+"?include_deleted=true", "")
+	assert.Equal(t, fiber.StatusOK, code)
+
+	code, _ = doAdminRequest(t, app, "DELETE", "/api/v1/users/"+id+"?hard_delete=true", "")
+	require.Equal(t, fiber.StatusNoContent, code)
+	code, _ = doAdminRequest(t, app, "GET", "/api/v1/users/"+id+"?include_deleted=true", "")
+	assert.Equal(t, fiber.StatusNotFound, code)
+}
+
+func TestAdminQueryFlagsDisabledWithoutToken(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	for _, request := range []struct{ method, path string }{
+		{"GET", "/api/v1/users?include_deleted=true"},
+		{"GET", "/api/v1/users/user-1?include_deleted=true"},
+		{"DELETE", "/api/v1/users/user-1?hard_delete=true"},
+	} {
+		code, body := doRequest(t, app, request.method, request.path, "")
+		assert.Equal(t, fiber.StatusServiceUnavailable, code, request.path)
+		assert.Equal(t, "UNAVAILABLE", body["error"].(map[string]interface{})["code"], request.path)
+	}
+
+	// Without the flags the routes stay public
+	code, _ := doRequest(t, app, "GET", "/api/v1/users?include_deleted=false", "")
+	assert.Equal(t, fiber.StatusOK, code)
+}
+
+func TestSearchUsersREST(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/users/search?q=smith", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Users []map[string]interface{} `json:"users"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, "jane.smith@example.com", result.Users[0]["email"])
+
+	empty, err := app.Test(httptest.NewRequest("GET", "/api/v1/users/search", nil))
+	require.NoError(t, err)
+	defer empty.Body.Close()
+	assert.Equal(t, fiber.StatusBadRequest, empty.StatusCode)
+}
+
+func TestCreateWithIdempotencyKeyREST(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	post := func(path, key, body string) (int, map[string]interface{}) {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(IdempotencyKeyHeader, key)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+
+	t.Run("payment", func(t *testing.T) {
+		body := `{"user_id":"user-001","total_amount":1200,"payment_method":"PAYMENT_METHOD_CREDIT_CARD"}`
+		status, first := post("/api/v1/payments", "pay-key-1", body)
+		require.Equal(t, fiber.StatusCreated, status)
+
+		status, repeat := post("/api/v1/payments", "pay-key-1", body)
+		require.Equal(t, fiber.StatusCreated, status)
+		assert.Equal(t, first["id"], repeat["id"], "a repeated key and body should replay the original payment")
+
+		status, _ = post("/api/v1/payments", "pay-key-1", `{"user_id":"user-001","total_amount":9999,"payment_method":"PAYMENT_METHOD_CREDIT_CARD"}`)
+		assert.Equal(t, fiber.StatusConflict, status)
+
+		status, other := post("/api/v1/payments", "pay-key-2", body)
+		require.Equal(t, fiber.StatusCreated, status)
+		assert.NotEqual(t, first["id"], other["id"])
+	})
+
+	t.Run("user", func(t *testing.T) {
+		body := `{"email":"idempotent@example.com","name":"Idempotent"}`
+		status, first := post("/api/v1/users", "user-key-1", body)
+		require.Equal(t, fiber.StatusCreated, status)
+
+		// Without the key the duplicate email would be rejected
+		status, repeat := post("/api/v1/users", "user-key-1", body)
+		require.Equal(t, fiber.StatusCreated, status)
+		assert.Equal(t, first["id"], repeat["id"])
+
+		status, _ = post("/api/v1/users", "user-key-1", `{"email":"someone-else@example.com","name":"Other"}`)
+		assert.Equal(t, fiber.StatusConflict, status)
+	})
+}
+
+func TestListTransactionsByStatus(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	status, body := doRequest(t, app, "GET", "/api/v1/transactions?status=failed,pending", "")
+	require.Equal(t, fiber.StatusOK, status)
+	transactions, _ := body["transactions"].([]interface{})
+	for _, item := range transactions {
+		tx := item.(map[string]interface{})
+		assert.Contains(t, []interface{}{"PAYMENT_STATUS_FAILED", "PAYMENT_STATUS_PENDING"}, tx["payment_status"], "transaction %v", tx["id"])
+	}
+
+	status, body = doRequest(t, app, "GET", "/api/v1/transactions?status=completed&card_id=card-1", "")
+	require.Equal(t, fiber.StatusOK, status)
+	transactions, _ = body["transactions"].([]interface{})
+	require.NotEmpty(t, transactions)
+	for _, item := range transactions {
+		tx := item.(map[string]interface{})
+		assert.Equal(t, "PAYMENT_STATUS_COMPLETED", tx["payment_status"])
+		assert.Equal(t, "card-1", tx["card_id"])
+	}
+
+	status, _ = doRequest(t, app, "GET", "/api/v1/transactions?status=refunded", "")
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	status, _ = doRequest(t, app, "GET", "/api/v1/transactions?start_date=yesterday", "")
+	assert.Equal(t, fiber.StatusBadRequest, status)
+}
+
+func TestUserFormatValidationAcrossProtocols(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	code, body := doRequest(t, app, "POST", "/api/v1/users", `{"email": "a@", "name": "Bad Email"}`)
+	assert.Equal(t, fiber.StatusBadRequest, code)
+	assert.Equal(t, "INVALID_ARGUMENT", body["error"].(map[string]interface{})["code"])
+
+	code, _ = doRequest(t, app, "POST", "/api/v1/users", `{"email": "phone@example.com", "name": "Bad Phone", "phone_number": "12-34"}`)
+	assert.Equal(t, fiber.StatusBadRequest, code)
+
+	code, _ = doRequest(t, app, "POST", "/api/v1/users", `{"email": "phone@example.com", "name": "Good Phone", "phone_number": "090-1234-5678"}`)
+	assert.Equal(t, fiber.StatusCreated, code)
+
+	_, body = doRequest(t, app, "POST", "/jsonrpc", `{"jsonrpc": "2.0", "method": "user.create", "params": {"email": "rpc@example.com", "name": "Bad Phone", "phone_number": "abc"}, "id": 1}`)
+	require.NotNil(t, body["error"])
+	assert.Equal(t, float64(JSONRPCInvalidParams), body["error"].(map[string]interface{})["code"])
+}
+
+func TestListPageSizeClampedToConfig(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Server.MaxPageSize = 2
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	code, body := doRequest(t, gw.GetHTTPHandler(), "GET", "/api/v1/users?page_size=1000", "")
+	require.Equal(t, fiber.StatusOK, code)
+	assert.Equal(t, float64(2), body["page_size"])
+	assert.Len(t, body["users"], 2)
+	assert.NotEmpty(t, body["next_page_token"])
+}
+
+func TestUpdateMaskPathsLimitedByConfig(t *testing.T) {
+	update := `{"name": "Masked", "address": "Tokyo", "update_mask": "name,address"}`
+
+	// The default limit allows both paths, so the unknown user is reported
+	code, _ := doRequest(t, newInitializedGateway(t), "PUT", "/api/v1/users/no-such-user", update)
+	assert.Equal(t, fiber.StatusNotFound, code)
+
+	cfg := newTestConfig("single")
+	cfg.Server.MaxFieldMaskPaths = 1
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	code, body := doRequest(t, gw.GetHTTPHandler(), "PUT", "/api/v1/users/no-such-user", update)
+	require.Equal(t, fiber.StatusBadRequest, code)
+	assert.Equal(t, "INVALID_ARGUMENT", body["error"].(map[string]interface{})["code"])
+}
+
+func TestBulkBatchSizeLimitedByConfig(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Server.MaxBatchSize = 2
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	code, body := doRequest(t, gw.GetHTTPHandler(), "POST", "/api/v1/etc/meisai/bulk", `{"etc_meisai_list": [
+		{"date": "2030-06-01", "car_number": "batch 1", "entrance_ic": "東名川崎", "exit_ic": "厚木"},
+		{"date": "2030-06-02", "car_number": "batch 2", "entrance_ic": "東名川崎", "exit_ic": "厚木"},
+		{"date": "2030-06-03", "car_number": "batch 3", "entrance_ic": "東名川崎", "exit_ic": "厚木"}
+	]}`)
+	require.Equal(t, fiber.StatusBadRequest, code)
+	assert.Equal(t, "batch size 3 exceeds maximum of 2", body["error"].(map[string]interface{})["message"])
+}
+
+func TestCardNumberExposedByConfig(t *testing.T) {
+	create := `{"user_id": "exposed-card-user", "card_number": "1234-5678-9012-3456", "vehicle_type": 1}`
+
+	code, body := doRequest(t, newInitializedGateway(t), "POST", "/api/v1/cards", create)
+	require.Equal(t, fiber.StatusCreated, code)
+	assert.Empty(t, body["card_number"])
+	assert.Equal(t, "****-****-****-3456", body["card_number_masked"])
+	assert.Equal(t, "3456", body["card_last4"])
+
+	cfg := newTestConfig("single")
+	cfg.Server.ExposeCardNumbers = true
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	code, body = doRequest(t, gw.GetHTTPHandler(), "POST", "/api/v1/cards", create)
+	require.Equal(t, fiber.StatusCreated, code)
+	assert.Equal(t, "1234-5678-9012-3456", body["card_number"])
+	assert.Equal(t, "3456", body["card_last4"])
+}
+
+func TestListETCMeisaiSearchREST(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	code, _ := doRequest(t, app, "POST", "/api/v1/etc/meisai/bulk", `{"etc_meisai_list": [
+		{"date": "2030-04-01", "car_number": "search 1", "entrance_ic": "東名川崎", "exit_ic": "厚木"},
+		{"date": "2030-04-02", "car_number": "search 2", "entrance_ic": "横浜町田", "exit_ic": "東名江田"},
+		{"date": "2030-05-01", "car_number": "search 3", "entrance_ic": "東名川崎", "exit_ic": "沼津"},
+		{"date": "2030-04-03", "car_number": "search 4", "entrance_ic": "中央道 八王子", "exit_ic": "大月"}
+	]}`)
+	require.Equal(t, fiber.StatusCreated, code)
+
+	code, body := doRequest(t, app, "GET", "/api/v1/etc/meisai?search="+url.QueryEscape("東名")+"&start_date=2030-04-01&end_date=2030-04-30", "")
+	require.Equal(t, fiber.StatusOK, code)
+	require.Len(t, body["etc_meisai_list"], 2)
+	var cars []interface{}
+	for _, record := range body["etc_meisai_list"].([]interface{}) {
+		cars = append(cars, record.(map[string]interface{})["car_number"])
+	}
+	assert.Equal(t, []interface{}{"search 1", "search 2"}, cars)
+	assert.Equal(t, float64(2), body["total_count"])
+}
\ No newline at end of file
@@ -0,0 +1,63 @@
+package gateway
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/audit"
+)
+
+// LegacyAuditPath is where the audit log was served before it moved to
+// /admin/audit. It still answers, behind the admin token, with a Deprecation
+// header and a Link to the new path.
+const LegacyAuditPath = "/api/v1/audit"
+
+// AuditRoutes handles REST routes for the audit log, served under the
+// authenticated /admin group
+type AuditRoutes struct {
+	log *audit.Log
+}
+
+// NewAuditRoutes creates a new audit route handler
+func NewAuditRoutes(log *audit.Log) *AuditRoutes {
+	return &AuditRoutes{
+		log: log,
+	}
+}
+
+// RegisterRoutes registers the audit query endpoint on the admin group
+func (r *AuditRoutes) RegisterRoutes(admin fiber.Router) {
+	admin.Get("/audit", r.queryAudit)
+}
+
+// queryAuditDeprecated serves LegacyAuditPath
+func (r *AuditRoutes) queryAuditDeprecated(c *fiber.Ctx) error {
+	c.Set("Deprecation", "true")
+	c.Append(fiber.HeaderLink, `</admin/audit>; rel="successor-version"`)
+	return r.queryAudit(c)
+}
+
+func (r *AuditRoutes) queryAudit(c *fiber.Ctx) error {
+	if r.log == nil {
+		return restError(c, 503, "Service unavailable")
+	}
+
+	pageSize := c.QueryInt("page_size", 0)
+	if pageSize < 0 {
+		return restError(c, 400, "Invalid page_size")
+	}
+
+	result, err := r.log.Query(audit.QueryOptions{
+		EntityType: c.Query("entity"),
+		PageSize:   pageSize,
+		PageToken:  c.Query("page_token"),
+	})
+	if errors.Is(err, audit.ErrInvalidPageToken) {
+		return restError(c, 400, "Invalid page_token")
+	}
+	if err != nil {
+		return restError(c, 500, "Internal server error")
+	}
+
+	return c.JSON(result)
+}
@@ -1,11 +1,17 @@
 package gateway
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"net/http/httptest"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/gofiber/fiber/v2"
 )
 
 // BenchmarkResult holds the results of a performance benchmark
@@ -63,37 +69,41 @@ func (lt *LatencyTracker) GetStats() (min, max, avg, p50, p95, p99 time.Duration
 		return 0, 0, 0, 0, 0, 0
 	}
 
-	// Sort latencies for percentile calculations
+	// Copy the latencies, summing them for the average in the same pass
 	sorted := make([]time.Duration, len(lt.latencies))
-	copy(sorted, lt.latencies)
-
-	// Simple bubble sort for small datasets (optimize for production)
-	for i := 0; i < len(sorted); i++ {
-		for j := 0; j < len(sorted)-1-i; j++ {
-			if sorted[j] > sorted[j+1] {
-				sorted[j], sorted[j+1] = sorted[j+1], sorted[j]
-			}
-		}
-	}
-
-	min = sorted[0]
-	max = sorted[len(sorted)-1]
-
-	// Calculate average
 	var total time.Duration
-	for _, lat := range sorted {
+	for i, lat := range lt.latencies {
+		sorted[i] = lat
 		total += lat
 	}
 	avg = total / time.Duration(len(sorted))
 
+	// Sort latencies for percentile calculations
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	min = sorted[0]
+	max = sorted[len(sorted)-1]
+
 	// Calculate percentiles
-	p50 = sorted[len(sorted)*50/100]
-	p95 = sorted[len(sorted)*95/100]
-	p99 = sorted[len(sorted)*99/100]
+	p50 = sorted[percentileIndex(len(sorted), 50)]
+	p95 = sorted[percentileIndex(len(sorted), 95)]
+	p99 = sorted[percentileIndex(len(sorted), 99)]
 
 	return min, max, avg, p50, p95, p99
 }
 
+// percentileIndex returns the index of the given percentile in a sorted
+// slice of length n, clamped to the last element
+func percentileIndex(n, percentile int) int {
+	idx := n * percentile / 100
+	if idx > n-1 {
+		idx = n - 1
+	}
+	return idx
+}
+
 // PerformanceBenchmark runs performance tests on the gateway
 type PerformanceBenchmark struct {
 	gateway    Gateway
@@ -104,7 +114,7 @@ type PerformanceBenchmark struct {
 
 // Gateway interface for benchmarking
 type Gateway interface {
-	GetHTTPHandler() interface{}
+	GetHTTPHandler() *fiber.App
 	GetPerformanceStats() map[string]interface{}
 }
 
@@ -120,6 +130,10 @@ func NewPerformanceBenchmark(gateway Gateway, config *BenchmarkConfig) *Performa
 
 // Run executes the performance benchmark
 func (pb *PerformanceBenchmark) Run(ctx context.Context) (*BenchmarkResult, error) {
+	if pb.gateway.GetHTTPHandler() == nil {
+		return nil, fmt.Errorf("gateway has no HTTP handler")
+	}
+
 	// Warmup phase
 	if pb.config.WarmupTime > 0 {
 		fmt.Printf("Warming up for %v...\n", pb.config.WarmupTime)
@@ -183,7 +197,6 @@ func (pb *PerformanceBenchmark) worker(ctx context.Context, wg *sync.WaitGroup,
 		default:
 			start := time.Now()
 
-			// Simulate request based on configuration
 			success := pb.makeRequest()
 
 			latency := time.Since(start)
@@ -198,14 +211,27 @@ func (pb *PerformanceBenchmark) worker(ctx context.Context, wg *sync.WaitGroup,
 	}
 }
 
-// makeRequest simulates making a request to the gateway
+// makeRequest sends the configured request to the gateway's HTTP handler and
+// reports whether it succeeded with a non-error status
 func (pb *PerformanceBenchmark) makeRequest() bool {
-	// This would normally make an actual HTTP request
-	// For now, simulate request processing time
-	time.Sleep(time.Microsecond * time.Duration(100 + (time.Now().UnixNano() % 1000)))
+	method := pb.config.Method
+	if method == "" {
+		method = fiber.MethodGet
+	}
+
+	req := httptest.NewRequest(method, pb.config.Endpoint, bytes.NewReader(pb.config.Payload))
+	for key, value := range pb.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := pb.gateway.GetHTTPHandler().Test(req, -1)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
 
-	// Simulate 95% success rate
-	return time.Now().UnixNano() % 100 < 95
+	return resp.StatusCode < 400
 }
 
 // GetResults returns the current benchmark results
@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+)
+
+const (
+	// DefaultMaxBodyBytes is the request body limit for routes without an override
+	DefaultMaxBodyBytes = 1 << 20 // 1MB
+	// DefaultBulkBodyBytes is the request body limit for bulk ETC明細 create
+	DefaultBulkBodyBytes = 16 << 20 // 16MB
+	// BulkETCMeisaiPath is the REST path for bulk ETC明細 create
+	BulkETCMeisaiPath = "/api/v1/etc/meisai/bulk"
+)
+
+// BodyLimits holds the global request body limit and per-path overrides
+type BodyLimits struct {
+	Default int
+	// Overrides maps a path prefix to its limit; the longest matching prefix wins
+	Overrides map[string]int
+}
+
+// bodyLimitsFromConfig builds body limits from configuration, filling in defaults
+func bodyLimitsFromConfig(cfg config.ServerConfig) BodyLimits {
+	limits := BodyLimits{
+		Default: cfg.MaxBodyBytes,
+		Overrides: map[string]int{
+			BulkETCMeisaiPath: DefaultBulkBodyBytes,
+		},
+	}
+	if limits.Default <= 0 {
+		limits.Default = DefaultMaxBodyBytes
+	}
+	for path, limit := range cfg.BodyLimits {
+		limits.Overrides[path] = limit
+	}
+	return limits
+}
+
+// LimitFor returns the body limit that applies to path
+func (l BodyLimits) LimitFor(path string) int {
+	limit := l.Default
+	matched := ""
+	for prefix, override := range l.Overrides {
+		if strings.HasPrefix(path, prefix) && len(prefix) > len(matched) {
+			matched = prefix
+			limit = override
+		}
+	}
+	return limit
+}
+
+// Max returns the largest configured limit, used as the server-wide cap.
+// Bodies above it are rejected by the server before reaching the middleware.
+func (l BodyLimits) Max() int {
+	max := l.Default
+	for _, limit := range l.Overrides {
+		if limit > max {
+			max = limit
+		}
+	}
+	return max
+}
+
+// newBodyLimitMiddleware rejects requests whose body exceeds the limit for
+// their path with 413. Content-Length is checked first; the received body is
+// checked as well for chunked requests that don't declare a length.
+func newBodyLimitMiddleware(limits BodyLimits) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		limit := limits.LimitFor(c.Path())
+		if limit <= 0 {
+			return c.Next()
+		}
+
+		if c.Request().Header.ContentLength() > limit || len(c.Body()) > limit {
+			return restError(c, fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Request body too large: the limit is %d bytes", limit))
+		}
+
+		return c.Next()
+	}
+}
\ No newline at end of file
@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLimitRejectsOversizedBody(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Server.MaxBodyBytes = 1024
+	cfg.Server.BodyLimits = map[string]int{BulkETCMeisaiPath: 8192}
+	app := NewSimpleGateway(cfg).GetHTTPHandler()
+
+	// Over the default limit but under the bulk limit, which is the server-wide cap
+	oversized := `{"name": "` + strings.Repeat("x", 4*1024) + `"}`
+
+	t.Run("declared content length", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/jsonrpc", strings.NewReader(oversized))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, fiber.StatusRequestEntityTooLarge, resp.StatusCode)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		errorObj := body["error"].(map[string]interface{})
+		assert.Equal(t, "INVALID_ARGUMENT", errorObj["code"])
+		assert.Equal(t, "Request body too large: the limit is 1024 bytes", errorObj["message"])
+	})
+
+	t.Run("chunked body without content length", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/jsonrpc", strings.NewReader(oversized))
+		req.Header.Set("Content-Type", "application/json")
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, fiber.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	t.Run("bulk path allows a larger body", func(t *testing.T) {
+		req := httptest.NewRequest("POST", BulkETCMeisaiPath, strings.NewReader(oversized))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.NotEqual(t, fiber.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+}
+
+func TestBodyLimitsLongestPrefixWins(t *testing.T) {
+	limits := BodyLimits{
+		Default: 100,
+		Overrides: map[string]int{
+			"/api/v1/etc/meisai":      200,
+			"/api/v1/etc/meisai/bulk": 300,
+		},
+	}
+
+	assert.Equal(t, 100, limits.LimitFor("/api/v1/users"))
+	assert.Equal(t, 200, limits.LimitFor("/api/v1/etc/meisai/42"))
+	assert.Equal(t, 300, limits.LimitFor("/api/v1/etc/meisai/bulk"))
+	assert.Equal(t, 300, limits.Max())
+}
+
+func TestGzipBodyCappedAtBodyLimit(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Server.MaxBodyBytes = 1024
+	cfg.Monitoring.MetricsEnabled = true
+	cfg.Monitoring.DecompressRequests = true
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+	app := gw.GetHTTPHandler()
+
+	gzipped := func(payload string) *bytes.Buffer {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write([]byte(payload))
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		return &buf
+	}
+	post := func(body *bytes.Buffer) int {
+		req := httptest.NewRequest("POST", "/jsonrpc", body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("small body is decompressed", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, post(gzipped(`{"jsonrpc": "2.0", "method": "user.list", "params": {}, "id": 1}`)))
+	})
+
+	t.Run("body expanding past the limit is rejected", func(t *testing.T) {
+		bomb := gzipped(`{"name": "` + strings.Repeat("x", 256<<10) + `"}`)
+		require.Less(t, bomb.Len(), 1024)
+		assert.Equal(t, fiber.StatusRequestEntityTooLarge, post(bomb))
+	})
+}
\ No newline at end of file
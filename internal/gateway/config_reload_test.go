@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+)
+
+func TestRestartRequiredChanges(t *testing.T) {
+	running := newTestConfig("single")
+	assert.Empty(t, restartRequiredChanges(running, newTestConfig("single"), simpleHotSwappable))
+
+	reloaded := newTestConfig("single")
+	reloaded.Server.DisableMultiStatus = true
+	reloaded.Diagnostics.MaxConcurrency = 8
+	reloaded.Monitoring.MetricsEnabled = !running.Monitoring.MetricsEnabled
+	reloaded.Logging.Format = "console"
+	reloaded.Logging.FilePath = "/var/log/gateway.log"
+	reloaded.Redis.URL = "redis://cache:6379"
+	reloaded.External.DBServiceTLS.CAFile = "/etc/ca.pem"
+	// Hot-swappable settings are not reported
+	reloaded.Logging.Level = "debug"
+	reloaded.Performance.RateLimit = 10
+	reloaded.Performance.CacheDuration = time.Second
+
+	restartRequired := []string{
+		"server.disable_multi_status",
+		"diagnostics.max_concurrency",
+		"monitoring.metrics_enabled",
+		"logging.format",
+		"logging.file_path",
+		"redis.url",
+		"external.db_service_tls.ca_file",
+	}
+	assert.ElementsMatch(t, restartRequired, restartRequiredChanges(running, reloaded, optimizedHotSwappable))
+
+	// SimpleGateway has no rate limiter or response cache to update
+	assert.ElementsMatch(t, append(restartRequired, "performance.rate_limit", "performance.cache_duration"),
+		restartRequiredChanges(running, reloaded, simpleHotSwappable))
+}
+
+func TestOptimizedGatewayApplyConfig(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Logging.Level = "info"
+	cfg.Performance.RateLimit = 2
+	perfConfig := DefaultPerformanceConfig()
+	perfConfig.EnableCompression = false
+	perfConfig.EnableCaching = false
+	gw := NewOptimizedGateway(cfg, perfConfig)
+	t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+	gw.app.Get("/limited", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	status := func() int {
+		resp, err := gw.app.Test(httptest.NewRequest("GET", "/limited", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, status())
+
+	// Reloading without a rate limit change keeps the counters
+	unchanged := *cfg
+	require.NoError(t, gw.ApplyConfig(&unchanged))
+	assert.Equal(t, fiber.StatusOK, status())
+	assert.Equal(t, fiber.StatusTooManyRequests, status(), "the configured limit of 2 should still apply")
+
+	reloaded := *cfg
+	reloaded.Performance.RateLimit = 5
+	reloaded.Performance.CacheDuration = 42 * time.Second
+	require.NoError(t, gw.ApplyConfig(&reloaded))
+	assert.Equal(t, fiber.StatusOK, status(), "counters restart with the new limit")
+	assert.Equal(t, 42*time.Second, gw.responseCache.duration)
+}
+
+func TestPerformanceConfigWithConfig(t *testing.T) {
+	defaults := DefaultPerformanceConfig()
+
+	merged := defaults.withConfig(config.PerformanceConfig{})
+	assert.Equal(t, defaults.RateLimit, merged.RateLimit)
+	assert.Equal(t, defaults.CacheDuration, merged.CacheDuration)
+
+	merged = defaults.withConfig(config.PerformanceConfig{RateLimit: 7, CacheDuration: time.Minute})
+	assert.Equal(t, 7, merged.RateLimit)
+	assert.Equal(t, time.Minute, merged.CacheDuration)
+	assert.Equal(t, 1000, defaults.RateLimit, "the base config is not modified")
+}
\ No newline at end of file
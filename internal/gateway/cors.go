@@ -0,0 +1,49 @@
+package gateway
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+)
+
+// newCORSMiddleware builds the CORS middleware from configuration. It returns
+// nil when no origins are configured so browsers enforce the same-origin policy.
+// The gRPC-Web headers are always allowed and exposed so browsers can call /grpc,
+// and Idempotency-Key is always allowed so browsers can retry creates safely.
+func newCORSMiddleware(cfg config.CORSConfig) fiber.Handler {
+	if len(cfg.Origins) == 0 {
+		return nil
+	}
+
+	allowHeaders := append([]string{}, cfg.Headers...)
+	for _, header := range append([]string{IdempotencyKeyHeader}, grpcWebRequestHeaders...) {
+		if !containsHeader(allowHeaders, header) {
+			allowHeaders = append(allowHeaders, header)
+		}
+	}
+
+	corsConfig := cors.Config{
+		AllowOrigins:     strings.Join(cfg.Origins, ","),
+		AllowHeaders:     strings.Join(allowHeaders, ","),
+		ExposeHeaders:    strings.Join(grpcWebExposedHeaders, ","),
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           cfg.MaxAge,
+	}
+	if len(cfg.Methods) > 0 {
+		corsConfig.AllowMethods = strings.Join(cfg.Methods, ",")
+	}
+
+	return cors.New(corsConfig)
+}
+
+// containsHeader reports whether headers includes name, ignoring case
+func containsHeader(headers []string, name string) bool {
+	for _, header := range headers {
+		if strings.EqualFold(header, name) {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
@@ -0,0 +1,56 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+)
+
+func preflight(t *testing.T, gw *SimpleGateway, origin string) http.Header {
+	t.Helper()
+
+	req := httptest.NewRequest("OPTIONS", "/ready", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	resp, err := gw.GetHTTPHandler().Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	return resp.Header
+}
+
+func TestCORSPreflight(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.CORS = config.CORSConfig{
+		Origins:          []string{"https://app.example.com"},
+		Methods:          []string{"GET", "POST"},
+		Headers:          []string{"Content-Type"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}
+	gw := NewSimpleGateway(cfg)
+
+	t.Run("allowed origin", func(t *testing.T) {
+		header := preflight(t, gw, "https://app.example.com")
+		assert.Equal(t, "https://app.example.com", header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", header.Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "600", header.Get("Access-Control-Max-Age"))
+		assert.Equal(t, "GET,POST", header.Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("disallowed origin", func(t *testing.T) {
+		header := preflight(t, gw, "https://evil.example.com")
+		assert.Empty(t, header.Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestCORSDefaultsToSameOrigin(t *testing.T) {
+	gw := NewSimpleGateway(newTestConfig("single"))
+
+	header := preflight(t, gw, "https://app.example.com")
+	assert.Empty(t, header.Get("Access-Control-Allow-Origin"))
+}
\ No newline at end of file
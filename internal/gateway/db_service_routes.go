@@ -8,20 +8,31 @@ import (
 	"github.com/gofiber/fiber/v2"
 	dbproto "github.com/yhonda-ohishi/db_service/src/proto"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // DBServiceRoutes handles REST routes for db_service
 type DBServiceRoutes struct {
-	conn *grpc.ClientConn
+	conn      *grpc.ClientConn
+	etcMeisai dbproto.ETCMeisaiServiceClient
+	retry     RetryPolicy
 }
 
 // NewDBServiceRoutes creates a new db_service route handler
 func NewDBServiceRoutes(conn *grpc.ClientConn) *DBServiceRoutes {
-	return &DBServiceRoutes{
-		conn: conn,
+	r := &DBServiceRoutes{
+		conn:  conn,
+		retry: DefaultRetryPolicy(),
 	}
+	if conn != nil {
+		r.etcMeisai = dbproto.NewETCMeisaiServiceClient(conn)
+	}
+	return r
+}
+
+// SetRetryPolicy sets how idempotent reads are retried on transient db_service failures
+func (r *DBServiceRoutes) SetRetryPolicy(policy RetryPolicy) {
+	r.retry = policy
 }
 
 // RegisterRoutes registers all db_service REST endpoints
@@ -49,14 +60,10 @@ func (r *DBServiceRoutes) RegisterRoutes(app *fiber.App) {
 // ETCMeisai handlers
 
 func (r *DBServiceRoutes) listETCMeisai(c *fiber.Ctx) error {
-	if r.conn == nil {
-		return c.Status(503).JSON(fiber.Map{
-			"error": "Service unavailable",
-		})
+	if r.etcMeisai == nil {
+		return restError(c, 503, "Service unavailable")
 	}
 
-	client := dbproto.NewETCMeisaiServiceClient(r.conn)
-
 	// Parse query parameters
 	req := &dbproto.ListETCMeisaiRequest{}
 	if hash := c.Query("hash"); hash != "" {
@@ -69,35 +76,46 @@ func (r *DBServiceRoutes) listETCMeisai(c *fiber.Ctx) error {
 		req.EndDate = &endDate
 	}
 
-	resp, err := client.List(context.Background(), req)
+	var resp *dbproto.ListETCMeisaiResponse
+	err := r.retry.Do(c.UserContext(), func(ctx context.Context) error {
+		var err error
+		resp, err = r.etcMeisai.List(ctx, req)
+		return err
+	})
 	if err != nil {
 		return handleGRPCError(c, err)
 	}
 
+	// Render an empty result as [] rather than null
+	items := resp.Items
+	if items == nil {
+		items = []*dbproto.ETCMeisai{}
+	}
+
 	return c.JSON(fiber.Map{
-		"items":       resp.Items,
+		"items":       items,
 		"total_count": resp.TotalCount,
 	})
 }
 
 func (r *DBServiceRoutes) getETCMeisai(c *fiber.Ctx) error {
-	if r.conn == nil {
-		return c.Status(503).JSON(fiber.Map{
-			"error": "Service unavailable",
-		})
+	if r.etcMeisai == nil {
+		return restError(c, 503, "Service unavailable")
 	}
 
 	idStr := c.Params("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid ID format",
-		})
+		return restError(c, 400, "Invalid ID format")
 	}
 
-	client := dbproto.NewETCMeisaiServiceClient(r.conn)
-	resp, err := client.Get(context.Background(), &dbproto.GetETCMeisaiRequest{
-		Id: id,
+	var resp *dbproto.ETCMeisaiResponse
+	err = r.retry.Do(c.UserContext(), func(ctx context.Context) error {
+		var err error
+		resp, err = r.etcMeisai.Get(ctx, &dbproto.GetETCMeisaiRequest{
+			Id: id,
+		})
+		return err
 	})
 	if err != nil {
 		return handleGRPCError(c, err)
@@ -107,21 +125,17 @@ func (r *DBServiceRoutes) getETCMeisai(c *fiber.Ctx) error {
 }
 
 func (r *DBServiceRoutes) createETCMeisai(c *fiber.Ctx) error {
-	if r.conn == nil {
-		return c.Status(503).JSON(fiber.Map{
-			"error": "Service unavailable",
-		})
+	if r.etcMeisai == nil {
+		return restError(c, 503, "Service unavailable")
 	}
 
 	var etcMeisai dbproto.ETCMeisai
 	if err := c.BodyParser(&etcMeisai); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return restError(c, 400, "Invalid request body")
 	}
 
-	client := dbproto.NewETCMeisaiServiceClient(r.conn)
-	resp, err := client.Create(context.Background(), &dbproto.CreateETCMeisaiRequest{
+	// Creates are not idempotent, so they are never retried
+	resp, err := r.etcMeisai.Create(c.UserContext(), &dbproto.CreateETCMeisaiRequest{
 		EtcMeisai: &etcMeisai,
 	})
 	if err != nil {
@@ -132,31 +146,24 @@ func (r *DBServiceRoutes) createETCMeisai(c *fiber.Ctx) error {
 }
 
 func (r *DBServiceRoutes) updateETCMeisai(c *fiber.Ctx) error {
-	if r.conn == nil {
-		return c.Status(503).JSON(fiber.Map{
-			"error": "Service unavailable",
-		})
+	if r.etcMeisai == nil {
+		return restError(c, 503, "Service unavailable")
 	}
 
 	idStr := c.Params("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid ID format",
-		})
+		return restError(c, 400, "Invalid ID format")
 	}
 
 	var etcMeisai dbproto.ETCMeisai
 	if err := c.BodyParser(&etcMeisai); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return restError(c, 400, "Invalid request body")
 	}
 
 	etcMeisai.Id = id
 
-	client := dbproto.NewETCMeisaiServiceClient(r.conn)
-	resp, err := client.Update(context.Background(), &dbproto.UpdateETCMeisaiRequest{
+	resp, err := r.etcMeisai.Update(c.UserContext(), &dbproto.UpdateETCMeisaiRequest{
 		EtcMeisai: &etcMeisai,
 	})
 	if err != nil {
@@ -167,22 +174,17 @@ func (r *DBServiceRoutes) updateETCMeisai(c *fiber.Ctx) error {
 }
 
 func (r *DBServiceRoutes) deleteETCMeisai(c *fiber.Ctx) error {
-	if r.conn == nil {
-		return c.Status(503).JSON(fiber.Map{
-			"error": "Service unavailable",
-		})
+	if r.etcMeisai == nil {
+		return restError(c, 503, "Service unavailable")
 	}
 
 	idStr := c.Params("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid ID format",
-		})
+		return restError(c, 400, "Invalid ID format")
 	}
 
-	client := dbproto.NewETCMeisaiServiceClient(r.conn)
-	_, err = client.Delete(context.Background(), &dbproto.DeleteETCMeisaiRequest{
+	_, err = r.etcMeisai.Delete(c.UserContext(), &dbproto.DeleteETCMeisaiRequest{
 		Id: id,
 	})
 	if err != nil {
@@ -196,20 +198,16 @@ func (r *DBServiceRoutes) deleteETCMeisai(c *fiber.Ctx) error {
 
 func (r *DBServiceRoutes) createDTakoUriageKeihi(c *fiber.Ctx) error {
 	if r.conn == nil {
-		return c.Status(503).JSON(fiber.Map{
-			"error": "Service unavailable",
-		})
+		return restError(c, 503, "Service unavailable")
 	}
 
 	var dtakoUriageKeihi dbproto.DTakoUriageKeihi
 	if err := c.BodyParser(&dtakoUriageKeihi); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return restError(c, 400, "Invalid request body")
 	}
 
 	client := dbproto.NewDTakoUriageKeihiServiceClient(r.conn)
-	resp, err := client.Create(context.Background(), &dbproto.CreateDTakoUriageKeihiRequest{
+	resp, err := client.Create(c.UserContext(), &dbproto.CreateDTakoUriageKeihiRequest{
 		DtakoUriageKeihi: &dtakoUriageKeihi,
 	})
 	if err != nil {
@@ -223,17 +221,13 @@ func (r *DBServiceRoutes) createDTakoUriageKeihi(c *fiber.Ctx) error {
 
 func (r *DBServiceRoutes) createDTakoFerryRows(c *fiber.Ctx) error {
 	if r.conn == nil {
-		return c.Status(503).JSON(fiber.Map{
-			"error": "Service unavailable",
-		})
+		return restError(c, 503, "Service unavailable")
 	}
 
 	// Parse JSON body manually to handle field types correctly
 	var body map[string]interface{}
 	if err := json.Unmarshal(c.Body(), &body); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid JSON body",
-		})
+		return restError(c, 400, "Invalid JSON body")
 	}
 
 	// Create DTakoFerryRows from parsed data
@@ -268,7 +262,7 @@ func (r *DBServiceRoutes) createDTakoFerryRows(c *fiber.Ctx) error {
 	}
 
 	client := dbproto.NewDTakoFerryRowsServiceClient(r.conn)
-	resp, err := client.Create(context.Background(), &dbproto.CreateDTakoFerryRowsRequest{
+	resp, err := client.Create(c.UserContext(), &dbproto.CreateDTakoFerryRowsRequest{
 		DtakoFerryRows: dtakoFerryRows,
 	})
 	if err != nil {
@@ -282,20 +276,16 @@ func (r *DBServiceRoutes) createDTakoFerryRows(c *fiber.Ctx) error {
 
 func (r *DBServiceRoutes) createETCMeisaiMapping(c *fiber.Ctx) error {
 	if r.conn == nil {
-		return c.Status(503).JSON(fiber.Map{
-			"error": "Service unavailable",
-		})
+		return restError(c, 503, "Service unavailable")
 	}
 
 	var etcMeisaiMapping dbproto.ETCMeisaiMapping
 	if err := c.BodyParser(&etcMeisaiMapping); err != nil {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return restError(c, 400, "Invalid request body")
 	}
 
 	client := dbproto.NewETCMeisaiMappingServiceClient(r.conn)
-	resp, err := client.Create(context.Background(), &dbproto.CreateETCMeisaiMappingRequest{
+	resp, err := client.Create(c.UserContext(), &dbproto.CreateETCMeisaiMappingRequest{
 		EtcMeisaiMapping: &etcMeisaiMapping,
 	})
 	if err != nil {
@@ -305,41 +295,12 @@ func (r *DBServiceRoutes) createETCMeisaiMapping(c *fiber.Ctx) error {
 	return c.Status(201).JSON(resp.EtcMeisaiMapping)
 }
 
-// handleGRPCError converts gRPC errors to HTTP status codes
+// handleGRPCError answers with the HTTP status and error envelope for a gRPC error
 func handleGRPCError(c *fiber.Ctx, err error) error {
 	st, ok := status.FromError(err)
 	if !ok {
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Internal server error",
-		})
+		return restError(c, 500, "Internal server error")
 	}
 
-	var httpStatus int
-	switch st.Code() {
-	case codes.NotFound:
-		httpStatus = 404
-	case codes.InvalidArgument:
-		httpStatus = 400
-	case codes.AlreadyExists:
-		httpStatus = 409
-	case codes.PermissionDenied:
-		httpStatus = 403
-	case codes.Unauthenticated:
-		httpStatus = 401
-	case codes.ResourceExhausted:
-		httpStatus = 429
-	case codes.FailedPrecondition:
-		httpStatus = 412
-	case codes.Unimplemented:
-		httpStatus = 501
-	case codes.Unavailable:
-		httpStatus = 503
-	default:
-		httpStatus = 500
-	}
-
-	return c.Status(httpStatus).JSON(fiber.Map{
-		"error": st.Message(),
-		"code":  st.Code().String(),
-	})
+	return writeRESTError(c, httpStatusFromCode(st.Code()), st.Code(), st.Message(), statusDetails(st))
 }
\ No newline at end of file
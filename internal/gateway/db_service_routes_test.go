@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbproto "github.com/yhonda-ohishi/db_service/src/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyETCMeisaiClient fails its first failures calls with code, then succeeds
+type flakyETCMeisaiClient struct {
+	dbproto.ETCMeisaiServiceClient
+	failures int
+	code     codes.Code
+	calls    int
+}
+
+func (f *flakyETCMeisaiClient) fail() error {
+	f.calls++
+	if f.calls <= f.failures {
+		return status.Error(f.code, "db_service restarting")
+	}
+	return nil
+}
+
+func (f *flakyETCMeisaiClient) Get(ctx context.Context, in *dbproto.GetETCMeisaiRequest, opts ...grpc.CallOption) (*dbproto.ETCMeisaiResponse, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &dbproto.ETCMeisaiResponse{EtcMeisai: &dbproto.ETCMeisai{Id: in.Id}}, nil
+}
+
+func (f *flakyETCMeisaiClient) Create(ctx context.Context, in *dbproto.CreateETCMeisaiRequest, opts ...grpc.CallOption) (*dbproto.ETCMeisaiResponse, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &dbproto.ETCMeisaiResponse{EtcMeisai: in.EtcMeisai}, nil
+}
+
+func newFlakyDBServiceApp(client *flakyETCMeisaiClient) *fiber.App {
+	routes := &DBServiceRoutes{
+		etcMeisai: client,
+		retry:     RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+	}
+	app := fiber.New()
+	routes.RegisterRoutes(app)
+	return app
+}
+
+func TestDBServiceRoutesRetryTransientFailures(t *testing.T) {
+	client := &flakyETCMeisaiClient{failures: 2, code: codes.Unavailable}
+	app := newFlakyDBServiceApp(client)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/db/etc-meisai/42", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, client.calls)
+}
+
+func TestDBServiceRoutesRetryLimits(t *testing.T) {
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		client := &flakyETCMeisaiClient{failures: 5, code: codes.Unavailable}
+		resp, err := newFlakyDBServiceApp(client).Test(httptest.NewRequest("GET", "/api/v1/db/etc-meisai/42", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, 3, client.calls)
+	})
+
+	t.Run("does not retry other codes", func(t *testing.T) {
+		client := &flakyETCMeisaiClient{failures: 1, code: codes.NotFound}
+		resp, err := newFlakyDBServiceApp(client).Test(httptest.NewRequest("GET", "/api/v1/db/etc-meisai/42", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, 1, client.calls)
+	})
+
+	t.Run("does not retry creates", func(t *testing.T) {
+		client := &flakyETCMeisaiClient{failures: 1, code: codes.Unavailable}
+		req := httptest.NewRequest("POST", "/api/v1/db/etc-meisai", strings.NewReader(`{"hash":"abc"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := newFlakyDBServiceApp(client).Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, 1, client.calls)
+	})
+}
+
+func TestRetryPolicyRespectsDeadline(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	start := time.Now()
+	err := policy.Do(ctx, func(ctx context.Context) error {
+		calls++
+		return status.Error(codes.Unavailable, "down")
+	})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Less(t, time.Since(start), time.Second, "retries should stop at the context deadline")
+	assert.GreaterOrEqual(t, calls, 1)
+}
\ No newline at end of file
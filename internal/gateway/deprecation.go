@@ -0,0 +1,57 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+)
+
+// deprecationNotice holds the headers announced for a deprecated path prefix
+type deprecationNotice struct {
+	path   string
+	sunset string
+	link   string
+}
+
+// newDeprecationMiddleware adds Deprecation and Sunset (RFC 8594) headers to
+// responses for configured path prefixes. The longest matching prefix wins. It
+// returns nil when no deprecations are configured.
+func newDeprecationMiddleware(deprecations []config.DeprecationConfig) fiber.Handler {
+	if len(deprecations) == 0 {
+		return nil
+	}
+
+	notices := make([]deprecationNotice, 0, len(deprecations))
+	for _, d := range deprecations {
+		notice := deprecationNotice{path: d.Path, link: d.Link}
+		// Sunsets are validated when the configuration is loaded
+		if sunset, err := d.SunsetTime(); err == nil && !sunset.IsZero() {
+			notice.sunset = sunset.UTC().Format(http.TimeFormat)
+		}
+		notices = append(notices, notice)
+	}
+
+	return func(c *fiber.Ctx) error {
+		var matched *deprecationNotice
+		for i := range notices {
+			if strings.HasPrefix(c.Path(), notices[i].path) && (matched == nil || len(notices[i].path) > len(matched.path)) {
+				matched = &notices[i]
+			}
+		}
+		if matched == nil {
+			return c.Next()
+		}
+
+		c.Set("Deprecation", "true")
+		if matched.sunset != "" {
+			c.Set("Sunset", matched.sunset)
+		}
+		if matched.link != "" {
+			c.Append(fiber.HeaderLink, `<`+matched.link+`>; rel="sunset"`)
+		}
+
+		return c.Next()
+	}
+}
\ No newline at end of file
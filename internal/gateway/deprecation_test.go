@@ -0,0 +1,36 @@
+package gateway
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+)
+
+func TestDeprecationHeaders(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Server.Deprecations = []config.DeprecationConfig{
+		{Path: "/api/v1/users", Sunset: "2027-03-31", Link: "https://example.com/docs/v2-migration"},
+	}
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+	app := gw.GetHTTPHandler()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/users", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "true", resp.Header.Get("Deprecation"))
+	assert.Equal(t, "Wed, 31 Mar 2027 00:00:00 GMT", resp.Header.Get("Sunset"))
+	assert.Equal(t, `<https://example.com/docs/v2-migration>; rel="sunset"`, resp.Header.Get("Link"))
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/transactions", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Empty(t, resp.Header.Get("Deprecation"))
+	assert.Empty(t, resp.Header.Get("Sunset"))
+}
\ No newline at end of file
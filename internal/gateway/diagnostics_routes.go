@@ -0,0 +1,101 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/diagnostics"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc"
+)
+
+// consistencyPageSize is the number of users each protocol probe lists
+const consistencyPageSize = 100
+
+// DiagnosticsRoutes handles the mixed-protocol self-diagnostic endpoint
+type DiagnosticsRoutes struct {
+	conn   *grpc.ClientConn
+	app    *fiber.App
+	config diagnostics.Config
+}
+
+// NewDiagnosticsRoutes creates a new diagnostics route handler. The app is
+// used to issue in-process JSON-RPC calls.
+func NewDiagnosticsRoutes(conn *grpc.ClientConn, app *fiber.App, config diagnostics.Config) *DiagnosticsRoutes {
+	return &DiagnosticsRoutes{
+		conn:   conn,
+		app:    app,
+		config: config,
+	}
+}
+
+// RegisterRoutes registers the diagnostics endpoint
+func (r *DiagnosticsRoutes) RegisterRoutes(app *fiber.App) {
+	app.Get("/diagnostics/consistency", r.checkConsistency)
+}
+
+// checkConsistency lists users over each protocol and compares the results
+func (r *DiagnosticsRoutes) checkConsistency(c *fiber.Ctx) error {
+	runner := diagnostics.NewRunner(r.config,
+		diagnostics.Probe{Protocol: "grpc", Run: r.probeGRPC},
+		diagnostics.Probe{Protocol: "jsonrpc", Run: r.probeJSONRPC},
+	)
+
+	report := runner.Run(c.UserContext())
+	statusCode := fiber.StatusOK
+	if !report.Consistent {
+		statusCode = fiber.StatusServiceUnavailable
+	}
+
+	return c.Status(statusCode).JSON(report)
+}
+
+func (r *DiagnosticsRoutes) probeGRPC(ctx context.Context) (string, error) {
+	if r.conn == nil {
+		return "", fmt.Errorf("no gRPC connection available")
+	}
+
+	resp, err := pb.NewUserServiceClient(r.conn).ListUsers(ctx, &pb.ListUsersRequest{
+		PageSize: consistencyPageSize,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("users:%d", len(resp.Users)), nil
+}
+
+func (r *DiagnosticsRoutes) probeJSONRPC(ctx context.Context) (string, error) {
+	payload := fmt.Sprintf(`{"jsonrpc": "2.0", "method": "user.list", "params": {"page_size": %d}, "id": "diagnostics"}`, consistencyPageSize)
+	req := httptest.NewRequest("POST", "/jsonrpc", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	// The runner enforces the timeout, so the in-process call itself is unbounded
+	resp, err := r.app.Test(req, -1)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Result *struct {
+			Users []json.RawMessage `json:"users"`
+		} `json:"result"`
+		Error *JSONRPCError `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode JSON-RPC response: %w", err)
+	}
+	if body.Error != nil {
+		return "", fmt.Errorf("JSON-RPC error %d: %s", body.Error.Code, body.Error.Message)
+	}
+	if body.Result == nil {
+		return "", fmt.Errorf("JSON-RPC response has no result")
+	}
+
+	return fmt.Sprintf("users:%d", len(body.Result.Users)), nil
+}
\ No newline at end of file
@@ -1,7 +1,6 @@
 package gateway
 
 import (
-	"context"
 	"encoding/json"
 	"os"
 	"strings"
@@ -41,23 +40,13 @@ func (r *DownloadServiceRoutes) RegisterRoutes(app *fiber.App) {
 func (r *DownloadServiceRoutes) downloadSync(c *fiber.Ctx) error {
 	var req etcpb.DownloadRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body: " + err.Error(),
-		})
+		return restError(c, fiber.StatusBadRequest, "Invalid request body: "+err.Error())
 	}
 
 	client := etcpb.NewDownloadServiceClient(r.conn)
-	resp, err := client.DownloadSync(context.Background(), &req)
+	resp, err := client.DownloadSync(c.UserContext(), &req)
 	if err != nil {
-		st, _ := status.FromError(err)
-		if st.Code() == codes.NotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": st.Message(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return handleGRPCError(c, err)
 	}
 
 	return c.JSON(resp)
@@ -67,9 +56,7 @@ func (r *DownloadServiceRoutes) downloadSync(c *fiber.Ctx) error {
 func (r *DownloadServiceRoutes) downloadAsync(c *fiber.Ctx) error {
 	var req etcpb.DownloadRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body: " + err.Error(),
-		})
+		return restError(c, fiber.StatusBadRequest, "Invalid request body: "+err.Error())
 	}
 
 	// Convert accounts array to full credentials if provided
@@ -83,12 +70,9 @@ func (r *DownloadServiceRoutes) downloadAsync(c *fiber.Ctx) error {
 	}
 
 	client := etcpb.NewDownloadServiceClient(r.conn)
-	resp, err := client.DownloadAsync(context.Background(), &req)
+	resp, err := client.DownloadAsync(c.UserContext(), &req)
 	if err != nil {
-		st, _ := status.FromError(err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": st.Message(),
-		})
+		return handleGRPCError(c, err)
 	}
 
 	return c.JSON(resp)
@@ -98,9 +82,7 @@ func (r *DownloadServiceRoutes) downloadAsync(c *fiber.Ctx) error {
 func (r *DownloadServiceRoutes) getJobStatus(c *fiber.Ctx) error {
 	jobID := c.Params("job_id")
 	if jobID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Job ID is required",
-		})
+		return restError(c, fiber.StatusBadRequest, "Job ID is required")
 	}
 
 	req := &etcpb.GetJobStatusRequest{
@@ -108,17 +90,12 @@ func (r *DownloadServiceRoutes) getJobStatus(c *fiber.Ctx) error {
 	}
 
 	client := etcpb.NewDownloadServiceClient(r.conn)
-	resp, err := client.GetJobStatus(context.Background(), req)
+	resp, err := client.GetJobStatus(c.UserContext(), req)
 	if err != nil {
-		st, _ := status.FromError(err)
-		if st.Code() == codes.NotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Job not found",
-			})
+		if status.Code(err) == codes.NotFound {
+			return restError(c, fiber.StatusNotFound, "Job not found")
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return handleGRPCError(c, err)
 	}
 
 	return c.JSON(resp)
@@ -129,12 +106,9 @@ func (r *DownloadServiceRoutes) getAllAccountIDs(c *fiber.Ctx) error {
 	req := &etcpb.GetAllAccountIDsRequest{}
 
 	client := etcpb.NewDownloadServiceClient(r.conn)
-	resp, err := client.GetAllAccountIDs(context.Background(), req)
+	resp, err := client.GetAllAccountIDs(c.UserContext(), req)
 	if err != nil {
-		st, _ := status.FromError(err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": st.Message(),
-		})
+		return handleGRPCError(c, err)
 	}
 
 	return c.JSON(resp)
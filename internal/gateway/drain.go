@@ -0,0 +1,68 @@
+package gateway
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/metrics"
+)
+
+// DrainRetryAfter is the Retry-After sent with the 503 answered to requests
+// arriving while the gateway shuts down
+const DrainRetryAfter = 5 * time.Second
+
+// drainExemptPaths keep answering while draining, so probes and scrapes can
+// watch the shutdown
+var drainExemptPaths = map[string]bool{
+	"/health":       true,
+	"/health/live":  true,
+	"/health/ready": true,
+	"/ready":        true,
+	"/metrics":      true,
+}
+
+// drainer refuses new requests once shutdown begins, while requests already
+// being handled run to completion before app.Shutdown returns
+type drainer struct {
+	draining atomic.Bool
+	inFlight atomic.Int64
+	// metrics, when set, reports the in-flight count as requests_in_flight
+	metrics *metrics.Service
+}
+
+// start makes the middleware refuse new requests
+func (d *drainer) start() {
+	d.draining.Store(true)
+}
+
+// inFlightRequests returns the number of requests being handled
+func (d *drainer) inFlightRequests() int64 {
+	return d.inFlight.Load()
+}
+
+// middleware answers 503 with Retry-After while draining and otherwise
+// counts the request as in flight until its handler returns
+func (d *drainer) middleware() fiber.Handler {
+	retryAfter := strconv.Itoa(int(DrainRetryAfter.Seconds()))
+	return func(c *fiber.Ctx) error {
+		if d.draining.Load() && !drainExemptPaths[c.Path()] {
+			c.Set(fiber.HeaderRetryAfter, retryAfter)
+			c.Set(fiber.HeaderConnection, "close")
+			return restError(c, fiber.StatusServiceUnavailable, "Server is shutting down")
+		}
+
+		d.inFlight.Add(1)
+		if d.metrics != nil {
+			d.metrics.RequestStarted()
+		}
+		defer func() {
+			d.inFlight.Add(-1)
+			if d.metrics != nil {
+				d.metrics.RequestFinished()
+			}
+		}()
+		return c.Next()
+	}
+}
\ No newline at end of file
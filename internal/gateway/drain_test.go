@@ -0,0 +1,82 @@
+package gateway
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainRefusesNewRequestsWhileInFlightFinish(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Monitoring.MetricsEnabled = true
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+	app := gw.GetHTTPHandler()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	app.Get("/api/v1/slow", func(c *fiber.Ctx) error {
+		close(started)
+		<-release
+		return c.SendString("done")
+	})
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	slowResult := make(chan result, 1)
+	go func() {
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/slow", nil), -1)
+		if err != nil {
+			slowResult <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slowResult <- result{status: resp.StatusCode, body: string(body)}
+	}()
+	<-started
+	assert.Equal(t, int64(1), gw.drain.inFlightRequests())
+
+	// The gauge counts the slow request and this scrape
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	require.NoError(t, err)
+	metricsBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Contains(t, string(metricsBody), `http_server_requests_in_flight{deployment_mode="single"} 2`)
+
+	gw.drain.start()
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/users", nil))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "5", resp.Header.Get(fiber.HeaderRetryAfter))
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/health/live", nil))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode, "probes keep answering while draining")
+
+	close(release)
+	slow := <-slowResult
+	require.NoError(t, slow.err)
+	assert.Equal(t, fiber.StatusOK, slow.status)
+	assert.Equal(t, "done", slow.body)
+	assert.Equal(t, int64(0), gw.drain.inFlightRequests())
+}
+
+func TestShutdownStartsDraining(t *testing.T) {
+	gw := NewSimpleGateway(newTestConfig("single"))
+	require.NoError(t, gw.Initialize())
+	require.NoError(t, gw.Stop())
+
+	assert.True(t, gw.drain.draining.Load())
+}
\ No newline at end of file
@@ -0,0 +1,33 @@
+package gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// recordETag derives a strong ETag from a record's content hash and its
+// last update time, so any update yields a new ETag
+func recordETag(hash string, updatedAt *timestamppb.Timestamp) string {
+	version := hash
+	if updatedAt != nil {
+		version += "@" + strconv.FormatInt(updatedAt.AsTime().UnixNano(), 10)
+	}
+	sum := sha256.Sum256([]byte(version))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using
+// weak comparison as required for If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
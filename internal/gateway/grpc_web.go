@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// GRPCWebPrefix is the path prefix under which gRPC-Web calls are served,
+// e.g. POST /grpc/etc_meisai.v1.UserService/GetUser
+const GRPCWebPrefix = "/grpc"
+
+// grpcWebRequestHeaders are the request headers gRPC-Web clients send
+var grpcWebRequestHeaders = []string{"X-Grpc-Web", "X-User-Agent", "Grpc-Timeout"}
+
+// grpcWebExposedHeaders are the response headers gRPC-Web clients read
+var grpcWebExposedHeaders = []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"}
+
+// GRPCWebRoutes serves gRPC-Web requests from browsers with the in-process gRPC server
+type GRPCWebRoutes struct {
+	wrapper *grpcweb.WrappedGrpcServer
+}
+
+// NewGRPCWebRoutes creates a gRPC-Web handler for server. CORS preflight is
+// answered by the gateway's CORS middleware.
+func NewGRPCWebRoutes(server *grpc.Server) *GRPCWebRoutes {
+	return &GRPCWebRoutes{
+		wrapper: grpcweb.WrapServer(server),
+	}
+}
+
+// RegisterRoutes registers the gRPC-Web endpoint
+func (r *GRPCWebRoutes) RegisterRoutes(app *fiber.App) {
+	handler := adaptor.HTTPHandler(http.StripPrefix(GRPCWebPrefix, http.HandlerFunc(r.serveGRPCWeb)))
+	app.Post(GRPCWebPrefix+"/*", handler)
+}
+
+func (r *GRPCWebRoutes) serveGRPCWeb(w http.ResponseWriter, req *http.Request) {
+	if !r.wrapper.IsGrpcWebRequest(req) {
+		http.Error(w, "Not a gRPC-Web request", http.StatusUnsupportedMediaType)
+		return
+	}
+	r.wrapper.HandleGrpcWebRequest(w, req)
+}
\ No newline at end of file
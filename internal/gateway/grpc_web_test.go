@@ -0,0 +1,130 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// grpcWebFrame encodes msg as a single gRPC-Web data frame
+func grpcWebFrame(t *testing.T, msg proto.Message) []byte {
+	t.Helper()
+
+	data, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	return append(frame, data...)
+}
+
+// readGRPCWebFrames splits a gRPC-Web response body into its data frames and
+// the trailers sent in the final frame
+func readGRPCWebFrames(t *testing.T, body []byte) ([][]byte, map[string]string) {
+	t.Helper()
+
+	var messages [][]byte
+	trailers := make(map[string]string)
+	for len(body) > 0 {
+		require.GreaterOrEqual(t, len(body), 5, "truncated frame header")
+		flags := body[0]
+		length := binary.BigEndian.Uint32(body[1:5])
+		require.GreaterOrEqual(t, len(body)-5, int(length), "truncated frame")
+		payload := body[5 : 5+length]
+		body = body[5+length:]
+
+		if flags&0x80 != 0 {
+			for _, line := range strings.Split(strings.TrimSpace(string(payload)), "\r\n") {
+				if key, value, ok := strings.Cut(line, ":"); ok {
+					trailers[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+				}
+			}
+			continue
+		}
+		messages = append(messages, payload)
+	}
+	return messages, trailers
+}
+
+func TestGRPCWebGetUser(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	// Create a user over REST to fetch over gRPC-Web
+	createReq := httptest.NewRequest("POST", "/api/v1/users",
+		strings.NewReader(`{"email": "grpcweb@example.com", "name": "gRPC-Web User"}`))
+	createReq.Header.Set("Content-Type", "application/json")
+	createResp, err := app.Test(createReq)
+	require.NoError(t, err)
+	defer createResp.Body.Close()
+	require.Equal(t, 201, createResp.StatusCode)
+	var created struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.NewDecoder(createResp.Body).Decode(&created))
+
+	req := httptest.NewRequest("POST", GRPCWebPrefix+"/etc_meisai.v1.UserService/GetUser",
+		bytes.NewReader(grpcWebFrame(t, &pb.GetUserRequest{Id: created.ID})))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "application/grpc-web+proto", resp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	messages, trailers := readGRPCWebFrames(t, body)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "0", trailers["grpc-status"])
+
+	var user pb.User
+	require.NoError(t, proto.Unmarshal(messages[0], &user))
+	assert.Equal(t, created.ID, user.Id)
+	assert.Equal(t, "grpcweb@example.com", user.Email)
+}
+
+func TestGRPCWebRejectsPlainRequests(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	req := httptest.NewRequest("POST", GRPCWebPrefix+"/etc_meisai.v1.UserService/GetUser", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, 415, resp.StatusCode)
+}
+
+func TestGRPCWebCORSPreflight(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.CORS = config.CORSConfig{
+		Origins: []string{"https://app.example.com"},
+		Headers: []string{"Content-Type"},
+	}
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	req := httptest.NewRequest("OPTIONS", GRPCWebPrefix+"/etc_meisai.v1.UserService/GetUser", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,x-user-agent")
+	resp, err := gw.GetHTTPHandler().Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, 204, resp.StatusCode)
+	assert.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	allowHeaders := strings.ToLower(resp.Header.Get("Access-Control-Allow-Headers"))
+	assert.Contains(t, allowHeaders, "x-grpc-web")
+	assert.Contains(t, allowHeaders, "x-user-agent")
+}
\ No newline at end of file
@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+	"google.golang.org/grpc/codes"
+)
+
+// DefaultMaxJSONDepth is the deepest nesting of JSON objects and arrays
+// accepted in request bodies unless server.max_json_depth is set
+const DefaultMaxJSONDepth = 64
+
+// maxJSONDepthFromConfig returns the configured nesting limit, or the default
+// when unset; a negative value disables the check
+func maxJSONDepthFromConfig(cfg config.ServerConfig) int {
+	if cfg.MaxJSONDepth == 0 {
+		return DefaultMaxJSONDepth
+	}
+	return cfg.MaxJSONDepth
+}
+
+// newJSONDepthMiddleware rejects request bodies nested deeper than maxDepth
+// before any handler unmarshals them, answering JSON-RPC requests with a
+// -32700 parse error and REST requests with 400. Every body except protobuf
+// and gRPC-Web is checked, whatever its Content-Type, so a mislabelled or
+// malformed type can't slip past the limit. A maxDepth of zero or less
+// disables the check.
+func newJSONDepthMiddleware(maxDepth int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		body := c.Body()
+		if maxDepth <= 0 || len(body) == 0 || isBinaryProtoMediaType(requestMediaType(c)) {
+			return c.Next()
+		}
+		if !jsonDepthExceeds(body, maxDepth) {
+			return c.Next()
+		}
+
+		message := fmt.Sprintf("JSON nesting exceeds the maximum depth of %d", maxDepth)
+		if c.Path() == "/jsonrpc" {
+			return c.JSON(newJSONRPCError(nil, JSONRPCParseError, "Parse error", message))
+		}
+		return writeRESTError(c, fiber.StatusBadRequest, codes.InvalidArgument, message, nil)
+	}
+}
+
+// isBinaryProtoMediaType reports whether a body of mediaType is binary
+// protobuf, as sent to the protobuf REST encoding or the gRPC-Web endpoint
+func isBinaryProtoMediaType(mediaType string) bool {
+	return mediaType == MIMEApplicationProtobuf || strings.HasPrefix(mediaType, "application/grpc-web")
+}
+
+// jsonDepthExceeds reports whether data nests objects and arrays deeper than
+// maxDepth. It scans the bytes once without decoding, skipping string
+// contents, and stops as soon as the limit is passed; malformed JSON is left
+// for the decoder to reject.
+func jsonDepthExceeds(data []byte, maxDepth int) bool {
+	depth := 0
+	inString := false
+	escaped := false
+	for _, b := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch b {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			if depth > maxDepth {
+				return true
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+	return false
+}
\ No newline at end of file
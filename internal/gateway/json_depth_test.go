@@ -0,0 +1,92 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nestedArray returns depth nested JSON arrays
+func nestedArray(depth int) string {
+	return strings.Repeat("[", depth) + strings.Repeat("]", depth)
+}
+
+func TestJSONDepthExceeds(t *testing.T) {
+	assert.False(t, jsonDepthExceeds([]byte(`{"a": [1, {"b": 2}]}`), 3))
+	assert.True(t, jsonDepthExceeds([]byte(`{"a": [1, {"b": 2}]}`), 2))
+	// Brackets inside strings, including after escaped quotes, don't count
+	assert.False(t, jsonDepthExceeds([]byte(`{"a": "[[[{{{\"[[["}`), 1))
+	assert.False(t, jsonDepthExceeds([]byte(nestedArray(64)), 64))
+	assert.True(t, jsonDepthExceeds([]byte(nestedArray(65)), 64))
+}
+
+func TestJSONDepthLimit(t *testing.T) {
+	const maxDepth = 8
+	cfg := newTestConfig("single")
+	cfg.Server.MaxJSONDepth = maxDepth
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+	app := gw.GetHTTPHandler()
+
+	jsonrpcErrorCode := func(t *testing.T, params string) float64 {
+		req := httptest.NewRequest("POST", "/jsonrpc", strings.NewReader(`{"jsonrpc": "2.0", "method": "user.list", "params": `+params+`, "id": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		errorObj, _ := body["error"].(map[string]interface{})
+		if errorObj == nil {
+			return 0
+		}
+		return errorObj["code"].(float64)
+	}
+
+	t.Run("JSON-RPC beyond the limit", func(t *testing.T) {
+		// The request object is the first level
+		assert.Equal(t, float64(JSONRPCParseError), jsonrpcErrorCode(t, nestedArray(maxDepth)))
+	})
+
+	t.Run("JSON-RPC just under the limit", func(t *testing.T) {
+		assert.NotEqual(t, float64(JSONRPCParseError), jsonrpcErrorCode(t, nestedArray(maxDepth-1)))
+	})
+
+	t.Run("REST beyond the limit", func(t *testing.T) {
+		code, body := doRequest(t, app, "POST", "/api/v1/users",
+			`{"email": "deep@example.com", "name": "Deep", "extra": `+nestedArray(maxDepth)+`}`)
+		require.Equal(t, fiber.StatusBadRequest, code)
+		errorObj := body["error"].(map[string]interface{})
+		assert.Equal(t, "INVALID_ARGUMENT", errorObj["code"])
+		assert.Equal(t, "JSON nesting exceeds the maximum depth of 8", errorObj["message"])
+	})
+
+	t.Run("REST with a non-JSON or malformed content type", func(t *testing.T) {
+		for _, contentType := range []string{"text/plain", "application/json;;"} {
+			req := httptest.NewRequest("POST", "/api/v1/users", strings.NewReader(`{"extra": `+nestedArray(maxDepth)+`}`))
+			req.Header.Set("Content-Type", contentType)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, fiber.StatusBadRequest, resp.StatusCode, contentType)
+
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, "JSON nesting exceeds the maximum depth of 8", body["error"].(map[string]interface{})["message"], contentType)
+		}
+	})
+
+	t.Run("REST just under the limit", func(t *testing.T) {
+		code, _ := doRequest(t, app, "POST", "/api/v1/users",
+			`{"email": "shallow@example.com", "name": "Shallow", "extra": `+nestedArray(maxDepth-1)+`}`)
+		assert.Equal(t, fiber.StatusCreated, code)
+	})
+}
\ No newline at end of file
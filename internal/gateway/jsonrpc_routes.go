@@ -0,0 +1,412 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/jsonrpc"
+	"github.com/yhonda-ohishi/db-handler-server/internal/logger"
+	"github.com/yhonda-ohishi/db-handler-server/internal/metrics"
+	"github.com/yhonda-ohishi/db-handler-server/internal/validation"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// resultMarshalOptions renders protobuf results with their proto field names
+// and always emits empty lists as [] rather than omitting them
+var resultMarshalOptions = protojson.MarshalOptions{
+	UseProtoNames:   true,
+	EmitUnpopulated: true,
+}
+
+// JSON-RPC 2.0 error codes
+const (
+	JSONRPCParseError     = jsonrpc.CodeParseError
+	JSONRPCInvalidRequest = jsonrpc.CodeInvalidRequest
+	JSONRPCMethodNotFound = jsonrpc.CodeMethodNotFound
+	JSONRPCInvalidParams  = jsonrpc.CodeInvalidParams
+	JSONRPCInternalError  = jsonrpc.CodeInternalError
+	JSONRPCServerError    = jsonrpc.CodeServerError
+)
+
+// jsonRPCContentTypes are the media types accepted on the JSON-RPC endpoint
+var jsonRPCContentTypes = []string{fiber.MIMEApplicationJSON, "application/json-rpc"}
+
+// JSONRPCRequest represents a JSON-RPC 2.0 request
+type JSONRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      interface{}     `json:"id,omitempty"`
+}
+
+// JSONRPCResponse represents a JSON-RPC 2.0 response
+type JSONRPCResponse struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Result  interface{}   `json:"result,omitempty"`
+	Error   *JSONRPCError `json:"error,omitempty"`
+	ID      interface{}   `json:"id"`
+}
+
+// JSONRPCError represents a JSON-RPC 2.0 error
+type JSONRPCError = jsonrpc.Error
+
+// JSONRPCConfig holds JSON-RPC endpoint configuration
+type JSONRPCConfig struct {
+	// LogRequests logs each call with its method, id and outcome
+	LogRequests bool
+	// LogParams includes the raw params in the request log
+	LogParams bool
+}
+
+// DefaultJSONRPCConfig returns the default JSON-RPC configuration
+func DefaultJSONRPCConfig() JSONRPCConfig {
+	return JSONRPCConfig{
+		LogRequests: true,
+		LogParams:   false,
+	}
+}
+
+// JSONRPCRoutes handles the JSON-RPC 2.0 endpoint
+type JSONRPCRoutes struct {
+	conn     *grpc.ClientConn
+	config   JSONRPCConfig
+	registry *jsonrpc.Registry
+	metrics  *metrics.Service
+	// phonePattern validates user phone numbers; nil accepts any value
+	phonePattern *regexp.Regexp
+}
+
+// NewJSONRPCRoutes creates a new JSON-RPC route handler with default configuration
+func NewJSONRPCRoutes(conn *grpc.ClientConn) *JSONRPCRoutes {
+	return NewJSONRPCRoutesWithConfig(conn, DefaultJSONRPCConfig())
+}
+
+// NewJSONRPCRoutesWithConfig creates a new JSON-RPC route handler
+func NewJSONRPCRoutesWithConfig(conn *grpc.ClientConn, config JSONRPCConfig) *JSONRPCRoutes {
+	r := &JSONRPCRoutes{
+		conn:     conn,
+		config:   config,
+		registry: jsonrpc.NewRegistry(),
+	}
+	r.registerMethods()
+	return r
+}
+
+// Registry returns the method registry, so additional methods can be registered
+func (r *JSONRPCRoutes) Registry() *jsonrpc.Registry {
+	return r.registry
+}
+
+// SetMetrics records per-method call counts and durations in m
+func (r *JSONRPCRoutes) SetMetrics(m *metrics.Service) {
+	r.metrics = m
+}
+
+// SetPhonePattern sets the pattern user phone numbers must match in
+// user.create and user.update; nil accepts any value
+func (r *JSONRPCRoutes) SetPhonePattern(pattern *regexp.Regexp) {
+	r.phonePattern = pattern
+}
+
+// RegisterRoutes registers the JSON-RPC endpoint, which serves both single
+// requests and batches
+func (r *JSONRPCRoutes) RegisterRoutes(app *fiber.App) {
+	app.Post("/jsonrpc", r.handle)
+}
+
+// registerMethods registers the methods backed by the gRPC services
+func (r *JSONRPCRoutes) registerMethods() {
+	users := pb.NewUserServiceClient(r.conn)
+	transactions := pb.NewTransactionServiceClient(r.conn)
+	cards := pb.NewCardServiceClient(r.conn)
+
+	r.registry.Register("user.get", grpcMethod(r.conn, func(ctx context.Context, params *pb.GetUserRequest) (interface{}, error) {
+		return users.GetUser(ctx, params)
+	}))
+	r.registry.Register("user.create", grpcMethod(r.conn, func(ctx context.Context, params *pb.CreateUserRequest) (interface{}, error) {
+		if err := validation.ValidateCreateUser(params, r.phonePattern); err != nil {
+			return nil, err
+		}
+		return users.CreateUser(ctx, params)
+	}))
+	r.registry.Register("user.update", grpcMethod(r.conn, func(ctx context.Context, params *pb.UpdateUserRequest) (interface{}, error) {
+		if err := validation.ValidateUpdateUser(params, r.phonePattern); err != nil {
+			return nil, err
+		}
+		return users.UpdateUser(ctx, params)
+	}))
+	r.registry.Register("user.delete", grpcMethod(r.conn, func(ctx context.Context, params *pb.DeleteUserRequest) (interface{}, error) {
+		if _, err := users.DeleteUser(ctx, params); err != nil {
+			return nil, err
+		}
+		return fiber.Map{"deleted": true}, nil
+	}))
+	r.registry.Register("user.list", grpcMethod(r.conn, func(ctx context.Context, params *pb.ListUsersRequest) (interface{}, error) {
+		return users.ListUsers(ctx, params)
+	}))
+
+	r.registry.Register("transaction.get", grpcMethod(r.conn, func(ctx context.Context, params *pb.GetTransactionRequest) (interface{}, error) {
+		return transactions.GetTransaction(ctx, params)
+	}))
+	r.registry.Register("transaction.history", grpcMethod(r.conn, func(ctx context.Context, params *pb.GetTransactionHistoryRequest) (interface{}, error) {
+		return transactions.GetTransactionHistory(ctx, params)
+	}))
+	r.registry.Register("transaction.list", grpcMethod(r.conn, func(ctx context.Context, params *pb.ListTransactionsRequest) (interface{}, error) {
+		return transactions.ListTransactions(ctx, params)
+	}))
+
+	r.registry.Register("card.get", grpcMethod(r.conn, func(ctx context.Context, params *pb.GetCardRequest) (interface{}, error) {
+		return cards.GetCard(ctx, params)
+	}))
+	r.registry.Register("card.list", grpcMethod(r.conn, func(ctx context.Context, params *pb.ListCardsRequest) (interface{}, error) {
+		return cards.ListCards(ctx, params)
+	}))
+	r.registry.Register("card.create", grpcMethod(r.conn, func(ctx context.Context, params *pb.CreateCardRequest) (interface{}, error) {
+		return cards.CreateCard(ctx, params)
+	}))
+	r.registry.Register("card.update", grpcMethod(r.conn, func(ctx context.Context, params *pb.UpdateCardRequest) (interface{}, error) {
+		return cards.UpdateCard(ctx, params)
+	}))
+}
+
+// handle serves a single request object, or a batch when the body is an array
+func (r *JSONRPCRoutes) handle(c *fiber.Ctx) error {
+	if !acceptsJSONRPCContentType(c.Get(fiber.HeaderContentType)) {
+		message := "Invalid Request: Content-Type must be application/json or application/json-rpc"
+		resp := newJSONRPCError(nil, JSONRPCInvalidRequest, message, nil)
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(r.finish(c, &JSONRPCRequest{}, resp, time.Now()))
+	}
+
+	body := bytes.TrimLeft(c.Body(), " \t\r\n")
+	if len(body) > 0 && body[0] == '[' {
+		return r.handleBatch(c, body)
+	}
+	return c.JSON(r.call(c, body))
+}
+
+// acceptsJSONRPCContentType reports whether contentType is a JSON media type,
+// ignoring parameters such as charset
+func acceptsJSONRPCContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	for _, accepted := range jsonRPCContentTypes {
+		if strings.EqualFold(mediaType, accepted) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleBatch answers a batch with an array holding the response to each
+// request, in order
+func (r *JSONRPCRoutes) handleBatch(c *fiber.Ctx, body []byte) error {
+	start := time.Now()
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return c.JSON(r.finish(c, &JSONRPCRequest{}, newJSONRPCError(nil, JSONRPCParseError, "Parse error", nil), start))
+	}
+	if len(batch) == 0 {
+		return c.JSON(r.finish(c, &JSONRPCRequest{}, newJSONRPCError(nil, JSONRPCInvalidRequest, "Invalid Request", nil), start))
+	}
+
+	responses := make([]*JSONRPCResponse, 0, len(batch))
+	for _, raw := range batch {
+		raw = bytes.TrimLeft(raw, " \t\r\n")
+		if len(raw) == 0 || raw[0] != '{' {
+			responses = append(responses, r.finish(c, &JSONRPCRequest{}, newJSONRPCError(nil, JSONRPCInvalidRequest, "Invalid Request", nil), time.Now()))
+			continue
+		}
+		responses = append(responses, r.call(c, raw))
+	}
+	return c.JSON(responses)
+}
+
+// call decodes, validates and dispatches a single request object
+func (r *JSONRPCRoutes) call(c *fiber.Ctx, raw []byte) *JSONRPCResponse {
+	start := time.Now()
+
+	var req JSONRPCRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return r.finish(c, &req, newJSONRPCError(nil, JSONRPCParseError, "Parse error", nil), start)
+	}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return r.finish(c, &req, newJSONRPCError(req.ID, JSONRPCInvalidRequest, "Invalid Request", nil), start)
+	}
+	return r.finish(c, &req, r.dispatch(c, &req), start)
+}
+
+// finish logs and records a call that started at start, returning resp
+func (r *JSONRPCRoutes) finish(c *fiber.Ctx, req *JSONRPCRequest, resp *JSONRPCResponse, start time.Time) *JSONRPCResponse {
+	r.logCall(c, req, resp, time.Since(start))
+	r.recordCall(req, resp, time.Since(start))
+	return resp
+}
+
+// dispatch looks up and invokes the handler registered for the JSON-RPC method
+func (r *JSONRPCRoutes) dispatch(c *fiber.Ctx, req *JSONRPCRequest) *JSONRPCResponse {
+	result, rpcErr := r.registry.Dispatch(c.UserContext(), req.Method, req.Params)
+	if rpcErr != nil {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			Error:   rpcErr,
+			ID:      req.ID,
+		}
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		Result:  result,
+		ID:      req.ID,
+	}
+}
+
+// grpcMethod adapts a gRPC call to a JSON-RPC handler, decoding the params into
+// the request message and rendering protobuf results with their proto field names
+func grpcMethod[Req any](conn *grpc.ClientConn, call func(ctx context.Context, params *Req) (interface{}, error)) jsonrpc.HandlerFunc {
+	return func(ctx context.Context, raw json.RawMessage) (interface{}, *jsonrpc.Error) {
+		if conn == nil {
+			return nil, jsonrpc.NewError(JSONRPCInternalError, "Service unavailable", nil)
+		}
+
+		params := new(Req)
+		if err := decodeParams(raw, params); err != nil {
+			return nil, jsonrpc.NewError(JSONRPCInvalidParams, "Invalid params", err.Error())
+		}
+
+		result, err := call(ctx, params)
+		if err != nil {
+			return nil, grpcErrorToJSONRPC(err)
+		}
+
+		if msg, ok := result.(proto.Message); ok {
+			data, err := resultMarshalOptions.Marshal(msg)
+			if err != nil {
+				return nil, jsonrpc.NewError(JSONRPCInternalError, "Internal error", err.Error())
+			}
+			result = json.RawMessage(data)
+		}
+		return result, nil
+	}
+}
+
+// logCall logs a JSON-RPC call using the request-scoped logger
+func (r *JSONRPCRoutes) logCall(c *fiber.Ctx, req *JSONRPCRequest, resp *JSONRPCResponse, duration time.Duration) {
+	if !r.config.LogRequests {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"rpc_method":  req.Method,
+		"rpc_id":      req.ID,
+		"duration_ms": duration.Milliseconds(),
+	}
+	if r.config.LogParams {
+		fields["rpc_params"] = string(req.Params)
+	} else if len(req.Params) > 0 {
+		fields["rpc_params"] = "[REDACTED]"
+	}
+
+	ctx := c.UserContext()
+	if _, ok := logger.GetRequestIDFromContext(ctx); !ok {
+		if requestID := c.Get("X-Request-ID"); requestID != "" {
+			ctx = logger.ContextWithRequestID(ctx, requestID)
+		}
+	}
+
+	log := logger.WithContext(ctx)
+	if resp.Error != nil {
+		fields["outcome"] = "error"
+		fields["error_code"] = resp.Error.Code
+		fields["error_message"] = resp.Error.Message
+		log.WithFields(fields).Warn("JSON-RPC request failed")
+		return
+	}
+
+	fields["outcome"] = "success"
+	log.WithFields(fields).Info("JSON-RPC request completed")
+}
+
+// recordCall records the call in the metrics service, labelling methods that
+// are not registered as "unknown" to bound the label cardinality
+func (r *JSONRPCRoutes) recordCall(req *JSONRPCRequest, resp *JSONRPCResponse, duration time.Duration) {
+	if r.metrics == nil {
+		return
+	}
+
+	method := req.Method
+	if !r.registry.Has(method) {
+		method = "unknown"
+	}
+	outcome := metrics.JSONRPCOutcomeSuccess
+	if resp.Error != nil {
+		outcome = strconv.Itoa(resp.Error.Code)
+	}
+	r.metrics.RecordJSONRPCRequest(method, outcome, duration)
+}
+
+// decodeParams decodes JSON-RPC params into a request message
+func decodeParams(params json.RawMessage, target interface{}) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	return json.Unmarshal(params, target)
+}
+
+// newJSONRPCError builds a JSON-RPC error response
+func newJSONRPCError(id interface{}, code int, message string, data interface{}) *JSONRPCResponse {
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		Error:   jsonrpc.NewError(code, message, data),
+		ID:      id,
+	}
+}
+
+// grpcErrorToJSONRPC converts gRPC errors to JSON-RPC errors
+func grpcErrorToJSONRPC(err error) *JSONRPCError {
+	st, ok := status.FromError(err)
+	if !ok {
+		return jsonrpc.NewError(JSONRPCInternalError, "Internal error", nil)
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument:
+		if fields := fieldViolations(st); len(fields) > 0 {
+			return jsonrpc.NewError(JSONRPCInvalidParams, "Invalid params: "+st.Message(), fiber.Map{"fields": fields})
+		}
+		return jsonrpc.NewError(JSONRPCInvalidParams, "Invalid params", st.Message())
+	case codes.Unimplemented:
+		return jsonrpc.NewError(JSONRPCMethodNotFound, "Method not found", st.Message())
+	default:
+		return jsonrpc.NewError(JSONRPCServerError, st.Message(), st.Code().String())
+	}
+}
+
+// fieldViolations maps each field named in the status's BadRequest details to
+// the reason it failed validation
+func fieldViolations(st *status.Status) map[string]string {
+	fields := make(map[string]string)
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, violation := range badRequest.GetFieldViolations() {
+			fields[violation.GetField()] = violation.GetDescription()
+		}
+	}
+	return fields
+}
\ No newline at end of file
@@ -0,0 +1,355 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yhonda-ohishi/db-handler-server/internal/client"
+	"github.com/yhonda-ohishi/db-handler-server/internal/jsonrpc"
+	"github.com/yhonda-ohishi/db-handler-server/internal/logger"
+	"github.com/yhonda-ohishi/db-handler-server/internal/services"
+	"google.golang.org/grpc"
+)
+
+// setupJSONRPCTestApp starts the services on bufconn and mounts the JSON-RPC endpoint
+func setupJSONRPCTestApp(t *testing.T, config JSONRPCConfig) *fiber.App {
+	t.Helper()
+
+	bufconnClient := client.NewBufconnClient()
+	grpcServer := grpc.NewServer()
+	services.NewServiceRegistry().RegisterAll(grpcServer)
+
+	go func() {
+		_ = grpcServer.Serve(bufconnClient.GetListener())
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := bufconnClient.GetConnection(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	app := fiber.New()
+	NewJSONRPCRoutesWithConfig(conn, config).RegisterRoutes(app)
+	return app
+}
+
+func callJSONRPC(t *testing.T, app *fiber.App, payload string) map[string]interface{} {
+	t.Helper()
+
+	req := httptest.NewRequest("POST", "/jsonrpc", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	return result
+}
+
+func TestJSONRPCRequestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, logger.Initialize(logger.Config{
+		Level:  "info",
+		Format: "json",
+		Output: &buf,
+	}))
+
+	app := setupJSONRPCTestApp(t, DefaultJSONRPCConfig())
+
+	result := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "user.get", "params": {"id": "missing-user"}, "id": 7}`)
+	assert.Equal(t, "2.0", result["jsonrpc"])
+	assert.NotNil(t, result["error"])
+
+	var logEntry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logEntry))
+
+	assert.Equal(t, "user.get", logEntry["rpc_method"])
+	assert.Equal(t, float64(7), logEntry["rpc_id"])
+	assert.Equal(t, "error", logEntry["outcome"])
+	assert.Equal(t, "[REDACTED]", logEntry["rpc_params"])
+	assert.NotContains(t, buf.String(), "missing-user")
+
+	// Successful calls are logged at info with params when enabled
+	buf.Reset()
+	app = setupJSONRPCTestApp(t, JSONRPCConfig{LogRequests: true, LogParams: true})
+
+	result = callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "user.list", "params": {"page_size": 5}, "id": "list-1"}`)
+	assert.Nil(t, result["error"])
+
+	logEntry = map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logEntry))
+
+	assert.Equal(t, "info", logEntry["level"])
+	assert.Equal(t, "user.list", logEntry["rpc_method"])
+	assert.Equal(t, "list-1", logEntry["rpc_id"])
+	assert.Equal(t, "success", logEntry["outcome"])
+	assert.Contains(t, logEntry["rpc_params"], "page_size")
+}
+
+func TestJSONRPCErrors(t *testing.T) {
+	app := setupJSONRPCTestApp(t, JSONRPCConfig{})
+
+	tests := []struct {
+		name    string
+		payload string
+		code    float64
+	}{
+		{"parse error", `{invalid`, JSONRPCParseError},
+		{"invalid version", `{"jsonrpc": "1.0", "method": "user.get", "id": 1}`, JSONRPCInvalidRequest},
+		{"method not found", `{"jsonrpc": "2.0", "method": "nonexistent.method", "id": 1}`, JSONRPCMethodNotFound},
+		{"not found", `{"jsonrpc": "2.0", "method": "user.get", "params": {"id": "missing"}, "id": 1}`, JSONRPCServerError},
+		{"invalid params", `{"jsonrpc": "2.0", "method": "user.get", "params": {}, "id": 1}`, JSONRPCInvalidParams},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callJSONRPC(t, app, tt.payload)
+			require.NotNil(t, result["error"])
+			errorObj := result["error"].(map[string]interface{})
+			assert.Equal(t, tt.code, errorObj["code"])
+		})
+	}
+}
+
+func TestJSONRPCCustomMethod(t *testing.T) {
+	routes := NewJSONRPCRoutesWithConfig(nil, JSONRPCConfig{})
+	routes.Registry().Register("system.echo", func(ctx context.Context, params json.RawMessage) (interface{}, *JSONRPCError) {
+		var args map[string]interface{}
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, jsonrpc.NewError(JSONRPCInvalidParams, "Invalid params", err.Error())
+		}
+		return args, nil
+	})
+
+	app := fiber.New()
+	routes.RegisterRoutes(app)
+
+	result := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "system.echo", "params": {"message": "hello"}, "id": 7}`)
+	require.Nil(t, result["error"])
+	assert.Equal(t, float64(7), result["id"])
+	assert.Equal(t, map[string]interface{}{"message": "hello"}, result["result"])
+
+	// Built-in methods stay registered but report the missing backend
+	result = callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "user.get", "params": {"id": "user-1"}, "id": 8}`)
+	require.NotNil(t, result["error"])
+	assert.Equal(t, float64(JSONRPCInternalError), result["error"].(map[string]interface{})["code"])
+
+	assert.Contains(t, routes.Registry().Methods(), "transaction.history")
+}
+
+func TestJSONRPCEmptyListResult(t *testing.T) {
+	app := setupJSONRPCTestApp(t, JSONRPCConfig{})
+
+	req := httptest.NewRequest("POST", "/jsonrpc", strings.NewReader(
+		`{"jsonrpc": "2.0", "method": "transaction.history", "params": {"card_id": "no-such-card"}, "id": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body struct {
+		Result map[string]json.RawMessage `json:"result"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "[]", string(body.Result["transactions"]))
+}
+
+func TestJSONRPCCardMethodsMatchREST(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	created := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "card.create", "params": {"user_id": "rpc-card-user", "card_number": "1234-5678-9012-3456", "vehicle_type": 2}, "id": 1}`)
+	require.Nil(t, created["error"])
+	card := created["result"].(map[string]interface{})
+	cardID := card["id"].(string)
+	assert.Equal(t, "3456", card["card_last4"])
+	assert.Equal(t, "VEHICLE_TYPE_KEI", card["vehicle_type"])
+
+	updated := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "card.update", "params": {"id": "`+cardID+`", "vehicle_number": "品川 500 あ 1234"}, "id": 2}`)
+	require.Nil(t, updated["error"])
+	assert.Equal(t, "品川 500 あ 1234", updated["result"].(map[string]interface{})["vehicle_number"])
+
+	got := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "card.get", "params": {"id": "`+cardID+`"}, "id": 3}`)
+	require.Nil(t, got["error"])
+
+	listed := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "card.list", "params": {"user_id": "rpc-card-user"}, "id": 4}`)
+	require.Nil(t, listed["error"])
+	rpcCards := listed["result"].(map[string]interface{})["cards"].([]interface{})
+	require.Len(t, rpcCards, 1)
+	assert.Equal(t, got["result"], rpcCards[0])
+
+	// REST returns the same card
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/cards?user_id=rpc-card-user", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var restList map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&restList))
+	assert.Equal(t, rpcCards, restList["cards"])
+
+	// gRPC errors are translated as for other methods
+	missing := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "card.get", "params": {"id": "missing-card"}, "id": 5}`)
+	rpcErr := missing["error"].(map[string]interface{})
+	assert.Equal(t, float64(JSONRPCServerError), rpcErr["code"])
+	assert.Equal(t, "NotFound", rpcErr["data"])
+
+	invalid := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "card.create", "params": {"user_id": "rpc-card-user"}, "id": 6}`)
+	assert.Equal(t, float64(JSONRPCInvalidParams), invalid["error"].(map[string]interface{})["code"])
+}
+
+func TestJSONRPCValidationFieldErrors(t *testing.T) {
+	app := setupJSONRPCTestApp(t, JSONRPCConfig{})
+
+	result := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "user.create", "params": {"name": "No Email"}, "id": 1}`)
+	require.NotNil(t, result["error"])
+	rpcErr := result["error"].(map[string]interface{})
+	assert.Equal(t, float64(JSONRPCInvalidParams), rpcErr["code"])
+	assert.Equal(t, "Invalid params: email is required", rpcErr["message"])
+	assert.Equal(t, map[string]interface{}{
+		"fields": map[string]interface{}{"email": "required"},
+	}, rpcErr["data"])
+
+	// Every failing field is reported, not just the first
+	result = callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "user.create", "params": {"email": "not-an-email"}, "id": 2}`)
+	rpcErr = result["error"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"fields": map[string]interface{}{"email": "invalid_format", "name": "required"},
+	}, rpcErr["data"])
+
+	created := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "user.create", "params": {"email": "fields@example.com", "name": "Fields"}, "id": 3}`)
+	require.Nil(t, created["error"])
+	userID := created["result"].(map[string]interface{})["id"].(string)
+
+	result = callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "user.update", "params": {"id": "`+userID+`", "email": "broken"}, "id": 4}`)
+	rpcErr = result["error"].(map[string]interface{})
+	assert.Equal(t, float64(JSONRPCInvalidParams), rpcErr["code"])
+	assert.Equal(t, map[string]interface{}{
+		"fields": map[string]interface{}{"email": "invalid_format"},
+	}, rpcErr["data"])
+}
+
+func TestJSONRPCMetrics(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Monitoring.MetricsEnabled = true
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+	app := gw.GetHTTPHandler()
+
+	created := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "user.create", "params": {"email": "metrics@example.com", "name": "Metrics User"}, "id": 1}`)
+	require.Nil(t, created["error"])
+	userID := created["result"].(map[string]interface{})["id"].(string)
+
+	got := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "user.get", "params": {"id": "`+userID+`"}, "id": 2}`)
+	require.Nil(t, got["error"])
+	missing := callJSONRPC(t, app, `{"jsonrpc": "2.0", "method": "no.such.method", "id": 3}`)
+	require.NotNil(t, missing["error"])
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `jsonrpc_requests_total{deployment_mode="single",method="user.get",outcome="success"} 1`)
+	assert.Contains(t, string(body), `jsonrpc_requests_total{deployment_mode="single",method="unknown",outcome="-32601"} 1`)
+	assert.Contains(t, string(body), `jsonrpc_request_duration_seconds_count{deployment_mode="single",method="user.get"} 1`)
+}
+
+func TestJSONRPCSingleAndBatchOnSameRoute(t *testing.T) {
+	app := setupJSONRPCTestApp(t, DefaultJSONRPCConfig())
+
+	post := func(payload string) []byte {
+		req := httptest.NewRequest("POST", "/jsonrpc", strings.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return body
+	}
+
+	// A single object gets a single response
+	var single map[string]interface{}
+	require.NoError(t, json.Unmarshal(post(`{"jsonrpc": "2.0", "method": "user.list", "params": {}, "id": 1}`), &single))
+	assert.Nil(t, single["error"])
+	assert.NotNil(t, single["result"])
+	assert.Equal(t, float64(1), single["id"])
+
+	// An array gets an array of responses in request order
+	var batch []map[string]interface{}
+	require.NoError(t, json.Unmarshal(post(` [
+		{"jsonrpc": "2.0", "method": "user.list", "params": {}, "id": "a"},
+		{"jsonrpc": "2.0", "method": "no.such.method", "id": "b"},
+		42
+	]`), &batch))
+	require.Len(t, batch, 3)
+	assert.Equal(t, "a", batch[0]["id"])
+	assert.NotNil(t, batch[0]["result"])
+	assert.Equal(t, "b", batch[1]["id"])
+	assert.Equal(t, float64(JSONRPCMethodNotFound), batch[1]["error"].(map[string]interface{})["code"])
+	assert.Nil(t, batch[2]["id"])
+	assert.Equal(t, float64(JSONRPCInvalidRequest), batch[2]["error"].(map[string]interface{})["code"])
+
+	// An empty batch is an invalid request
+	var empty map[string]interface{}
+	require.NoError(t, json.Unmarshal(post(`[]`), &empty))
+	assert.Equal(t, float64(JSONRPCInvalidRequest), empty["error"].(map[string]interface{})["code"])
+}
+
+func TestJSONRPCContentType(t *testing.T) {
+	app := setupJSONRPCTestApp(t, DefaultJSONRPCConfig())
+	payload := `{"jsonrpc": "2.0", "method": "user.list", "params": {}, "id": 1}`
+
+	tests := []struct {
+		name        string
+		contentType string
+		accepted    bool
+	}{
+		{"json", "application/json", true},
+		{"json with charset", "application/json; charset=utf-8", true},
+		{"json-rpc", "application/json-rpc", true},
+		{"case insensitive", "Application/JSON", true},
+		{"missing", "", false},
+		{"wrong", "text/plain", false},
+		{"form", "application/x-www-form-urlencoded", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/jsonrpc", strings.NewReader(payload))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			var result map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+			if tt.accepted {
+				assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+				assert.Nil(t, result["error"])
+				assert.NotNil(t, result["result"])
+				return
+			}
+
+			assert.Equal(t, fiber.StatusUnsupportedMediaType, resp.StatusCode)
+			require.NotNil(t, result["error"])
+			errorObj := result["error"].(map[string]interface{})
+			assert.Equal(t, float64(JSONRPCInvalidRequest), errorObj["code"])
+			assert.Contains(t, errorObj["message"], "Content-Type must be application/json")
+		})
+	}
+}
\ No newline at end of file
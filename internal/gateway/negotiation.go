@@ -0,0 +1,79 @@
+package gateway
+
+import (
+	"errors"
+	"mime"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MIMEApplicationProtobuf is the content type for binary protobuf bodies
+const MIMEApplicationProtobuf = "application/x-protobuf"
+
+var (
+	restMarshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	restUnmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// wantsProtobuf reports whether the client prefers a protobuf response.
+// JSON is listed first so it wins when the Accept header is missing or "*/*".
+func wantsProtobuf(c *fiber.Ctx) bool {
+	return c.Accepts(fiber.MIMEApplicationJSON, MIMEApplicationProtobuf) == MIMEApplicationProtobuf
+}
+
+// sendProto writes msg as protobuf or JSON depending on the Accept header
+func sendProto(c *fiber.Ctx, statusCode int, msg proto.Message) error {
+	var (
+		data        []byte
+		contentType string
+		err         error
+	)
+	if wantsProtobuf(c) {
+		data, err = proto.Marshal(msg)
+		contentType = MIMEApplicationProtobuf
+	} else {
+		data, err = restMarshalOptions.Marshal(msg)
+		contentType = fiber.MIMEApplicationJSON
+	}
+	if err != nil {
+		return restError(c, 500, "Failed to encode response")
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Status(statusCode).Send(data)
+}
+
+// errUnsupportedContentType is returned by parseProto for bodies that are
+// neither JSON nor protobuf
+var errUnsupportedContentType = errors.New("unsupported content type")
+
+// parseProto decodes the request body into msg as protobuf or JSON depending
+// on the Content-Type header. A missing Content-Type is read as JSON; any
+// other type returns errUnsupportedContentType.
+func parseProto(c *fiber.Ctx, msg proto.Message) error {
+	switch requestMediaType(c) {
+	case MIMEApplicationProtobuf:
+		return proto.Unmarshal(c.Body(), msg)
+	case "", fiber.MIMEApplicationJSON:
+		return restUnmarshalOptions.Unmarshal(c.Body(), msg)
+	default:
+		return errUnsupportedContentType
+	}
+}
+
+// requestMediaType returns the lowercased media type of the request's
+// Content-Type without parameters, or the raw header when it can't be parsed
+func requestMediaType(c *fiber.Ctx) string {
+	contentType := strings.ToLower(strings.TrimSpace(string(c.Request().Header.ContentType())))
+	if contentType == "" {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mediaType
+}
\ No newline at end of file
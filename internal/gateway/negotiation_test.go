@@ -0,0 +1,51 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProtoRejectsUnsupportedContentType(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	tests := []struct {
+		contentType string
+		wantStatus  int
+	}{
+		{"", fiber.StatusCreated},
+		{"application/json", fiber.StatusCreated},
+		{"Application/JSON; charset=utf-8", fiber.StatusCreated},
+		{"text/plain", fiber.StatusUnsupportedMediaType},
+		{"application/xml", fiber.StatusUnsupportedMediaType},
+		{"application/json;;", fiber.StatusUnsupportedMediaType},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			body := fmt.Sprintf(`{"email": "content-type-%d@example.com", "name": "Content Type"}`, i)
+			req := httptest.NewRequest("POST", "/api/v1/users", strings.NewReader(body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			if tt.wantStatus == fiber.StatusUnsupportedMediaType {
+				var result map[string]interface{}
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+				errorObj := result["error"].(map[string]interface{})
+				assert.Equal(t, "INVALID_ARGUMENT", errorObj["code"])
+				assert.Contains(t, errorObj["message"], "Unsupported Content-Type")
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,60 @@
+package gateway
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc/codes"
+)
+
+// newEmptyPathParamMiddleware answers 400 InvalidArgument when a request path
+// stops where a route expects a path parameter, such as GET /api/v1/users/
+// for /api/v1/users/:id. Without it the trailing slash would match the
+// collection route (or 404), leaving clients to guess whether the ID was
+// missing or unknown; 404 is kept for well-formed IDs that are not found.
+// The routes are read from app on the first request with a trailing slash.
+func newEmptyPathParamMiddleware(app *fiber.App) fiber.Handler {
+	var once sync.Once
+	var params map[string]string
+
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		if len(path) <= 1 || !strings.HasSuffix(path, "/") {
+			return c.Next()
+		}
+
+		once.Do(func() {
+			params = emptyPathParams(app.GetRoutes(true))
+		})
+		if name, ok := params[c.Method()+" "+path]; ok {
+			return writeRESTError(c, fiber.StatusBadRequest, codes.InvalidArgument, "missing path parameter: "+name, nil)
+		}
+		return c.Next()
+	}
+}
+
+// emptyPathParams maps "METHOD /static/prefix/" to the name of the parameter
+// that follows the prefix in a route such as /static/prefix/:name
+func emptyPathParams(routes []fiber.Route) map[string]string {
+	params := make(map[string]string)
+	for _, route := range routes {
+		segments := strings.Split(route.Path, "/")
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, "*") || strings.HasPrefix(segment, "+") {
+				break
+			}
+			if !strings.HasPrefix(segment, ":") {
+				continue
+			}
+			prefix := strings.Join(segments[:i], "/") + "/"
+			name := strings.TrimSuffix(strings.TrimPrefix(segment, ":"), "?")
+			// Optional parameters may be left out
+			if !strings.HasSuffix(segment, "?") {
+				params[route.Method+" "+prefix] = name
+			}
+			break
+		}
+	}
+	return params
+}
\ No newline at end of file
@@ -0,0 +1,49 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmptyPathParamIsInvalidArgument(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	code, body := doRequest(t, app, "GET", "/api/v1/users/", "")
+	require.Equal(t, fiber.StatusBadRequest, code)
+	errorObj, ok := body["error"].(map[string]interface{})
+	require.True(t, ok, "error should be an object: %v", body)
+	assert.Equal(t, "INVALID_ARGUMENT", errorObj["code"])
+	assert.Equal(t, "missing path parameter: id", errorObj["message"])
+
+	code, body = doRequest(t, app, "GET", "/api/v1/users/nonexistent", "")
+	require.Equal(t, fiber.StatusNotFound, code)
+	assert.Equal(t, "NOT_FOUND", body["error"].(map[string]interface{})["code"])
+
+	// The collection route without a trailing slash is unaffected
+	code, _ = doRequest(t, app, "GET", "/api/v1/users", "")
+	assert.Equal(t, fiber.StatusOK, code)
+
+	// Other methods only fail when they have a matching parameter route
+	code, _ = doRequest(t, app, "DELETE", "/api/v1/users/", "")
+	assert.Equal(t, fiber.StatusBadRequest, code)
+	code, _ = doRequest(t, app, "POST", "/api/v1/users/", `{"email": "slash@example.com", "name": "Slash"}`)
+	assert.Equal(t, fiber.StatusCreated, code)
+}
+
+func TestEmptyPathParams(t *testing.T) {
+	params := emptyPathParams([]fiber.Route{
+		{Method: "GET", Path: "/api/v1/users/:id"},
+		{Method: "GET", Path: "/api/v1/payments/statement/:userId/:year/:month/pdf"},
+		{Method: "GET", Path: "/api/v1/reports/:kind?"},
+		{Method: "GET", Path: "/static/*"},
+		{Method: "GET", Path: "/api/v1/users"},
+	})
+
+	assert.Equal(t, map[string]string{
+		"GET /api/v1/users/":              "id",
+		"GET /api/v1/payments/statement/": "userId",
+	}, params)
+}
\ No newline at end of file
@@ -2,17 +2,32 @@ package gateway
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cache"
-	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/monitor"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
 	"github.com/gofiber/fiber/v2/utils"
+	"github.com/valyala/fasthttp"
 	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+	applogger "github.com/yhonda-ohishi/db-handler-server/internal/logger"
+)
+
+// Rate limit keys selecting what requests are counted against
+const (
+	// RateLimitKeyUser limits each authenticated user separately, falling
+	// back to the client IP for other requests. Only identities verified by
+	// an authentication step count; X-User-ID is a client claim and ignored.
+	// The gateway has no authentication middleware yet, so until one calls
+	// logger.ContextWithAuthenticatedUserID every request is keyed by IP.
+	RateLimitKeyUser = "user"
+	// RateLimitKeyIP limits each client IP
+	RateLimitKeyIP = "ip"
 )
 
 // PerformanceConfig holds performance optimization settings
@@ -20,16 +35,37 @@ type PerformanceConfig struct {
 	EnableCompression bool
 	EnableCaching     bool
 	EnableRateLimit   bool
+	// EnableProfiling serves pprof on the admin listener behind the admin
+	// token; see checkProfiling for when it is refused
 	EnableProfiling   bool
 	EnableMonitoring  bool
+	// ForceProfiling allows EnableProfiling in separate mode
+	ForceProfiling    bool
+
+	// Compression settings
+	// CompressionMinSize leaves response bodies smaller than this many bytes uncompressed
+	CompressionMinSize int
+	// CompressionSkipTypes lists content types, or type prefixes such as
+	// "image/", that are already compressed and sent as-is
+	CompressionSkipTypes []string
+	// EnableBrotli offers br to clients that accept it, ahead of gzip and deflate
+	EnableBrotli bool
 
 	// Cache settings
 	CacheDuration    time.Duration
 	CacheMaxSize     int
+	// HonorNoCache lets clients bypass both caches with Cache-Control: no-cache
+	HonorNoCache     bool
 
 	// Rate limiting
 	RateLimit        int
 	RateLimitWindow  time.Duration
+	// RateLimitKey is RateLimitKeyUser or RateLimitKeyIP. Like
+	// TrustedProxies it has no app config key and is set in code only.
+	RateLimitKey     string
+	// TrustedProxies lists the load balancer IPs or CIDRs whose
+	// X-Forwarded-For header gives the client IP
+	TrustedProxies   []string
 
 	// Connection pooling
 	MaxConnections   int
@@ -46,11 +82,20 @@ func DefaultPerformanceConfig() *PerformanceConfig {
 		EnableProfiling:   false, // Enable only in debug mode
 		EnableMonitoring:  true,
 
+		CompressionMinSize: 1024,
+		CompressionSkipTypes: []string{
+			"image/", "video/", "audio/", "text/event-stream",
+			"application/pdf", "application/zip", "application/gzip",
+		},
+		EnableBrotli: true,
+
 		CacheDuration:     5 * time.Minute,
 		CacheMaxSize:      1000,
+		HonorNoCache:      true,
 
 		RateLimit:         1000, // requests per minute
 		RateLimitWindow:   time.Minute,
+		RateLimitKey:      RateLimitKeyIP,
 
 		MaxConnections:    10000,
 		KeepAlive:        true,
@@ -58,10 +103,26 @@ func DefaultPerformanceConfig() *PerformanceConfig {
 	}
 }
 
+// withConfig returns a copy of p with the settings of perf that are set
+func (p *PerformanceConfig) withConfig(perf config.PerformanceConfig) *PerformanceConfig {
+	merged := *p
+	if perf.RateLimit > 0 {
+		merged.RateLimit = perf.RateLimit
+	}
+	if perf.CacheDuration > 0 {
+		merged.CacheDuration = perf.CacheDuration
+	}
+	return &merged
+}
+
 // OptimizedGateway provides a performance-optimized gateway implementation
 type OptimizedGateway struct {
 	*SimpleGateway
 	perfConfig    *PerformanceConfig
+	// rateLimiter holds the current rate limiting fiber.Handler
+	rateLimiter    atomic.Value
+	// rateLimit is the limit rateLimiter enforces
+	rateLimit      atomic.Int64
 	connectionPool *ConnectionPool
 	responseCache  *ResponseCache
 }
@@ -82,20 +143,25 @@ type ConnectionEntry struct {
 
 // ResponseCache provides intelligent response caching
 type ResponseCache struct {
-	mu       sync.RWMutex
+	mu       sync.Mutex
 	entries  map[string]*CacheEntry
 	maxSize  int
 	duration time.Duration
+	hits     atomic.Int64
+	misses   atomic.Int64
 }
 
 type CacheEntry struct {
-	data      []byte
-	timestamp time.Time
-	hits      int64
+	data        []byte
+	contentType string
+	timestamp   time.Time
+	hits        int64
 }
 
 // NewOptimizedGateway creates a performance-optimized gateway
 func NewOptimizedGateway(cfg *config.Config, perfConfig *PerformanceConfig) *OptimizedGateway {
+	perfConfig = perfConfig.withConfig(cfg.Performance)
+
 	// Create base gateway with optimized fiber config
 	app := fiber.New(fiber.Config{
 		AppName:               "ETC Meisai Gateway (Optimized)",
@@ -108,6 +174,7 @@ func NewOptimizedGateway(cfg *config.Config, perfConfig *PerformanceConfig) *Opt
 		DisableDefaultContentType: true,
 		DisableHeaderNormalizing: true,
 		ReduceMemoryUsage:    true,
+		BodyLimit:            bodyLimitsFromConfig(cfg.Server).Max(),
 
 		// Optimized timeouts
 		ReadTimeout:          30 * time.Second,
@@ -117,30 +184,22 @@ func NewOptimizedGateway(cfg *config.Config, perfConfig *PerformanceConfig) *Opt
 		// Connection limits
 		Concurrency:          perfConfig.MaxConnections,
 
+		// Client IPs come from X-Forwarded-For only behind trusted proxies
+		EnableTrustedProxyCheck: len(perfConfig.TrustedProxies) > 0,
+		TrustedProxies:          perfConfig.TrustedProxies,
+		ProxyHeader:             proxyHeader(perfConfig.TrustedProxies),
+		EnableIPValidation:      true,
+
 		// Error handling
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			return c.Status(code).JSON(fiber.Map{
-				"error": err.Error(),
-				"code":  code,
-			})
-		},
+		ErrorHandler: restErrorHandler,
 
 		// JSON optimization disabled due to API changes
 		// JSONEncoder: utils.UnsafeString,
 		// JSONDecoder: utils.UnsafeBytes,
 	})
 
-	baseGateway := &SimpleGateway{
-		config: cfg,
-		app:    app,
-	}
-
 	optimized := &OptimizedGateway{
-		SimpleGateway: baseGateway,
+		SimpleGateway: newSimpleGatewayWithApp(cfg, app),
 		perfConfig:    perfConfig,
 		connectionPool: NewConnectionPool(perfConfig.MaxConnections),
 		responseCache:  NewResponseCache(perfConfig.CacheMaxSize, perfConfig.CacheDuration),
@@ -151,15 +210,55 @@ func NewOptimizedGateway(cfg *config.Config, perfConfig *PerformanceConfig) *Opt
 	return optimized
 }
 
+// ApplyPerformanceConfig swaps in the rate limit and cache duration of perf
+// while serving; other performance settings only take effect on restart
+func (g *OptimizedGateway) ApplyPerformanceConfig(perf *PerformanceConfig) {
+	if g.perfConfig.EnableRateLimit && g.rateLimit.Swap(int64(perf.RateLimit)) != int64(perf.RateLimit) {
+		// Counters restart with the new limit
+		g.rateLimiter.Store(g.newRateLimiter(perf.RateLimit))
+	}
+	g.responseCache.SetDuration(perf.CacheDuration)
+}
+
+// proxyHeader returns the header carrying the client IP when requests
+// arrive through trusted proxies
+func proxyHeader(trustedProxies []string) string {
+	if len(trustedProxies) == 0 {
+		return ""
+	}
+	return fiber.HeaderXForwardedFor
+}
+
+// newRateLimiter limits each client to max requests per RateLimitWindow
+func (g *OptimizedGateway) newRateLimiter(max int) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:          max,
+		Expiration:   g.perfConfig.RateLimitWindow,
+		KeyGenerator: g.rateLimitKey,
+		LimitReached: func(c *fiber.Ctx) error {
+			return restError(c, fiber.StatusTooManyRequests, "Rate limit exceeded")
+		},
+	})
+}
+
+// rateLimitKey returns the key a request is counted against: the
+// authenticated user ID when keyed by user, otherwise the client IP
+func (g *OptimizedGateway) rateLimitKey(c *fiber.Ctx) string {
+	if g.perfConfig.RateLimitKey == RateLimitKeyUser {
+		if userID, ok := applogger.GetAuthenticatedUserIDFromContext(c.UserContext()); ok && userID != "" {
+			return "user:" + userID
+		}
+	}
+	return "ip:" + c.IP()
+}
+
 // setupPerformanceMiddleware configures performance-oriented middleware
 func (g *OptimizedGateway) setupPerformanceMiddleware() {
 	// Recovery middleware (keep first)
 	g.app.Use(func(c *fiber.Ctx) error {
 		defer func() {
 			if r := recover(); r != nil {
-				c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": "Internal server error",
-				})
+				_ = restError(c, fiber.StatusInternalServerError, "Internal server error")
 			}
 		}()
 		return c.Next()
@@ -167,52 +266,37 @@ func (g *OptimizedGateway) setupPerformanceMiddleware() {
 
 	// Compression middleware
 	if g.perfConfig.EnableCompression {
-		g.app.Use(compress.New(compress.Config{
-			Level: compress.LevelBestSpeed, // Favor speed over compression ratio
-		}))
+		g.app.Use(g.compressionMiddleware())
 	}
 
-	// Rate limiting middleware
+	// Rate limiting middleware, rebuilt by ApplyPerformanceConfig
 	if g.perfConfig.EnableRateLimit {
-		g.app.Use(limiter.New(limiter.Config{
-			Max:        g.perfConfig.RateLimit,
-			Expiration: g.perfConfig.RateLimitWindow,
-			KeyGenerator: func(c *fiber.Ctx) string {
-				return c.IP()
-			},
-			LimitReached: func(c *fiber.Ctx) error {
-				return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-					"error": "Rate limit exceeded",
-				})
-			},
-		}))
+		g.rateLimit.Store(int64(g.perfConfig.RateLimit))
+		g.rateLimiter.Store(g.newRateLimiter(g.perfConfig.RateLimit))
+		g.app.Use(func(c *fiber.Ctx) error {
+			return g.rateLimiter.Load().(fiber.Handler)(c)
+		})
 	}
 
 	// Response caching middleware
 	if g.perfConfig.EnableCaching {
-		g.app.Use(cache.New(cache.Config{
-			Expiration:   g.perfConfig.CacheDuration,
-			CacheControl: true,
-			KeyGenerator: func(c *fiber.Ctx) string {
-				return utils.CopyString(c.OriginalURL())
-			},
-			// Only cache GET requests
-			Next: func(c *fiber.Ctx) bool {
-				return c.Method() != fiber.MethodGet
-			},
-		}))
+		g.app.Use(g.responseCacheMiddleware())
 	}
 
 	// Performance monitoring
 	if g.perfConfig.EnableMonitoring {
-		g.app.Get("/debug/monitor", monitor.New(monitor.Config{
+		g.operationalRouter().Get("/debug/monitor", monitor.New(monitor.Config{
 			Title: "gRPC Gateway Performance Monitor",
 		}))
 	}
 
-	// Profiling endpoints (debug mode only)
+	// Profiling endpoints (debug mode only), admin listener and token required
 	if g.perfConfig.EnableProfiling {
-		g.app.Use(pprof.New())
+		if err := checkProfiling(g.config, g.perfConfig); err != nil {
+			applogger.WithError(err).Warn("Profiling disabled")
+		} else {
+			g.adminApp.Use("/debug/pprof", newAdminAuthMiddleware(g.config.Server.AdminToken), pprof.New())
+		}
 	}
 
 	// Request ID and timing middleware
@@ -232,6 +316,165 @@ func (g *OptimizedGateway) setupPerformanceMiddleware() {
 	})
 }
 
+// checkProfiling reports why pprof can't be served: it needs the admin
+// listener and an admin token, and separate mode (production) deployments
+// must set ForceProfiling
+func checkProfiling(cfg *config.Config, perf *PerformanceConfig) error {
+	if !cfg.Monitoring.AdminListener {
+		return fmt.Errorf("pprof requires monitoring.admin_listener")
+	}
+	if cfg.Server.AdminToken == "" {
+		return fmt.Errorf("pprof requires server.admin_token")
+	}
+	if cfg.IsSeparateMode() && !perf.ForceProfiling {
+		return fmt.Errorf("pprof is not enabled in separate mode unless forced")
+	}
+	return nil
+}
+
+// compressionMiddleware compresses responses with the best encoding the
+// client's Accept-Encoding allows, skipping small bodies and content types
+// that are already compressed
+func (g *OptimizedGateway) compressionMiddleware() fiber.Handler {
+	// Favor speed over compression ratio
+	noop := func(*fasthttp.RequestCtx) {}
+	compressor := fasthttp.CompressHandlerLevel(noop, fasthttp.CompressBestSpeed)
+	if g.perfConfig.EnableBrotli {
+		compressor = fasthttp.CompressHandlerBrotliLevel(noop, fasthttp.CompressBrotliBestSpeed, fasthttp.CompressBestSpeed)
+	}
+
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if g.shouldCompress(c) {
+			compressor(c.Context())
+		}
+		return nil
+	}
+}
+
+// shouldCompress reports whether the response is worth compressing
+func (g *OptimizedGateway) shouldCompress(c *fiber.Ctx) bool {
+	resp := c.Response()
+	if !resp.IsBodyStream() && len(resp.Body()) < g.perfConfig.CompressionMinSize {
+		return false
+	}
+
+	contentType := strings.ToLower(string(resp.Header.ContentType()))
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = strings.TrimSpace(contentType[:i])
+	}
+	for _, skip := range g.perfConfig.CompressionSkipTypes {
+		skip = strings.ToLower(skip)
+		if contentType == skip || (strings.HasSuffix(skip, "/") && strings.HasPrefix(contentType, skip)) {
+			return false
+		}
+	}
+	return true
+}
+
+// bypassCache reports whether the request asked to skip cached responses
+// with Cache-Control: no-cache and the gateway is configured to honor it
+func (g *OptimizedGateway) bypassCache(c *fiber.Ctx) bool {
+	if !g.perfConfig.HonorNoCache {
+		return false
+	}
+
+	for _, directive := range strings.Split(c.Get(fiber.HeaderCacheControl), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-cache") {
+			return true
+		}
+	}
+	return false
+}
+
+// personalizedRequest reports whether the response may depend on who is
+// asking: the request carries credentials or a user ID, or the caller was
+// authenticated. Such responses are never shared through the cache.
+func personalizedRequest(c *fiber.Ctx) bool {
+	// Header names are compared case-insensitively since the optimized
+	// gateway doesn't normalize them
+	personalized := false
+	c.Request().Header.VisitAll(func(key, value []byte) {
+		name := string(key)
+		if len(value) > 0 && (strings.EqualFold(name, fiber.HeaderAuthorization) || strings.EqualFold(name, "X-User-ID")) {
+			personalized = true
+		}
+	})
+	if personalized {
+		return true
+	}
+	userID, _ := applogger.GetAuthenticatedUserIDFromContext(c.UserContext())
+	return userID != ""
+}
+
+// cacheableResponse reports whether the handler allowed sharing its response
+func cacheableResponse(c *fiber.Ctx) bool {
+	for _, directive := range strings.Split(string(c.Response().Header.Peek(fiber.HeaderCacheControl)), ",") {
+		switch strings.ToLower(strings.TrimSpace(directive)) {
+		case "private", "no-store":
+			return false
+		}
+	}
+	return true
+}
+
+// cachedResponse returns the ResponseCache entry for the request URL unless
+// the request bypasses the cache
+func (g *OptimizedGateway) cachedResponse(c *fiber.Ctx) (*CacheEntry, bool) {
+	if g.bypassCache(c) {
+		return nil, false
+	}
+	return g.responseCache.lookup(c.OriginalURL())
+}
+
+// responseCacheMiddleware serves successful GET responses from the
+// ResponseCache, keyed by the original URL. Requests bypassing the cache and
+// personalized requests neither read nor populate it, and responses marked
+// Cache-Control private or no-store aren't stored. Successful writes purge
+// the cached GETs of the written resource and of its parent collection.
+func (g *OptimizedGateway) responseCacheMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+			err := c.Next()
+			if status := c.Response().StatusCode(); err == nil && status >= 200 && status < 300 {
+				path := strings.TrimSuffix(c.Path(), "/")
+				g.responseCache.InvalidatePath(path)
+				if i := strings.LastIndex(path, "/"); i > 0 {
+					g.responseCache.InvalidateExact(path[:i])
+				}
+			}
+			return err
+		}
+		if c.Method() != fiber.MethodGet || g.bypassCache(c) || personalizedRequest(c) {
+			return c.Next()
+		}
+
+		if entry, ok := g.cachedResponse(c); ok {
+			c.Set("X-Cache", "HIT")
+			if entry.contentType != "" {
+				c.Set(fiber.HeaderContentType, entry.contentType)
+			}
+			return c.Send(entry.data)
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		c.Set("X-Cache", "MISS")
+		if c.Response().StatusCode() == fiber.StatusOK && cacheableResponse(c) {
+			g.responseCache.SetWithContentType(
+				utils.CopyString(c.OriginalURL()),
+				utils.CopyBytes(c.Response().Body()),
+				string(c.Response().Header.ContentType()),
+			)
+		}
+		return nil
+	}
+}
+
 // NewConnectionPool creates a new connection pool
 func NewConnectionPool(maxSize int) *ConnectionPool {
 	pool := &ConnectionPool{
@@ -330,33 +573,103 @@ func NewResponseCache(maxSize int, duration time.Duration) *ResponseCache {
 
 // Get retrieves a cached response
 func (c *ResponseCache) Get(key string) ([]byte, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	entry, ok := c.lookup(key)
+	if !ok {
+		return nil, false
+	}
+	return entry.data, true
+}
+
+// SetDuration changes how long entries stay fresh, including existing ones
+func (c *ResponseCache) SetDuration(duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.duration = duration
+}
+
+// lookup returns the unexpired entry for key, counting the hit or miss
+func (c *ResponseCache) lookup(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	if entry, exists := c.entries[key]; exists {
 		if time.Since(entry.timestamp) < c.duration {
 			entry.hits++
-			return entry.data, true
+			c.hits.Add(1)
+			return entry, true
 		}
-		// Entry expired, will be cleaned up later
+		delete(c.entries, key)
 	}
 
+	c.misses.Add(1)
 	return nil, false
 }
 
 // Set stores a response in cache
 func (c *ResponseCache) Set(key string, data []byte) {
+	c.SetWithContentType(key, data, "")
+}
+
+// SetWithContentType stores a response and its content type in cache
+func (c *ResponseCache) SetWithContentType(key string, data []byte, contentType string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if len(c.entries) >= c.maxSize {
-		c.evictLeastUsed()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxSize {
+		c.evictExpired()
+		if len(c.entries) >= c.maxSize {
+			c.evictLeastUsed()
+		}
 	}
 
 	c.entries[key] = &CacheEntry{
-		data:      data,
-		timestamp: time.Now(),
-		hits:      0,
+		data:        data,
+		contentType: contentType,
+		timestamp:   time.Now(),
+		hits:        0,
+	}
+}
+
+// InvalidatePath removes entries for path and everything beneath it,
+// regardless of query string
+func (c *ResponseCache) InvalidatePath(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		keyPath := cacheKeyPath(key)
+		if keyPath == path || strings.HasPrefix(keyPath, path+"/") {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// InvalidateExact removes entries for path with any query string
+func (c *ResponseCache) InvalidateExact(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if cacheKeyPath(key) == path {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cacheKeyPath strips the query string from a cache key
+func cacheKeyPath(key string) string {
+	if i := strings.IndexByte(key, '?'); i >= 0 {
+		key = key[:i]
+	}
+	return strings.TrimSuffix(key, "/")
+}
+
+// evictExpired removes all expired entries
+func (c *ResponseCache) evictExpired() {
+	for key, entry := range c.entries {
+		if time.Since(entry.timestamp) >= c.duration {
+			delete(c.entries, key)
+		}
 	}
 }
 
@@ -383,18 +696,15 @@ func (g *OptimizedGateway) GetPerformanceStats() map[string]interface{} {
 	poolSize := len(g.connectionPool.connections)
 	g.connectionPool.mu.RUnlock()
 
-	g.responseCache.mu.RLock()
+	g.responseCache.mu.Lock()
 	cacheSize := len(g.responseCache.entries)
-	totalHits := int64(0)
-	for _, entry := range g.responseCache.entries {
-		totalHits += entry.hits
-	}
-	g.responseCache.mu.RUnlock()
+	g.responseCache.mu.Unlock()
 
 	return map[string]interface{}{
 		"connection_pool_size": poolSize,
 		"cache_size":          cacheSize,
-		"cache_hits":          totalHits,
+		"cache_hits":          g.responseCache.hits.Load(),
+		"cache_misses":        g.responseCache.misses.Load(),
 		"compression_enabled": g.perfConfig.EnableCompression,
 		"rate_limit_enabled":  g.perfConfig.EnableRateLimit,
 		"max_connections":     g.perfConfig.MaxConnections,
@@ -407,5 +717,5 @@ func (g *OptimizedGateway) Shutdown(ctx context.Context) error {
 		g.connectionPool.cleanupTicker.Stop()
 	}
 
-	return g.SimpleGateway.app.Shutdown()
+	return g.SimpleGateway.Shutdown(ctx)
 }
\ No newline at end of file
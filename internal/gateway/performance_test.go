@@ -0,0 +1,552 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	applogger "github.com/yhonda-ohishi/db-handler-server/internal/logger"
+)
+
+func TestNoCacheRequestBypassesCache(t *testing.T) {
+	perfConfig := DefaultPerformanceConfig()
+	perfConfig.EnableCompression = false
+	gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+	t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+
+	var calls int32
+	gw.app.Get("/cached", func(c *fiber.Ctx) error {
+		return c.SendString(strconv.Itoa(int(atomic.AddInt32(&calls, 1))))
+	})
+
+	get := func(cacheControl string) string {
+		req := httptest.NewRequest("GET", "/cached", nil)
+		if cacheControl != "" {
+			req.Header.Set(fiber.HeaderCacheControl, cacheControl)
+		}
+		resp, err := gw.app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	assert.Equal(t, "1", get(""))
+	assert.Equal(t, "1", get(""), "a normal request should be served from the cache")
+	assert.Equal(t, "2", get("no-cache"), "a no-cache request should reach the handler")
+	assert.Equal(t, "1", get(""), "a no-cache request should not replace the cached response")
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+
+}
+
+func TestResponseCacheNotSharedBetweenUsers(t *testing.T) {
+	perfConfig := DefaultPerformanceConfig()
+	perfConfig.EnableCompression = false
+	perfConfig.EnableRateLimit = false
+	gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+	t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+
+	gw.app.Get("/me", func(c *fiber.Ctx) error {
+		return c.SendString("profile of " + c.Get("X-User-ID") + c.Get(fiber.HeaderAuthorization))
+	})
+	gw.app.Get("/private", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderCacheControl, "private, max-age=60")
+		return c.SendString("private")
+	})
+
+	get := func(path, header, value string) (string, string) {
+		req := httptest.NewRequest("GET", path, nil)
+		if header != "" {
+			// Set verbatim, since the optimized gateway does not normalize
+			// header names
+			req.Header[header] = []string{value}
+		}
+		resp, err := gw.app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body), resp.Header.Get("X-Cache")
+	}
+
+	body, _ := get("/me", "X-User-ID", "alice")
+	assert.Equal(t, "profile of alice", body)
+	body, cache := get("/me", "X-User-ID", "bob")
+	assert.Equal(t, "profile of bob", body, "another user must not get alice's cached response")
+	assert.NotEqual(t, "HIT", cache)
+
+	body, _ = get("/me", fiber.HeaderAuthorization, "Bearer alice-token")
+	assert.Equal(t, "profile of Bearer alice-token", body)
+	body, cache = get("/me", fiber.HeaderAuthorization, "Bearer bob-token")
+	assert.Equal(t, "profile of Bearer bob-token", body)
+	assert.NotEqual(t, "HIT", cache)
+
+	// Personalized requests don't populate the cache for anonymous callers
+	body, cache = get("/me", "", "")
+	assert.Equal(t, "profile of ", body)
+	assert.Equal(t, "MISS", cache)
+
+	get("/private", "", "")
+	_, cache = get("/private", "", "")
+	assert.Equal(t, "MISS", cache, "Cache-Control: private responses must not be cached")
+}
+
+func TestResponseCacheHonorsNoCache(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		honorNoCache bool
+		cacheControl string
+		wantHit      bool
+	}{
+		{"normal request", true, "", true},
+		{"no-cache request", true, "max-age=0, no-cache", false},
+		{"no-cache ignored when disabled", false, "no-cache", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			perfConfig := DefaultPerformanceConfig()
+			perfConfig.HonorNoCache = tc.honorNoCache
+			gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+			t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+			gw.responseCache.Set("/cached", []byte("cached"))
+
+			reqCtx := &fasthttp.RequestCtx{}
+			reqCtx.Request.SetRequestURI("/cached")
+			reqCtx.Request.Header.Set(fiber.HeaderCacheControl, tc.cacheControl)
+			c := gw.app.AcquireCtx(reqCtx)
+			defer gw.app.ReleaseCtx(c)
+
+			entry, ok := gw.cachedResponse(c)
+			assert.Equal(t, tc.wantHit, ok)
+			if tc.wantHit {
+				assert.Equal(t, "cached", string(entry.data))
+			}
+		})
+	}
+}
+
+func TestResponseCacheServesRepeatedGet(t *testing.T) {
+	perfConfig := DefaultPerformanceConfig()
+	perfConfig.EnableCompression = false
+	perfConfig.CacheDuration = 50 * time.Millisecond
+	gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+	t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+
+	var calls int32
+	gw.app.Get("/items", func(c *fiber.Ctx) error {
+		atomic.AddInt32(&calls, 1)
+		return c.JSON(fiber.Map{"items": []string{"a", "b"}})
+	})
+
+	get := func() *http.Response {
+		resp, err := gw.app.Test(httptest.NewRequest("GET", "/items?page=1", nil))
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	first := get()
+	assert.Equal(t, "MISS", first.Header.Get("X-Cache"))
+
+	second := get()
+	assert.Equal(t, "HIT", second.Header.Get("X-Cache"))
+	assert.Equal(t, fiber.MIMEApplicationJSON, second.Header.Get("Content-Type"))
+	body, err := io.ReadAll(second.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items": ["a", "b"]}`, string(body))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	stats := gw.GetPerformanceStats()
+	assert.EqualValues(t, 1, stats["cache_hits"])
+	assert.EqualValues(t, 1, stats["cache_misses"])
+	assert.Equal(t, 1, stats["cache_size"])
+
+	// Expired entries are refetched
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, "MISS", get().Header.Get("X-Cache"))
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+func TestResponseCacheInvalidatedOnWrite(t *testing.T) {
+	perfConfig := DefaultPerformanceConfig()
+	perfConfig.EnableCompression = false
+	gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+	t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+
+	var mu sync.Mutex
+	names := map[string]string{"1": "Alice", "2": "Bob"}
+	gw.app.Get("/api/v1/users/:id", func(c *fiber.Ctx) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return c.SendString(names[c.Params("id")])
+	})
+	gw.app.Get("/api/v1/users", func(c *fiber.Ctx) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return c.SendString(names["1"] + "," + names["2"])
+	})
+	gw.app.Put("/api/v1/users/:id", func(c *fiber.Ctx) error {
+		mu.Lock()
+		defer mu.Unlock()
+		names[c.Params("id")] = string(c.Body())
+		return c.SendString("ok")
+	})
+
+	do := func(method, path, body string) (string, string) {
+		resp, err := gw.app.Test(httptest.NewRequest(method, path, strings.NewReader(body)))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(data), resp.Header.Get("X-Cache")
+	}
+
+	do("GET", "/api/v1/users/1", "")
+	do("GET", "/api/v1/users?page=1", "")
+	do("GET", "/api/v1/users/2", "")
+	body, cacheStatus := do("GET", "/api/v1/users/1", "")
+	require.Equal(t, "HIT", cacheStatus)
+	require.Equal(t, "Alice", body)
+
+	do("PUT", "/api/v1/users/1", "Alicia")
+
+	body, cacheStatus = do("GET", "/api/v1/users/1", "")
+	assert.Equal(t, "Alicia", body)
+	assert.Equal(t, "MISS", cacheStatus)
+
+	body, cacheStatus = do("GET", "/api/v1/users?page=1", "")
+	assert.Equal(t, "Alicia,Bob", body)
+	assert.Equal(t, "MISS", cacheStatus)
+
+	// Unrelated resources stay cached
+	_, cacheStatus = do("GET", "/api/v1/users/2", "")
+	assert.Equal(t, "HIT", cacheStatus)
+}
+
+func TestApplyPerformanceConfigSwapsRateLimit(t *testing.T) {
+	perfConfig := DefaultPerformanceConfig()
+	perfConfig.EnableCompression = false
+	perfConfig.EnableCaching = false
+	gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+	t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+
+	gw.app.Get("/limited", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	status := func() int {
+		resp, err := gw.app.Test(httptest.NewRequest("GET", "/limited", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, status())
+	assert.Equal(t, fiber.StatusOK, status())
+
+	reloaded := *perfConfig
+	reloaded.RateLimit = 1
+	reloaded.CacheDuration = time.Second
+	gw.ApplyPerformanceConfig(&reloaded)
+
+	assert.Equal(t, fiber.StatusOK, status())
+	assert.Equal(t, fiber.StatusTooManyRequests, status(), "the reloaded limit should apply without a restart")
+	assert.Equal(t, time.Second, gw.responseCache.duration)
+}
+
+func TestRateLimitKeyedByUser(t *testing.T) {
+	perfConfig := DefaultPerformanceConfig()
+	perfConfig.EnableCompression = false
+	perfConfig.EnableCaching = false
+	perfConfig.RateLimit = 1
+	perfConfig.RateLimitKey = RateLimitKeyUser
+	gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+	t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+
+	// Stands in for an authentication step ahead of the limiter
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if user := c.Get("X-Test-Authenticated-User"); user != "" {
+			c.SetUserContext(applogger.ContextWithAuthenticatedUserID(c.UserContext(), user))
+		}
+		return c.Next()
+	})
+	app.Use(gw.newRateLimiter(perfConfig.RateLimit))
+	app.Get("/limited", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	// Every request comes from the same IP
+	status := func(header, userID string) int {
+		req := httptest.NewRequest("GET", "/limited", nil)
+		if userID != "" {
+			req.Header.Set(header, userID)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, status("X-Test-Authenticated-User", "alice"))
+	assert.Equal(t, fiber.StatusOK, status("X-Test-Authenticated-User", "bob"), "users behind the same IP have independent limits")
+	assert.Equal(t, fiber.StatusTooManyRequests, status("X-Test-Authenticated-User", "alice"))
+	assert.Equal(t, fiber.StatusTooManyRequests, status("X-Test-Authenticated-User", "bob"))
+
+	// Unauthenticated requests share the IP's limit, whatever X-User-ID claims
+	assert.Equal(t, fiber.StatusOK, status("X-User-ID", "rotating-1"))
+	assert.Equal(t, fiber.StatusTooManyRequests, status("X-User-ID", "rotating-2"))
+	assert.Equal(t, fiber.StatusTooManyRequests, status("X-User-ID", "rotating-3"))
+	assert.Equal(t, fiber.StatusTooManyRequests, status("", ""))
+}
+
+func TestRateLimitDefaultsToIP(t *testing.T) {
+	perfConfig := DefaultPerformanceConfig()
+	require.Equal(t, RateLimitKeyIP, perfConfig.RateLimitKey)
+	perfConfig.EnableCompression = false
+	perfConfig.EnableCaching = false
+	perfConfig.RateLimit = 1
+	gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+	t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+	gw.app.Get("/limited", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	for i, want := range []int{fiber.StatusOK, fiber.StatusTooManyRequests, fiber.StatusTooManyRequests} {
+		req := httptest.NewRequest("GET", "/limited", nil)
+		req.Header.Set("X-User-ID", fmt.Sprintf("rotating-%d", i))
+		resp, err := gw.app.Test(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, want, resp.StatusCode, "request %d", i)
+	}
+}
+
+func TestRateLimitTrustedProxy(t *testing.T) {
+	newGateway := func(trustedProxies []string) *OptimizedGateway {
+		perfConfig := DefaultPerformanceConfig()
+		perfConfig.EnableCompression = false
+		perfConfig.EnableCaching = false
+		perfConfig.RateLimit = 1
+		perfConfig.RateLimitKey = RateLimitKeyIP
+		perfConfig.TrustedProxies = trustedProxies
+		gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+		t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+		gw.app.Get("/limited", func(c *fiber.Ctx) error {
+			return c.SendString("ok")
+		})
+		return gw
+	}
+
+	status := func(gw *OptimizedGateway, forwardedFor string) int {
+		req := httptest.NewRequest("GET", "/limited", nil)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		resp, err := gw.app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Behind a trusted proxy each forwarded client is limited separately
+	trusted := newGateway([]string{"0.0.0.0"})
+	assert.Equal(t, fiber.StatusOK, status(trusted, "203.0.113.1"))
+	assert.Equal(t, fiber.StatusOK, status(trusted, "203.0.113.2"))
+	assert.Equal(t, fiber.StatusTooManyRequests, status(trusted, "203.0.113.1"))
+
+	// Without one, X-Forwarded-For is ignored
+	untrusted := newGateway(nil)
+	assert.Equal(t, fiber.StatusOK, status(untrusted, "203.0.113.1"))
+	assert.Equal(t, fiber.StatusTooManyRequests, status(untrusted, "203.0.113.2"))
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	perfConfig := DefaultPerformanceConfig()
+	perfConfig.EnableCaching = false
+	perfConfig.EnableRateLimit = false
+	gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+	t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+
+	// small is over fasthttp's own minimum but under CompressionMinSize
+	small := strings.Repeat("x", 500)
+	large := strings.Repeat(`{"name": "ETC明細"},`, 200)
+	gw.app.Get("/small", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"value": small})
+	})
+	gw.app.Get("/large", func(c *fiber.Ctx) error {
+		c.Type("json")
+		return c.SendString(large)
+	})
+	gw.app.Get("/image", func(c *fiber.Ctx) error {
+		c.Type("png")
+		return c.SendString(large)
+	})
+
+	get := func(path, acceptEncoding string) *http.Response {
+		req := httptest.NewRequest("GET", path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		resp, err := gw.app.Test(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("small JSON is not compressed", func(t *testing.T) {
+		resp := get("/small", "gzip")
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"value": "`+small+`"}`, string(body))
+	})
+
+	t.Run("large JSON is gzipped", func(t *testing.T) {
+		resp := get("/large", "gzip")
+		require.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+		reader, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, large, string(body))
+	})
+
+	t.Run("brotli is preferred when accepted", func(t *testing.T) {
+		resp := get("/large", "gzip, br")
+		assert.Equal(t, "br", resp.Header.Get("Content-Encoding"))
+	})
+
+	t.Run("clients without Accept-Encoding get identity", func(t *testing.T) {
+		resp := get("/large", "")
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	})
+
+	t.Run("skipped content types are sent as-is", func(t *testing.T) {
+		resp := get("/image", "gzip")
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	})
+
+	t.Run("brotli can be disabled", func(t *testing.T) {
+		noBrotli := *perfConfig
+		noBrotli.EnableBrotli = false
+		gw := NewOptimizedGateway(newTestConfig("single"), &noBrotli)
+		t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+		gw.app.Get("/large", func(c *fiber.Ctx) error {
+			c.Type("json")
+			return c.SendString(large)
+		})
+
+		req := httptest.NewRequest("GET", "/large", nil)
+		req.Header.Set("Accept-Encoding", "br, gzip")
+		resp, err := gw.app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+	})
+}
+
+func TestProfilingRequiresAdminToken(t *testing.T) {
+	newProfilingGateway := func(mode string, force bool) *OptimizedGateway {
+		cfg := newTestConfig(mode)
+		cfg.Monitoring.AdminListener = true
+		cfg.Server.AdminToken = "secret"
+		perfConfig := DefaultPerformanceConfig()
+		perfConfig.EnableProfiling = true
+		perfConfig.ForceProfiling = force
+		gw := NewOptimizedGateway(cfg, perfConfig)
+		t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+		return gw
+	}
+	pprofStatus := func(app *fiber.App, token string) int {
+		req := httptest.NewRequest("GET", "/debug/pprof/", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	gw := newProfilingGateway("single", false)
+	admin := gw.GetAdminHandler()
+	assert.Equal(t, http.StatusUnauthorized, pprofStatus(admin, ""))
+	assert.Equal(t, http.StatusUnauthorized, pprofStatus(admin, "wrong"))
+	assert.Equal(t, http.StatusOK, pprofStatus(admin, "secret"))
+	// Never served on the public port
+	assert.Equal(t, http.StatusNotFound, pprofStatus(gw.GetHTTPHandler(), "secret"))
+
+	// Separate mode refuses profiling unless forced, with a warning
+	var logs bytes.Buffer
+	require.NoError(t, applogger.Initialize(applogger.Config{Level: "info", Format: "json", Output: &logs}))
+	refused := newProfilingGateway("separate", false)
+	assert.Equal(t, http.StatusNotFound, pprofStatus(refused.GetAdminHandler(), "secret"))
+	assert.Contains(t, logs.String(), `"level":"warn"`)
+	assert.Contains(t, logs.String(), "pprof is not enabled in separate mode unless forced")
+	forced := newProfilingGateway("separate", true)
+	assert.Equal(t, http.StatusUnauthorized, pprofStatus(forced.GetAdminHandler(), ""))
+	assert.Equal(t, http.StatusOK, pprofStatus(forced.GetAdminHandler(), "secret"))
+}
+
+func TestOptimizedGatewayInitializeAndShutdown(t *testing.T) {
+	gw := NewOptimizedGateway(newTestConfig("single"), DefaultPerformanceConfig())
+	require.NoError(t, gw.Initialize())
+	assert.True(t, gw.IsReady())
+
+	// The optimized app serves the same routes and readiness as the simple gateway
+	code, _ := doRequest(t, gw.GetHTTPHandler(), "GET", "/api/v1/users", "")
+	assert.Equal(t, fiber.StatusOK, code)
+	code, _ = doRequest(t, gw.GetHTTPHandler(), "GET", "/ready", "")
+	assert.Equal(t, fiber.StatusOK, code)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, gw.Shutdown(ctx))
+	assert.False(t, gw.IsReady())
+}
+
+func TestOptimizedGatewayStartReportsHealth(t *testing.T) {
+	// Reserve a free port for the HTTP server
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	cfg := newTestConfig("single")
+	cfg.Server.HTTPPort = port
+	gw := NewOptimizedGateway(cfg, DefaultPerformanceConfig())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = gw.Shutdown(ctx)
+	})
+	require.NoError(t, gw.Start(context.Background()))
+
+	select {
+	case <-gw.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("optimized gateway did not start listening")
+	}
+
+	// Initialize registered the health checkers on the embedded gateway
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/health", port))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "grpc_server")
+}
\ No newline at end of file
@@ -0,0 +1,146 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	rpccode "google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// legacyErrorFormatKey marks requests answered with the old {"error": "..."}
+// body instead of the error envelope
+const legacyErrorFormatKey = "legacyErrorFormat"
+
+// RESTError is the body of the {"error": {...}} envelope returned by REST
+// handlers. Code is the canonical gRPC code name (e.g. INVALID_ARGUMENT) and
+// Details carries the gRPC status details, such as google.rpc.BadRequest field
+// violations, each tagged with its "@type".
+type RESTError struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+// detailMarshalOptions renders status details with their proto field names
+var detailMarshalOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// newLegacyErrorFormatMiddleware makes REST errors use the pre-envelope format
+func newLegacyErrorFormatMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(legacyErrorFormatKey, true)
+		return c.Next()
+	}
+}
+
+// restError answers with httpStatus and message, using the gRPC code that
+// corresponds to httpStatus
+func restError(c *fiber.Ctx, httpStatus int, message string) error {
+	return writeRESTError(c, httpStatus, codeFromHTTPStatus(httpStatus), message, nil)
+}
+
+// writeRESTError answers with the error envelope, or the legacy body when
+// enabled for the request
+func writeRESTError(c *fiber.Ctx, httpStatus int, code codes.Code, message string, details []json.RawMessage) error {
+	if legacy, _ := c.Locals(legacyErrorFormatKey).(bool); legacy {
+		return c.Status(httpStatus).JSON(fiber.Map{
+			"error": message,
+			"code":  code.String(),
+		})
+	}
+
+	return c.Status(httpStatus).JSON(fiber.Map{
+		"error": RESTError{
+			Code:    rpccode.Code_name[int32(code)],
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// restErrorHandler is the Fiber error handler, rendering errors returned by
+// handlers and middleware (such as unmatched routes) in the envelope
+func restErrorHandler(c *fiber.Ctx, err error) error {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return restError(c, fiberErr.Code, fiberErr.Message)
+	}
+	return restError(c, fiber.StatusInternalServerError, "Internal server error")
+}
+
+// statusDetails renders the details attached to st as JSON objects
+func statusDetails(st *status.Status) []json.RawMessage {
+	var details []json.RawMessage
+	for _, detail := range st.Proto().GetDetails() {
+		data, err := detailMarshalOptions.Marshal(detail)
+		if err != nil {
+			continue
+		}
+		details = append(details, data)
+	}
+	return details
+}
+
+// httpStatusFromCode maps a gRPC code to the HTTP status REST handlers answer with
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.NotFound:
+		return 404
+	case codes.InvalidArgument:
+		return 400
+	case codes.AlreadyExists:
+		return 409
+	case codes.PermissionDenied:
+		return 403
+	case codes.Unauthenticated:
+		return 401
+	case codes.ResourceExhausted:
+		return 429
+	case codes.FailedPrecondition:
+		return 412
+	case codes.Unimplemented:
+		return 501
+	case codes.Unavailable:
+		return 503
+	case codes.DeadlineExceeded:
+		return 504
+	case codes.Canceled:
+		// Client Closed Request, as nginx and grpc-gateway answer
+		return 499
+	default:
+		return 500
+	}
+}
+
+// codeFromHTTPStatus maps an HTTP status to the closest gRPC code
+func codeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case 400, 413, 415:
+		return codes.InvalidArgument
+	case 401:
+		return codes.Unauthenticated
+	case 403:
+		return codes.PermissionDenied
+	case 404:
+		return codes.NotFound
+	case 405, 501:
+		return codes.Unimplemented
+	case 409:
+		return codes.AlreadyExists
+	case 412:
+		return codes.FailedPrecondition
+	case 429:
+		return codes.ResourceExhausted
+	case 499:
+		return codes.Canceled
+	case 503:
+		return codes.Unavailable
+	case 504:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
\ No newline at end of file
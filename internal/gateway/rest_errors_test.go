@@ -0,0 +1,132 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRESTValidationErrorEnvelope(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	code, body := doRequest(t, app, "POST", "/api/v1/users", `{"email": "not-an-email", "name": ""}`)
+	require.Equal(t, fiber.StatusBadRequest, code)
+
+	errorObj, ok := body["error"].(map[string]interface{})
+	require.True(t, ok, "error should be an object: %v", body)
+	assert.Equal(t, "INVALID_ARGUMENT", errorObj["code"])
+	assert.NotEmpty(t, errorObj["message"])
+
+	details, ok := errorObj["details"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, details, 1)
+	detail := details[0].(map[string]interface{})
+	assert.Equal(t, "type.googleapis.com/google.rpc.BadRequest", detail["@type"])
+
+	fields := map[string]bool{}
+	for _, v := range detail["field_violations"].([]interface{}) {
+		fields[v.(map[string]interface{})["field"].(string)] = true
+	}
+	assert.True(t, fields["email"])
+	assert.True(t, fields["name"])
+}
+
+func TestRESTErrorEnvelopeForHandlerErrors(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	code, body := doRequest(t, app, "GET", "/api/v1/etc/meisai/abc", "")
+	require.Equal(t, fiber.StatusBadRequest, code)
+	errorObj := body["error"].(map[string]interface{})
+	assert.Equal(t, "INVALID_ARGUMENT", errorObj["code"])
+	assert.Equal(t, "Invalid ID format", errorObj["message"])
+
+	code, body = doRequest(t, app, "GET", "/api/v1/users/no-such-user", "")
+	require.Equal(t, fiber.StatusNotFound, code)
+	errorObj = body["error"].(map[string]interface{})
+	assert.Equal(t, "NOT_FOUND", errorObj["code"])
+
+	code, body = doRequest(t, app, "GET", "/no-such-route", "")
+	require.Equal(t, fiber.StatusNotFound, code)
+	errorObj = body["error"].(map[string]interface{})
+	assert.Equal(t, "NOT_FOUND", errorObj["code"])
+}
+
+func TestRESTLegacyErrorFormat(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Server.LegacyErrorFormat = true
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	code, body := doRequest(t, gw.GetHTTPHandler(), "POST", "/api/v1/users", `{"email": "not-an-email", "name": ""}`)
+	require.Equal(t, fiber.StatusBadRequest, code)
+	assert.IsType(t, "", body["error"])
+	assert.Equal(t, "InvalidArgument", body["code"])
+}
+
+func TestMiddlewareErrorsUseEnvelope(t *testing.T) {
+	requestError := func(t *testing.T, app *fiber.App, method, path string) (int, string) {
+		resp, err := app.Test(httptest.NewRequest(method, path, nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		errorObj, ok := body["error"].(map[string]interface{})
+		require.True(t, ok, "error should be an object: %v", body)
+		return resp.StatusCode, errorObj["code"].(string)
+	}
+
+	t.Run("read-only", func(t *testing.T) {
+		cfg := newTestConfig("single")
+		cfg.Server.ReadOnly = true
+		gw := NewSimpleGateway(cfg)
+		t.Cleanup(func() { _ = gw.Stop() })
+		require.NoError(t, gw.Initialize())
+
+		code, errorCode := requestError(t, gw.GetHTTPHandler(), "POST", "/api/v1/users")
+		assert.Equal(t, fiber.StatusForbidden, code)
+		assert.Equal(t, "PERMISSION_DENIED", errorCode)
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		perfConfig := DefaultPerformanceConfig()
+		perfConfig.EnableCompression = false
+		perfConfig.EnableCaching = false
+		perfConfig.RateLimit = 1
+		gw := NewOptimizedGateway(newTestConfig("single"), perfConfig)
+		t.Cleanup(gw.connectionPool.cleanupTicker.Stop)
+		gw.app.Get("/limited", func(c *fiber.Ctx) error {
+			return fiber.ErrNotFound
+		})
+
+		code, errorCode := requestError(t, gw.app, "GET", "/limited")
+		assert.Equal(t, fiber.StatusNotFound, code)
+		assert.Equal(t, "NOT_FOUND", errorCode)
+
+		code, errorCode = requestError(t, gw.app, "GET", "/limited")
+		assert.Equal(t, fiber.StatusTooManyRequests, code)
+		assert.Equal(t, "RESOURCE_EXHAUSTED", errorCode)
+	})
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	tests := map[codes.Code]int{
+		codes.InvalidArgument:  400,
+		codes.NotFound:         404,
+		codes.Unavailable:      503,
+		codes.DeadlineExceeded: 504,
+		codes.Canceled:         499,
+		codes.Internal:         500,
+	}
+	for code, want := range tests {
+		assert.Equal(t, want, httpStatusFromCode(code), code.String())
+		if code != codes.Internal {
+			assert.Equal(t, code, codeFromHTTPStatus(want), "round trip of %s", code)
+		}
+	}
+}
\ No newline at end of file
@@ -3,16 +3,24 @@ package gateway
 import (
 	"context"
 	"fmt"
+	"net"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/yhonda-ohishi/db-handler-server/internal/audit"
 	"github.com/yhonda-ohishi/db-handler-server/internal/client"
 	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+	"github.com/yhonda-ohishi/db-handler-server/internal/diagnostics"
 	"github.com/yhonda-ohishi/db-handler-server/internal/health"
+	applogger "github.com/yhonda-ohishi/db-handler-server/internal/logger"
+	"github.com/yhonda-ohishi/db-handler-server/internal/metrics"
+	"github.com/yhonda-ohishi/db-handler-server/internal/readonly"
 	"github.com/yhonda-ohishi/db-handler-server/internal/services"
+	"github.com/yhonda-ohishi/db-handler-server/internal/tlsconfig"
+	"github.com/yhonda-ohishi/db-handler-server/internal/validation"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -21,102 +29,543 @@ import (
 type SimpleGateway struct {
 	config         *config.Config
 	app            *fiber.App
+	// adminApp serves the operational endpoints on their own port when
+	// monitoring.admin_listener is set
+	adminApp       *fiber.App
 	grpcServer     *grpc.Server
+	networkServer  *grpc.Server
+	grpcListener   net.Listener
 	bufconnClient  *client.BufconnClient
 	healthService  *health.Service
 	serviceRegistry *services.ServiceRegistry
+	dbClient       *client.NetworkClient
+	dbConn         *grpc.ClientConn
+	// handlersClient connects to the user, transaction, card and payment
+	// handlers in separate mode when external.handlers_grpc_url is set
+	handlersClient *client.NetworkClient
+	handlersConn   *grpc.ClientConn
+	readOnly       *readonly.Mode
+	metrics        *metrics.Service
+	// dependencyMu orders dependency_up updates against shutdown, which
+	// stops the background checks and marks every dependency down
+	dependencyMu     sync.Mutex
+	dependenciesDown bool
+	stopHealthChecks context.CancelFunc
+	swaggerOnce    sync.Once
+	swaggerJSON    []byte
+	swaggerErr     error
+	ready          atomic.Bool
+	// drain refuses new requests once Shutdown begins
+	drain          *drainer
+	// listening is closed once the HTTP listeners are bound
+	listening      chan struct{}
+	httpListeners  []net.Listener
 	wg             sync.WaitGroup
 }
 
+// DefaultDurationBuckets are the request duration histogram buckets (in
+// seconds) used unless monitoring.duration_buckets is set. Gateway and
+// db_service calls mostly take 1ms-500ms, so they are finest around 1-100ms
+// where the metrics package defaults are coarse.
+var DefaultDurationBuckets = []float64{
+	0.001, 0.0025, 0.005, 0.0075, 0.01, 0.015, 0.02, 0.03, 0.05, 0.075, 0.1,
+	0.25, 0.5, 1, 2.5, 5, 10,
+}
+
 // NewSimpleGateway creates a new simple gateway
 func NewSimpleGateway(cfg *config.Config) *SimpleGateway {
 	app := fiber.New(fiber.Config{
-		AppName: "ETC Meisai Gateway",
+		AppName:      "ETC Meisai Gateway",
+		BodyLimit:    bodyLimitsFromConfig(cfg.Server).Max(),
+		ErrorHandler: restErrorHandler,
 	})
+	return newSimpleGatewayWithApp(cfg, app)
+}
+
+// newSimpleGatewayWithApp builds the gateway on app, mounting the common
+// middleware, health, drain and read-only state; the optimized gateway uses it
+// with its own tuned app
+func newSimpleGatewayWithApp(cfg *config.Config, app *fiber.App) *SimpleGateway {
+	bodyLimits := bodyLimitsFromConfig(cfg.Server)
+
+	var metricsService *metrics.Service
+	if cfg.Monitoring.MetricsEnabled {
+		metricsConfig := metrics.DefaultConfig()
+		metricsConfig.ConstLabels = map[string]string{metrics.DeploymentModeLabel: cfg.Deployment.Mode}
+		metricsConfig.DurationBuckets = DefaultDurationBuckets
+		if len(cfg.Monitoring.DurationBuckets) > 0 {
+			metricsConfig.DurationBuckets = cfg.Monitoring.DurationBuckets
+		}
+		metricsConfig.GRPCDurationBuckets = cfg.Monitoring.GRPCDurationBuckets
+		metricsService = metrics.NewService(metricsConfig)
+	}
 
 	// Add middleware
+	drain := &drainer{}
 	app.Use(recover.New())
+	if cfg.Server.LegacyErrorFormat {
+		app.Use(newLegacyErrorFormatMiddleware())
+	}
 	app.Use(logger.New())
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Content-Type,Authorization",
-	}))
+	app.Use(applogger.FiberRequestLogger())
+	app.Use(applogger.UserContextMiddleware())
+	if metricsService != nil {
+		// Mounted ahead of the limits so rejected requests are counted too
+		middlewareConfig := metrics.DefaultMiddlewareConfig(metricsService)
+		middlewareConfig.DecompressRequests = cfg.Monitoring.DecompressRequests
+		middlewareConfig.MaxDecompressedBytes = bodyLimits.LimitFor
+		app.Use(metrics.MiddlewareWithConfig(middlewareConfig))
+	}
+	app.Use(drain.middleware())
+	app.Use(newBodyLimitMiddleware(bodyLimits))
+	app.Use(newJSONDepthMiddleware(maxJSONDepthFromConfig(cfg.Server)))
+	app.Use(newTimeoutMiddleware(requestTimeoutsFromConfig(cfg.Server)))
+	if corsHandler := newCORSMiddleware(cfg.CORS); corsHandler != nil {
+		app.Use(corsHandler)
+	}
+	if deprecationHandler := newDeprecationMiddleware(cfg.Server.Deprecations); deprecationHandler != nil {
+		app.Use(deprecationHandler)
+	}
+	app.Use(newEmptyPathParamMiddleware(app))
+
+	g := &SimpleGateway{
+		config:        cfg,
+		app:           app,
+		healthService: health.NewService(),
+		readOnly:      readonly.New(cfg.Server.ReadOnly, services.WriteMethods),
+		drain:         drain,
+		listening:     make(chan struct{}),
+	}
+	if cfg.Monitoring.AdminListener {
+		g.adminApp = newAdminApp()
+	}
+	if metricsService != nil {
+		g.metrics = metricsService
+		drain.metrics = g.metrics
+		g.healthService.SetObserver(g.recordDependencyHealth)
+	}
+
+	// Block REST writes while read-only
+	app.Use("/api", g.readOnly.FiberMiddleware())
+
+	// Readiness is registered up front so it reports 503 until initialization completes
+	app.Get("/ready", g.readyHandler)
+
+	return g
+}
+
+// SetBufconnClient makes the gateway use an existing bufconn client whose
+// gRPC server is served by the caller
+func (g *SimpleGateway) SetBufconnClient(bufconnClient *client.BufconnClient) {
+	g.bufconnClient = bufconnClient
+}
+
+// GetHTTPHandler returns the Fiber app serving the gateway routes
+func (g *SimpleGateway) GetHTTPHandler() *fiber.App {
+	return g.app
+}
+
+// GetAdminHandler returns the Fiber app serving the admin port, or nil when
+// the operational endpoints share the main port
+func (g *SimpleGateway) GetAdminHandler() *fiber.App {
+	return g.adminApp
+}
+
+// newAdminApp creates the Fiber app for the admin listener
+func newAdminApp() *fiber.App {
+	app := fiber.New(fiber.Config{
+		AppName:      "ETC Meisai Gateway Admin",
+		ErrorHandler: restErrorHandler,
+	})
+	app.Use(recover.New())
+	return app
+}
 
-	return &SimpleGateway{
-		config: cfg,
-		app:    app,
+// operationalRouter returns the app that serves /metrics, /debug/monitor
+// and pprof
+func (g *SimpleGateway) operationalRouter() fiber.Router {
+	if g.adminApp != nil {
+		return g.adminApp
 	}
+	return g.app
+}
+
+// IsReady reports whether initialization has completed
+func (g *SimpleGateway) IsReady() bool {
+	return g.ready.Load()
+}
+
+// Ready returns a channel that is closed once Start has bound the HTTP
+// listeners and the gateway accepts connections
+func (g *SimpleGateway) Ready() <-chan struct{} {
+	return g.listening
+}
+
+// Initialize wires the gRPC connection and routes without starting the HTTP server
+func (g *SimpleGateway) Initialize() error {
+	return g.initialize(context.Background())
 }
 
 // Start starts the gateway in the configured mode
 func (g *SimpleGateway) Start(ctx context.Context) error {
-	if g.config.IsSingleMode() {
-		return g.startSingleMode(ctx)
+	if err := g.initialize(ctx); err != nil {
+		return err
 	}
-	return g.startSeparateMode(ctx)
+	return g.startHTTPServer()
 }
 
-// startSingleMode starts gateway with bufconn
-func (g *SimpleGateway) startSingleMode(ctx context.Context) error {
-	// Create bufconn client
-	g.bufconnClient = client.NewBufconnClient()
+// initialize sets up the configured mode and marks the gateway ready on success
+func (g *SimpleGateway) initialize(ctx context.Context) error {
+	var err error
+	if g.config.IsSingleMode() {
+		err = g.initSingleMode(ctx)
+	} else {
+		err = g.initSeparateMode(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	g.ready.Store(true)
+	g.startHealthChecks()
+	return nil
+}
 
-	// Create gRPC server but don't start it yet
-	g.grpcServer = grpc.NewServer()
+// initSingleMode sets up the gateway with bufconn
+func (g *SimpleGateway) initSingleMode(ctx context.Context) error {
+	phonePattern, err := validation.PhonePattern(g.config.Server.PhoneFormat)
+	if err != nil {
+		return err
+	}
+	statementLocation, err := g.config.Server.StatementLocation()
+	if err != nil {
+		return fmt.Errorf("invalid statement time zone %q: %w", g.config.Server.StatementTimeZone, err)
+	}
 
-	// Register services first - use single mode registry with mock DB services
-	g.serviceRegistry = services.NewServiceRegistryForSingleMode()
-	g.serviceRegistry.RegisterAll(g.grpcServer)
+	// Serve our own services unless the caller supplied a bufconn client
+	if g.bufconnClient == nil {
+		// Create bufconn client
+		g.bufconnClient = client.NewBufconnClient()
 
-	// Enable reflection
-	reflection.Register(g.grpcServer)
+		// Create gRPC server but don't start it yet
+		g.grpcServer = grpc.NewServer(g.grpcServerOptions()...)
 
-	// Now start the server with the listener
-	listener := g.bufconnClient.GetListener()
-	g.wg.Add(1)
-	go func() {
-		defer g.wg.Done()
-		fmt.Println("Starting gRPC server on bufconn")
-		if err := g.grpcServer.Serve(listener); err != nil {
-			fmt.Printf("gRPC server error: %v\n", err)
+		// Register services first - use single mode registry with mock DB services
+		g.serviceRegistry, err = services.NewServiceRegistryForSingleModeWithPool(g.downloadDBPool())
+		if err != nil {
+			return err
 		}
-	}()
+		g.serviceRegistry.EnabledServices = g.config.Server.EnabledServices
+		services.WithPhoneValidation(phonePattern)(g.serviceRegistry)
+		services.WithMaxPageSize(g.config.Server.MaxPageSize, g.config.Server.MaxPageSizes)(g.serviceRegistry)
+		if g.config.Server.MaxFieldMaskPaths != 0 {
+			services.WithMaxFieldMaskPaths(g.config.Server.MaxFieldMaskPaths)(g.serviceRegistry)
+		}
+		services.WithMaxBatchSize(g.config.Server.MaxBatchSize)(g.serviceRegistry)
+		services.WithAuditMaxPageSize(g.config.Server.AuditMaxPageSize)(g.serviceRegistry)
+		services.WithStatementLocation(statementLocation)(g.serviceRegistry)
+		services.WithCardNumberExposure(g.config.Server.ExposeCardNumbers)(g.serviceRegistry)
+		g.serviceRegistry.RegisterAll(g.grpcServer)
+		g.serviceRegistry.CardService.StartExpirySweeper(services.DefaultCardExpirySweepInterval)
+
+		// Enable reflection
+		reflection.Register(g.grpcServer)
+
+		// Now start the server with the listener
+		listener := g.bufconnClient.GetListener()
+		g.wg.Add(1)
+		go func() {
+			defer g.wg.Done()
+			fmt.Println("Starting gRPC server on bufconn")
+			if err := g.grpcServer.Serve(listener); err != nil {
+				fmt.Printf("gRPC server error: %v\n", err)
+			}
+		}()
+
+		if g.config.Server.GRPCListen {
+			if err := g.startNetworkGRPCServer(); err != nil {
+				return err
+			}
+		}
+	}
 
 	// Get a connection to the bufconn server for REST proxy
-	conn, err := g.bufconnClient.GetConnection(ctx)
+	conn, err := g.bufconnClient.GetConnection(ctx,
+		grpc.WithChainUnaryInterceptor(applogger.UnaryClientMetadataInterceptor()))
 	if err != nil {
 		return fmt.Errorf("failed to get bufconn connection: %w", err)
 	}
 
+	// Report the in-process gRPC server and registered services in /health
+	g.healthService.RegisterChecker("grpc_server", health.NewGRPCConnChecker("grpc_server", conn))
+	if g.serviceRegistry != nil {
+		g.healthService.RegisterChecker("services", health.NewCheckerFunc("services", g.checkServices))
+	}
+
 	// Setup basic REST endpoints
 	g.setupBasicEndpoints()
 
 	// Setup db_service REST routes
 	dbRoutes := NewDBServiceRoutes(conn)
+	dbRoutes.SetRetryPolicy(retryPolicyFromConfig(g.config.External.DBServiceRetry))
 	dbRoutes.RegisterRoutes(g.app)
 
+	// Setup user, transaction and payment REST routes
+	apiRoutes := NewAPIRoutes(conn)
+	apiRoutes.SetMultiStatus(!g.config.Server.DisableMultiStatus)
+	apiRoutes.SetPhonePattern(phonePattern)
+	apiRoutes.SetAdminToken(g.config.Server.AdminToken)
+	apiRoutes.RegisterRoutes(g.app)
+
 	// Setup download service REST routes
 	downloadRoutes := NewDownloadServiceRoutes(conn)
 	downloadRoutes.RegisterRoutes(g.app)
 
+	// Setup JSON-RPC endpoint
+	jsonrpcRoutes := NewJSONRPCRoutes(conn)
+	jsonrpcRoutes.SetPhonePattern(phonePattern)
+	if g.metrics != nil {
+		jsonrpcRoutes.SetMetrics(g.metrics)
+	}
+	jsonrpcRoutes.RegisterRoutes(g.app)
+
+	// Setup gRPC-Web endpoint backed by the in-process gRPC server
+	if g.grpcServer != nil {
+		grpcWebRoutes := NewGRPCWebRoutes(g.grpcServer)
+		grpcWebRoutes.RegisterRoutes(g.app)
+	}
+
+	// Setup mixed-protocol self-diagnostic endpoint
+	diagnosticsRoutes := NewDiagnosticsRoutes(conn, g.app, diagnostics.Config{
+		MaxConcurrency: g.config.Diagnostics.MaxConcurrency,
+		Timeout:        g.config.Diagnostics.Timeout,
+	})
+	diagnosticsRoutes.RegisterRoutes(g.app)
+
+	// Setup live transaction feed
+	var transactionService *services.TransactionService
+	if g.serviceRegistry != nil {
+		transactionService = g.serviceRegistry.TransactionService
+	}
+	feedRoutes := NewTransactionFeedRoutes(transactionService)
+	feedRoutes.RegisterRoutes(g.app)
+
 	// Setup Swagger UI
 	g.SetupSwaggerUI()
 
-	// Start HTTP server
-	return g.startHTTPServer()
+	return nil
+}
+
+// downloadDBPool returns the connection pool settings for the download
+// service database, taken from the database config
+func (g *SimpleGateway) downloadDBPool() services.DBPoolConfig {
+	pool := services.DefaultDBPoolConfig()
+	pool.MaxOpenConns = g.config.Database.MaxConnections
+	pool.MaxIdleConns = g.config.Database.IdleConnections
+	pool.ConnMaxLifetime = g.config.Database.ConnMaxLifetime
+	return pool
+}
+
+// grpcServerOptions returns the interceptors shared by the gRPC servers
+func (g *SimpleGateway) grpcServerOptions() []grpc.ServerOption {
+	var interceptors []grpc.UnaryServerInterceptor
+	if g.metrics != nil {
+		interceptors = append(interceptors, g.metrics.UnaryServerInterceptor())
+	}
+	interceptors = append(interceptors,
+		applogger.UnaryServerMetadataInterceptor(),
+		applogger.UnaryServerLoggingInterceptor(),
+		g.readOnly.UnaryServerInterceptor(),
+	)
+	return []grpc.ServerOption{grpc.ChainUnaryInterceptor(interceptors...)}
+}
+
+// startNetworkGRPCServer serves the registered services on the configured
+// gRPC port, with TLS when enabled
+func (g *SimpleGateway) startNetworkGRPCServer() error {
+	opts := g.grpcServerOptions()
+	if g.config.Server.TLS.Enabled {
+		creds, err := tlsconfig.ServerCredentials(g.config.Server.TLS)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", g.config.Server.GRPCPort))
+	if err != nil {
+		return fmt.Errorf("failed to listen on gRPC port: %w", err)
+	}
+	g.grpcListener = listener
+
+	g.networkServer = grpc.NewServer(opts...)
+	g.serviceRegistry.RegisterAll(g.networkServer)
+	reflection.Register(g.networkServer)
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		fmt.Printf("Starting gRPC server on %s (TLS: %t)\n", listener.Addr(), g.config.Server.TLS.Enabled)
+		if err := g.networkServer.Serve(listener); err != nil {
+			fmt.Printf("gRPC server error: %v\n", err)
+		}
+	}()
+
+	return nil
 }
 
-// startSeparateMode starts gateway with network connections
-func (g *SimpleGateway) startSeparateMode(ctx context.Context) error {
+// GRPCAddress returns the address of the network gRPC listener, or "" when
+// the services are only served over bufconn
+func (g *SimpleGateway) GRPCAddress() string {
+	if g.grpcListener == nil {
+		return ""
+	}
+	return g.grpcListener.Addr().String()
+}
+
+// initSeparateMode sets up the gateway with network connections. db_service
+// and ETC明細 calls go to the database backend and user, transaction, card
+// and payment calls to the handlers backend; when only one of them is
+// configured it serves both.
+func (g *SimpleGateway) initSeparateMode(ctx context.Context) error {
+	phonePattern, err := validation.PhonePattern(g.config.Server.PhoneFormat)
+	if err != nil {
+		return err
+	}
+
+	dbAddress := g.config.External.DBServiceURL
+	if dbAddress == "" {
+		dbAddress = g.config.External.DatabaseGRPCURL
+	}
+	handlersAddress := g.config.External.HandlersGRPCURL
+	if dbAddress == "" && handlersAddress == "" {
+		return fmt.Errorf("separate mode requires external.db_service_url, external.database_grpc_url or external.handlers_grpc_url")
+	}
+
+	// Connect to each downstream backend so /health reflects its availability
+	if dbAddress != "" {
+		networkConfig, err := backendNetworkConfig("db_service", dbAddress, g.config.External.DBServiceTLS)
+		if err != nil {
+			return err
+		}
+		networkClient, conn, err := g.connectBackend(ctx, "db_service", networkConfig)
+		if err != nil {
+			return err
+		}
+		g.dbClient, g.dbConn = networkClient, conn
+	}
+	if handlersAddress != "" {
+		networkConfig, err := backendNetworkConfig("handlers", handlersAddress, g.config.External.HandlersTLS)
+		if err != nil {
+			return err
+		}
+		networkClient, conn, err := g.connectBackend(ctx, "handlers_service", networkConfig)
+		if err != nil {
+			return err
+		}
+		g.handlersClient, g.handlersConn = networkClient, conn
+	}
+
+	dbConn, handlersConn := g.dbConn, g.handlersConn
+	if dbConn == nil {
+		dbConn = handlersConn
+	}
+	if handlersConn == nil {
+		handlersConn = dbConn
+	}
+
 	// Setup basic endpoints
 	g.setupBasicEndpoints()
 
+	// Setup db_service REST routes
+	dbRoutes := NewDBServiceRoutes(dbConn)
+	dbRoutes.SetRetryPolicy(retryPolicyFromConfig(g.config.External.DBServiceRetry))
+	dbRoutes.RegisterRoutes(g.app)
+
+	// Setup user, transaction and payment REST routes, with ETC明細 on db_service
+	apiRoutes := NewAPIRoutes(handlersConn)
+	apiRoutes.SetETCConnection(dbConn)
+	apiRoutes.SetMultiStatus(!g.config.Server.DisableMultiStatus)
+	apiRoutes.SetPhonePattern(phonePattern)
+	apiRoutes.SetAdminToken(g.config.Server.AdminToken)
+	apiRoutes.RegisterRoutes(g.app)
+
+	// Setup JSON-RPC endpoint
+	jsonrpcRoutes := NewJSONRPCRoutes(handlersConn)
+	jsonrpcRoutes.SetPhonePattern(phonePattern)
+	if g.metrics != nil {
+		jsonrpcRoutes.SetMetrics(g.metrics)
+	}
+	jsonrpcRoutes.RegisterRoutes(g.app)
+
 	// Setup Swagger UI
 	g.SetupSwaggerUI()
 
-	// Start HTTP server
-	return g.startHTTPServer()
+	return nil
+}
+
+// backendNetworkConfig returns the client config for a downstream backend,
+// using TLS credentials when tlsCfg is enabled
+func backendNetworkConfig(name, address string, tlsCfg config.ClientTLSConfig) (*client.NetworkClientConfig, error) {
+	networkConfig := client.DefaultNetworkConfig(address)
+	if tlsCfg.Enabled {
+		creds, err := tlsconfig.ClientCredentials(tlsCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure %s TLS: %w", name, err)
+		}
+		networkConfig.TransportCredentials = creds
+	}
+	return networkConfig, nil
+}
+
+// connectBackend dials a downstream backend and reports it in /health under name
+func (g *SimpleGateway) connectBackend(ctx context.Context, name string, networkConfig *client.NetworkClientConfig) (*client.NetworkClient, *grpc.ClientConn, error) {
+	networkClient := client.NewNetworkClient(networkConfig)
+	if err := networkClient.Connect(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s: %w", name, err)
+	}
+
+	conn, err := networkClient.GetConnection(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get %s connection: %w", name, err)
+	}
+	g.healthService.RegisterChecker(name,
+		health.NewGRPCConnChecker(name, conn).WithProbe(health.HealthCheckProbe("")))
+	return networkClient, conn, nil
+}
+
+// readyHandler returns 503 until initialization completes and, in separate
+// mode, while a downstream backend is unreachable
+func (g *SimpleGateway) readyHandler(c *fiber.Ctx) error {
+	if !g.ready.Load() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status": "not_ready",
+			"reason": "gateway initialization not complete",
+		})
+	}
+
+	if g.config.IsSeparateMode() {
+		backends := []struct {
+			name string
+			conn *grpc.ClientConn
+		}{
+			{"db_service", g.dbConn},
+			{"handlers_service", g.handlersConn},
+		}
+		for _, backend := range backends {
+			if backend.conn == nil {
+				continue
+			}
+			if err := health.NewGRPCConnChecker(backend.name, backend.conn).Check(c.Context()); err != nil {
+				return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+					"status": "not_ready",
+					"reason": backend.name + " unavailable: " + err.Error(),
+				})
+			}
+		}
+	}
+
+	return c.JSON(fiber.Map{"status": "ready"})
 }
 
 // setupBasicEndpoints sets up basic API endpoints
@@ -131,13 +580,29 @@ func (g *SimpleGateway) setupBasicEndpoints() {
 	})
 
 	// Health endpoints
+	g.app.Get("/health", g.healthService.HealthHandler)
+
+	// Prometheus metrics, when monitoring is enabled
+	if g.metrics != nil {
+		g.operationalRouter().Get("/metrics", g.metrics.Handler())
+	}
+
 	g.app.Get("/health/live", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"status": "alive"})
 	})
 
-	g.app.Get("/health/ready", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{"status": "ready"})
-	})
+	g.app.Get("/health/ready", g.healthService.ReadinessHandler)
+
+	// Admin endpoints
+	adminRoutes := NewAdminRoutes(g.readOnly)
+	adminRoutes.SetServiceRegistry(g.serviceRegistry)
+	var auditLog *audit.Log
+	if g.serviceRegistry != nil {
+		auditLog = g.serviceRegistry.AuditLog
+	}
+	adminRoutes.SetAuditLog(auditLog)
+	adminRoutes.SetToken(g.config.Server.AdminToken)
+	adminRoutes.RegisterRoutes(g.app)
 
 	// Info endpoint
 	g.app.Get("/info", func(c *fiber.Ctx) error {
@@ -151,52 +616,6 @@ func (g *SimpleGateway) setupBasicEndpoints() {
 	// Basic API endpoints for testing
 	api := g.app.Group("/api/v1")
 
-	// Users endpoint
-	api.Get("/users", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"users": []fiber.Map{
-				{"id": "1", "name": "Test User 1", "email": "user1@example.com"},
-				{"id": "2", "name": "Test User 2", "email": "user2@example.com"},
-			},
-		})
-	})
-
-	// Transactions endpoint
-	api.Get("/transactions", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"transactions": []fiber.Map{
-				{"id": "1", "amount": 1000, "card_id": "card1"},
-				{"id": "2", "amount": 1500, "card_id": "card2"},
-			},
-		})
-	})
-
-	// ETC明細 endpoints
-	api.Get("/etc/meisai", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"etc_meisai": []fiber.Map{
-				{
-					"id": "1",
-					"date": "2024-01-15",
-					"entrance_ic": "首都高速道路 入口",
-					"exit_ic": "名神高速道路 出口",
-					"toll_amount": 8500,
-					"final_amount": 8000,
-					"car_number": "品川 500 あ 1234",
-				},
-				{
-					"id": "2",
-					"date": "2024-01-20",
-					"entrance_ic": "第三京浜道路 入口",
-					"exit_ic": "東名高速道路 出口",
-					"toll_amount": 6200,
-					"final_amount": 5900,
-					"car_number": "横浜 301 さ 5678",
-				},
-			},
-		})
-	})
-
 	api.Get("/etc/summary", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
 			"summary": fiber.Map{
@@ -209,50 +628,214 @@ func (g *SimpleGateway) setupBasicEndpoints() {
 	})
 }
 
-// startHTTPServer starts the HTTP server
+// startHealthChecks runs the dependency checks in the background so
+// dependency_up stays current without /health being polled
+func (g *SimpleGateway) startHealthChecks() {
+	interval := g.config.Monitoring.HealthCheckInterval
+	if g.metrics == nil || interval <= 0 {
+		return
+	}
+
+	g.dependencyMu.Lock()
+	defer g.dependencyMu.Unlock()
+	if g.dependenciesDown || g.stopHealthChecks != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	g.stopHealthChecks = cancel
+	g.healthService.StartBackgroundChecks(ctx, interval)
+}
+
+// recordDependencyHealth sets dependency_up from the health checks' view of
+// each dependency and internal service
+func (g *SimpleGateway) recordDependencyHealth(components []health.ComponentHealth) {
+	g.dependencyMu.Lock()
+	defer g.dependencyMu.Unlock()
+	if g.dependenciesDown {
+		return
+	}
+
+	for _, component := range components {
+		g.metrics.SetDependencyUp(component.Name, component.Status == health.StatusHealthy)
+	}
+	if g.serviceRegistry != nil {
+		for name, healthy := range g.serviceRegistry.IsHealthy() {
+			g.metrics.SetDependencyUp(name, healthy)
+		}
+	}
+}
+
+// markDependenciesDown stops the background checks and reports every
+// dependency down for the rest of the shutdown
+func (g *SimpleGateway) markDependenciesDown() {
+	g.dependencyMu.Lock()
+	defer g.dependencyMu.Unlock()
+
+	g.dependenciesDown = true
+	if g.stopHealthChecks != nil {
+		g.stopHealthChecks()
+	}
+	if g.metrics != nil {
+		g.metrics.MarkDependenciesDown()
+	}
+}
+
+// checkServices reports an error when any registered service is missing
+func (g *SimpleGateway) checkServices(ctx context.Context) error {
+	if g.serviceRegistry == nil {
+		return fmt.Errorf("service registry not initialized")
+	}
+
+	for name, healthy := range g.serviceRegistry.IsHealthy() {
+		if !healthy {
+			return fmt.Errorf("%s is not available", name)
+		}
+	}
+	return nil
+}
+
+// startHTTPServer binds the HTTP listeners, serves them in the background
+// and closes the Ready channel; a port that cannot be bound is returned as an
+// error instead of being reported after startup
 func (g *SimpleGateway) startHTTPServer() error {
 	address := fmt.Sprintf(":%d", g.config.Server.HTTPPort)
 
 	fmt.Printf("Starting HTTP server on %s\n", address)
-	// Start in goroutine and use a channel to signal when it's ready
-	errCh := make(chan error, 1)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	var adminListener net.Listener
+	if g.adminApp != nil {
+		adminAddress := fmt.Sprintf(":%d", g.config.Monitoring.MetricsPort)
+		fmt.Printf("Starting admin HTTP server on %s\n", adminAddress)
+		adminListener, err = net.Listen("tcp", adminAddress)
+		if err != nil {
+			listener.Close()
+			return fmt.Errorf("failed to listen on %s: %w", adminAddress, err)
+		}
+		g.httpListeners = append(g.httpListeners, adminListener)
+	}
+	g.httpListeners = append(g.httpListeners, listener)
 
 	g.wg.Add(1)
 	go func() {
 		defer g.wg.Done()
-		if err := g.app.Listen(address); err != nil {
-			errCh <- fmt.Errorf("HTTP server error: %w", err)
+		if err := g.app.Listener(listener); err != nil {
+			fmt.Printf("Server start error: HTTP server error: %v\n", err)
 		}
 	}()
 
-	// Give the server a moment to start
-	go func() {
-		select {
-		case err := <-errCh:
-			fmt.Printf("Server start error: %v\n", err)
-		}
-	}()
+	if adminListener != nil {
+		g.wg.Add(1)
+		go func() {
+			defer g.wg.Done()
+			if err := g.adminApp.Listener(adminListener); err != nil {
+				fmt.Printf("Server start error: admin HTTP server error: %v\n", err)
+			}
+		}()
+	}
 
+	close(g.listening)
 	return nil
 }
 
-// Stop stops the gateway
-func (g *SimpleGateway) Stop() error {
+// Shutdown gracefully stops the HTTP server and gRPC server and closes client
+// connections, forcing the gRPC server to stop if ctx expires first
+func (g *SimpleGateway) Shutdown(ctx context.Context) error {
 	fmt.Println("Stopping gateway...")
+	g.drain.start()
+	g.ready.Store(false)
+	if g.serviceRegistry != nil {
+		g.serviceRegistry.MarkNotServing()
+	}
+	g.markDependenciesDown()
 
+	var shutdownErr error
 	if g.app != nil {
-		_ = g.app.Shutdown()
+		if err := g.app.ShutdownWithContext(ctx); err != nil {
+			shutdownErr = fmt.Errorf("failed to shut down HTTP server: %w", err)
+		}
+	}
+	if g.adminApp != nil {
+		if err := g.adminApp.ShutdownWithContext(ctx); err != nil && shutdownErr == nil {
+			shutdownErr = fmt.Errorf("failed to shut down admin HTTP server: %w", err)
+		}
+	}
+	// A server shut down before it began serving would otherwise still
+	// serve its listener
+	for _, listener := range g.httpListeners {
+		_ = listener.Close()
 	}
 
-	if g.grpcServer != nil {
-		g.grpcServer.GracefulStop()
+	for _, server := range []*grpc.Server{g.networkServer, g.grpcServer} {
+		if server != nil {
+			stopGRPCServer(ctx, server)
+		}
+	}
+
+	if g.serviceRegistry != nil {
+		if err := g.serviceRegistry.Shutdown(ctx); err != nil && shutdownErr == nil {
+			shutdownErr = err
+		}
 	}
 
 	if g.bufconnClient != nil {
 		_ = g.bufconnClient.Close()
 	}
 
-	g.wg.Wait()
+	if g.dbClient != nil {
+		if err := g.dbClient.Close(); err != nil && shutdownErr == nil {
+			shutdownErr = fmt.Errorf("failed to close db_service connection: %w", err)
+		}
+	}
+	if g.handlersClient != nil {
+		if err := g.handlersClient.Close(); err != nil && shutdownErr == nil {
+			shutdownErr = fmt.Errorf("failed to close handlers_service connection: %w", err)
+		}
+	}
+
+	// Wait for the server goroutines to exit
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		if shutdownErr == nil {
+			shutdownErr = fmt.Errorf("timed out waiting for servers to stop: %w", ctx.Err())
+		}
+	}
+
+	if shutdownErr != nil {
+		return shutdownErr
+	}
+
 	fmt.Println("Gateway stopped")
 	return nil
+}
+
+// stopGRPCServer stops server gracefully, forcing it to stop if ctx expires first
+func stopGRPCServer(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+		<-stopped
+	}
+}
+
+// Stop stops the gateway without a deadline
+func (g *SimpleGateway) Stop() error {
+	return g.Shutdown(context.Background())
 }
\ No newline at end of file
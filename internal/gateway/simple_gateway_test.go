@@ -0,0 +1,439 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+	"github.com/yhonda-ohishi/db-handler-server/internal/health"
+	"github.com/yhonda-ohishi/db-handler-server/internal/logger"
+	"google.golang.org/grpc"
+)
+
+func newTestConfig(mode string) *config.Config {
+	return &config.Config{
+		Deployment: config.DeploymentConfig{Mode: mode},
+		Server:     config.ServerConfig{HTTPPort: 8080, GRPCPort: 9090},
+	}
+}
+
+func getReadyStatus(t *testing.T, gw *SimpleGateway) int {
+	t.Helper()
+
+	resp, err := gw.GetHTTPHandler().Test(httptest.NewRequest("GET", "/ready", nil), 5000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestReadyGatedOnInitializeSingleMode(t *testing.T) {
+	gw := NewSimpleGateway(newTestConfig("single"))
+	t.Cleanup(func() { _ = gw.Stop() })
+
+	assert.False(t, gw.IsReady())
+	assert.Equal(t, fiber.StatusServiceUnavailable, getReadyStatus(t, gw))
+
+	require.NoError(t, gw.Initialize())
+
+	assert.True(t, gw.IsReady())
+	assert.Equal(t, fiber.StatusOK, getReadyStatus(t, gw))
+}
+
+func TestReadyRequiresDownstreamSeparateMode(t *testing.T) {
+	t.Run("reachable db_service", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		server := grpc.NewServer()
+		go func() {
+			_ = server.Serve(listener)
+		}()
+		t.Cleanup(server.Stop)
+
+		cfg := newTestConfig("separate")
+		cfg.External.DBServiceURL = listener.Addr().String()
+		gw := NewSimpleGateway(cfg)
+		t.Cleanup(func() { _ = gw.Stop() })
+
+		assert.Equal(t, fiber.StatusServiceUnavailable, getReadyStatus(t, gw))
+		require.NoError(t, gw.Initialize())
+		assert.Equal(t, fiber.StatusOK, getReadyStatus(t, gw))
+	})
+
+	t.Run("unreachable db_service", func(t *testing.T) {
+		// Reserve a port and release it so nothing is listening there
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		cfg := newTestConfig("separate")
+		cfg.External.DBServiceURL = address
+		gw := NewSimpleGateway(cfg)
+		t.Cleanup(func() { _ = gw.Stop() })
+
+		require.NoError(t, gw.Initialize())
+		assert.True(t, gw.IsReady())
+		assert.Equal(t, fiber.StatusServiceUnavailable, getReadyStatus(t, gw))
+	})
+}
+
+func TestShutdownReleasesListenerSingleMode(t *testing.T) {
+	// Reserve a free port for the HTTP server
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	cfg := newTestConfig("single")
+	cfg.Server.HTTPPort = port
+	gw := NewSimpleGateway(cfg)
+	require.NoError(t, gw.Start(context.Background()))
+
+	// Wait until the HTTP server accepts connections
+	address := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	require.NoError(t, gw.Shutdown(ctx))
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.False(t, gw.IsReady())
+
+	// The port is free again once shutdown returns
+	reused, err := net.Listen("tcp", address)
+	require.NoError(t, err)
+	require.NoError(t, reused.Close())
+}
+
+func TestRequestIDPropagatesToServiceLogs(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, logger.Initialize(logger.Config{
+		Level:  "info",
+		Format: "json",
+		Output: &buf,
+	}))
+
+	app := newInitializedGateway(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/users/missing-user", nil)
+	req.Header.Set("X-Request-ID", "http-request-42")
+	req.Header.Set("X-User-ID", "user-7")
+	resp, err := app.Test(req, 5000)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// The service-layer log line for the gRPC call carries the HTTP request ID
+	var serviceEntry map[string]interface{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+			continue
+		}
+		if entry["grpc.method"] == "/etc_meisai.v1.UserService/GetUser" {
+			serviceEntry = entry
+		}
+	}
+	require.NotNil(t, serviceEntry, "expected a gRPC log line in:\n%s", buf.String())
+	assert.Equal(t, "http-request-42", serviceEntry["request_id"])
+	assert.Equal(t, "user-7", serviceEntry["user_id"])
+}
+
+// duplicateRoutes lists the method and path of every route registered more
+// than once. Fiber routes to the first match, so the later handlers never run;
+// consecutive registrations are merged into one route whose extra handlers
+// only run if the first calls Next, which no route here does.
+func duplicateRoutes(app *fiber.App) []string {
+	stack := app.Stack()
+
+	// Middleware added with Use is registered for every method, including
+	// CONNECT which no route here serves
+	middleware := map[string]int{}
+	for i, method := range app.Config().RequestMethods {
+		if method == fiber.MethodConnect {
+			for _, route := range stack[i] {
+				middleware[route.Path]++
+			}
+		}
+	}
+
+	var duplicates []string
+	for _, routes := range stack {
+		registrations := map[string]int{}
+		for _, route := range routes {
+			before := registrations[route.Path] - middleware[route.Path]
+			if middleware[route.Path] > 0 {
+				registrations[route.Path]++
+			} else {
+				registrations[route.Path] += len(route.Handlers)
+			}
+			if before < 2 && registrations[route.Path]-middleware[route.Path] >= 2 {
+				duplicates = append(duplicates, route.Method+" "+route.Path)
+			}
+		}
+	}
+	return duplicates
+}
+
+func TestNoDuplicateRoutes(t *testing.T) {
+	t.Run("detects duplicates", func(t *testing.T) {
+		app := fiber.New()
+		handler := func(c *fiber.Ctx) error { return nil }
+		app.Use(handler)
+		app.Get("/", handler)
+		app.Post("/jsonrpc", handler)
+		app.Post("/jsonrpc", handler)
+		app.Put("/users", handler)
+		app.Put("/cards", handler)
+		app.Put("/users", handler)
+		assert.ElementsMatch(t, []string{"POST /jsonrpc", "PUT /users"}, duplicateRoutes(app))
+	})
+
+	for _, mode := range []string{"single", "separate"} {
+		t.Run(mode, func(t *testing.T) {
+			cfg := newTestConfig(mode)
+			// Routes are registered even when db_service is unreachable
+			cfg.External.DBServiceURL = "127.0.0.1:1"
+			gw := NewSimpleGateway(cfg)
+			t.Cleanup(func() { _ = gw.Stop() })
+			require.NoError(t, gw.Initialize())
+
+			assert.Empty(t, duplicateRoutes(gw.GetHTTPHandler()))
+		})
+	}
+}
+
+func TestDependencyUpTracksHealthChecks(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Monitoring.MetricsEnabled = true
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	var down atomic.Bool
+	gw.healthService.RegisterChecker("simulated", health.NewCheckerFunc("simulated", func(ctx context.Context) error {
+		if down.Load() {
+			return errors.New("simulated outage")
+		}
+		return nil
+	}))
+
+	scrape := func() string {
+		resp, err := gw.GetHTTPHandler().Test(httptest.NewRequest("GET", "/metrics", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	gw.healthService.CheckAll(context.Background())
+	metricsBody := scrape()
+	assert.Contains(t, metricsBody, `dependency_up{deployment_mode="single",name="simulated"} 1`)
+	assert.Contains(t, metricsBody, `dependency_up{deployment_mode="single",name="user_service"} 1`)
+
+	down.Store(true)
+	gw.healthService.CheckAll(context.Background())
+	assert.Contains(t, scrape(), `dependency_up{deployment_mode="single",name="simulated"} 0`)
+
+	down.Store(false)
+	gw.healthService.CheckAll(context.Background())
+	assert.Contains(t, scrape(), `dependency_up{deployment_mode="single",name="simulated"} 1`)
+
+	// Shutdown reports every dependency down, and later checks don't revive it
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, gw.Shutdown(ctx))
+	gw.healthService.CheckAll(context.Background())
+	metricsBody = scrape()
+	assert.Contains(t, metricsBody, `dependency_up{deployment_mode="single",name="simulated"} 0`)
+	assert.Contains(t, metricsBody, `dependency_up{deployment_mode="single",name="user_service"} 0`)
+}
+
+func TestHTTPRequestMetricsOnRunningGateway(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	cfg := newTestConfig("single")
+	cfg.Server.HTTPPort = port
+	cfg.Monitoring.MetricsEnabled = true
+	gw := NewSimpleGateway(cfg)
+	require.NoError(t, gw.Start(context.Background()))
+	t.Cleanup(func() { _ = gw.Stop() })
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get("http://" + net.JoinHostPort("127.0.0.1", strconv.Itoa(port)) + path)
+		if err != nil {
+			return 0, ""
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+	require.Eventually(t, func() bool {
+		status, _ := get("/health/live")
+		return status == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The series behind the documented rate(http_server_requests_total[5m]) query
+	status, metricsBody := get("/metrics")
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, metricsBody, `http_server_requests_total{deployment_mode="single",method="GET",path="/health/live",status="200"}`)
+	assert.Contains(t, metricsBody, `http_server_request_duration_seconds_count{deployment_mode="single",method="GET",path="/health/live",status="200"}`)
+
+	for _, line := range strings.Split(metricsBody, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		assert.Contains(t, line, `deployment_mode="single"`)
+	}
+}
+
+func TestMetricsServedOnAdminPort(t *testing.T) {
+	freePort := func() int {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+		return listener.Addr().(*net.TCPAddr).Port
+	}
+
+	cfg := newTestConfig("single")
+	cfg.Server.HTTPPort = freePort()
+	cfg.Monitoring.MetricsEnabled = true
+	cfg.Monitoring.AdminListener = true
+	cfg.Monitoring.MetricsPort = freePort()
+	gw := NewSimpleGateway(cfg)
+	require.NoError(t, gw.Start(context.Background()))
+	t.Cleanup(func() { _ = gw.Stop() })
+
+	get := func(port int, path string) int {
+		resp, err := http.Get("http://" + net.JoinHostPort("127.0.0.1", strconv.Itoa(port)) + path)
+		if err != nil {
+			return 0
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Wait until both listeners accept connections
+	require.Eventually(t, func() bool {
+		return get(cfg.Server.HTTPPort, "/health/live") == http.StatusOK &&
+			get(cfg.Monitoring.MetricsPort, "/metrics") == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, http.StatusNotFound, get(cfg.Server.HTTPPort, "/metrics"))
+	assert.Equal(t, http.StatusNotFound, get(cfg.Monitoring.MetricsPort, "/health/live"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, gw.Shutdown(ctx))
+
+	// Both ports are released on shutdown
+	for _, port := range []int{cfg.Server.HTTPPort, cfg.Monitoring.MetricsPort} {
+		reused, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+		require.NoError(t, err)
+		require.NoError(t, reused.Close())
+	}
+}
+
+func TestDurationBucketsTunedForGateway(t *testing.T) {
+	scrapeAfterCall := func(t *testing.T, cfg *config.Config) string {
+		gw := NewSimpleGateway(cfg)
+		t.Cleanup(func() { _ = gw.Stop() })
+		require.NoError(t, gw.Initialize())
+		app := gw.GetHTTPHandler()
+
+		req := httptest.NewRequest("POST", "/jsonrpc", bytes.NewBufferString(`{"jsonrpc": "2.0", "method": "user.list", "params": {}, "id": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		resp, err = app.Test(httptest.NewRequest("GET", "/metrics", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+	bucket := func(le string) string {
+		return `jsonrpc_request_duration_seconds_bucket{deployment_mode="single",method="user.list",le="` + le + `"}`
+	}
+	httpBucket := func(le string) string {
+		return `http_server_request_duration_seconds_bucket{deployment_mode="single",method="POST",path="/jsonrpc",status="200",le="` + le + `"}`
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg := newTestConfig("single")
+		cfg.Monitoring.MetricsEnabled = true
+		metricsBody := scrapeAfterCall(t, cfg)
+
+		for _, le := range []string{"0.001", "0.0075", "0.015", "0.075", "10"} {
+			assert.Contains(t, metricsBody, bucket(le))
+			assert.Contains(t, metricsBody, httpBucket(le))
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		cfg := newTestConfig("single")
+		cfg.Monitoring.MetricsEnabled = true
+		cfg.Monitoring.DurationBuckets = []float64{0.002, 0.042}
+		metricsBody := scrapeAfterCall(t, cfg)
+
+		assert.Contains(t, metricsBody, bucket("0.002"))
+		assert.Contains(t, metricsBody, bucket("0.042"))
+		assert.NotContains(t, metricsBody, bucket("0.0075"))
+		assert.Contains(t, metricsBody, httpBucket("0.042"))
+		assert.NotContains(t, metricsBody, httpBucket("0.0075"))
+	})
+
+	grpcBucket := func(le string) string {
+		return `grpc_server_handling_seconds_bucket{deployment_mode="single",grpc_code="OK",grpc_method="ListUsers",grpc_service="etc_meisai.v1.UserService",le="` + le + `"}`
+	}
+
+	t.Run("grpc defaults", func(t *testing.T) {
+		cfg := newTestConfig("single")
+		cfg.Monitoring.MetricsEnabled = true
+		metricsBody := scrapeAfterCall(t, cfg)
+
+		assert.Contains(t, metricsBody, `grpc_server_handled_total{deployment_mode="single",grpc_code="OK",grpc_method="ListUsers",grpc_service="etc_meisai.v1.UserService"} 1`)
+		assert.Contains(t, metricsBody, grpcBucket("0.0005"))
+	})
+
+	t.Run("grpc configured", func(t *testing.T) {
+		cfg := newTestConfig("single")
+		cfg.Monitoring.MetricsEnabled = true
+		cfg.Monitoring.GRPCDurationBuckets = []float64{0.003, 0.3}
+		metricsBody := scrapeAfterCall(t, cfg)
+
+		assert.Contains(t, metricsBody, grpcBucket("0.003"))
+		assert.Contains(t, metricsBody, grpcBucket("0.3"))
+		assert.NotContains(t, metricsBody, grpcBucket("0.0005"))
+	})
+}
\ No newline at end of file
@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+)
+
+// StatementPDFContentType is the content type of rendered monthly statements
+const StatementPDFContentType = "application/pdf"
+
+// statementFilename names the downloaded PDF for a monthly statement
+func statementFilename(statement *pb.MonthlyStatement) string {
+	return fmt.Sprintf("statement-%04d-%02d.pdf", statement.Year, statement.Month)
+}
+
+// renderStatementPDF renders a monthly statement as a single-page A4 PDF.
+// Statements without trips still render, noting that nothing was recorded.
+func renderStatementPDF(statement *pb.MonthlyStatement) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Monthly Statement %04d-%02d", statement.Year, statement.Month), false)
+	pdf.SetCreator("ETC Meisai Gateway", false)
+	if statement.GeneratedAt != nil {
+		pdf.SetCreationDate(statement.GeneratedAt.AsTime())
+	}
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 12, "ETC Monthly Statement", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(0, 7, fmt.Sprintf("Period: %04d-%02d", statement.Year, statement.Month), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 7, "User: "+statement.UserId, "", 1, "L", false, 0, "")
+	if statement.CardId != "" {
+		pdf.CellFormat(0, 7, "Card: "+statement.CardId, "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(6)
+
+	if statement.TotalTrips == 0 {
+		pdf.CellFormat(0, 8, "No trips were recorded for this period.", "", 1, "L", false, 0, "")
+	} else {
+		rows := [][2]string{
+			{"Trips", strconv.Itoa(int(statement.TotalTrips))},
+			{"Distance", strconv.FormatFloat(statement.TotalDistance, 'f', 1, 64) + " km"},
+			{"Toll amount", formatYen(statement.TotalAmount)},
+			{"Discount", "-" + formatYen(statement.DiscountAmount)},
+		}
+		for _, row := range rows {
+			pdf.CellFormat(60, 8, row[0], "B", 0, "L", false, 0, "")
+			pdf.CellFormat(60, 8, row[1], "B", 1, "R", false, 0, "")
+		}
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(60, 8, "Amount due", "B", 0, "L", false, 0, "")
+		pdf.CellFormat(60, 8, formatYen(statement.FinalAmount), "B", 1, "R", false, 0, "")
+		pdf.SetFont("Helvetica", "", 11)
+	}
+
+	if statement.PaymentDueDate != nil {
+		pdf.Ln(6)
+		pdf.CellFormat(0, 7, "Payment due: "+statement.PaymentDueDate.AsTime().Format(time.DateOnly), "", 1, "L", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render statement PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// formatYen formats an amount in yen with thousands separators, e.g. JPY 12,345
+func formatYen(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	var grouped []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped = append(grouped, ',')
+		}
+		grouped = append(grouped, digits[i])
+	}
+	return sign + "JPY " + string(grouped)
+}
\ No newline at end of file
@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+)
+
+func TestStatementPDFREST(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/payments/statement/user-1/2024/3/pdf", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, StatementPDFContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="statement-2024-03.pdf"`, resp.Header.Get("Content-Disposition"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(body, []byte("%PDF")), "body should start with a PDF header")
+
+	t.Run("invalid month", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/payments/statement/user-1/2024/13/pdf", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("non-numeric period", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/payments/statement/user-1/2024/march/pdf", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestRenderStatementPDFWithoutTrips(t *testing.T) {
+	data, err := renderStatementPDF(&pb.MonthlyStatement{
+		UserId: "user-1",
+		Year:   2024,
+		Month:  1,
+	})
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF")), "empty statements should still render")
+}
+
+func TestFormatYen(t *testing.T) {
+	assert.Equal(t, "JPY 0", formatYen(0))
+	assert.Equal(t, "JPY 999", formatYen(999))
+	assert.Equal(t, "JPY 12,345", formatYen(12345))
+	assert.Equal(t, "JPY 1,234,567", formatYen(1234567))
+	assert.Equal(t, "-JPY 1,000", formatYen(-1000))
+}
\ No newline at end of file
@@ -2,22 +2,35 @@ package gateway
 
 import (
 	"encoding/json"
-	"io/ioutil"
-	"log"
+	"fmt"
+	"io/fs"
 	"net/http"
-	"path/filepath"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	applogger "github.com/yhonda-ohishi/db-handler-server/internal/logger"
+	swaggerspec "github.com/yhonda-ohishi/db-handler-server/proto/swagger"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
 // Using CDN for Swagger UI instead of embedding files
 
+// swaggerFetchTimeout bounds the download of a remote swagger spec
+const swaggerFetchTimeout = 5 * time.Second
+
 // SwaggerSpec represents the OpenAPI specification
 type SwaggerSpec struct {
 	OpenAPI string                 `json:"openapi"`
 	Info    SwaggerInfo            `json:"info"`
 	Servers []SwaggerServer        `json:"servers"`
 	Paths   map[string]interface{} `json:"paths"`
+	// Components holds the schemas referenced from Paths
+	Components map[string]interface{} `json:"components,omitempty"`
 }
 
 type SwaggerInfo struct {
@@ -33,88 +46,21 @@ type SwaggerServer struct {
 
 // SetupSwaggerUI configures Swagger UI routes
 func (g *SimpleGateway) SetupSwaggerUI() {
-	// Serve auto-generated swagger spec JSON from protobuf
+	// Serve the merged upstream swagger spec, built once on first request
 	g.app.Get("/swagger.json", func(c *fiber.Ctx) error {
-		// Create merged swagger spec
-		mergedSwagger := map[string]interface{}{
-			"swagger": "2.0",
-			"info": map[string]interface{}{
-				"title":       "ETC Meisai Gateway API",
-				"description": "Combined API documentation for all services",
-				"version":     "1.0.0",
-			},
-			"schemes": []string{"http", "https"},
-			"consumes": []string{"application/json"},
-			"produces": []string{"application/json"},
-			"paths": make(map[string]interface{}),
-			"definitions": make(map[string]interface{}),
-		}
-
-		// Try to read db_service swagger file
-		dbServiceSwaggerPath := filepath.Join("..", "db_service", "swagger", "apidocs.swagger.json")
-		if data, err := ioutil.ReadFile(dbServiceSwaggerPath); err == nil {
-			var dbServiceSwagger map[string]interface{}
-			if err := json.Unmarshal(data, &dbServiceSwagger); err == nil {
-				log.Printf("Successfully loaded db_service swagger")
-				// Merge paths and definitions
-				if paths, ok := dbServiceSwagger["paths"].(map[string]interface{}); ok {
-					for k, v := range paths {
-						mergedSwagger["paths"].(map[string]interface{})[k] = v
-					}
-				}
-				if defs, ok := dbServiceSwagger["definitions"].(map[string]interface{}); ok {
-					for k, v := range defs {
-						mergedSwagger["definitions"].(map[string]interface{})[k] = v
-					}
-				}
-			}
-		}
-
-		// Try to fetch etc_meisai_scraper swagger from GitHub
-		etcMeisaiSwaggerURL := "https://raw.githubusercontent.com/yhonda-ohishi/etc_meisai_scraper/master/swagger/etc_meisai.swagger.json"
-		if resp, err := http.Get(etcMeisaiSwaggerURL); err == nil && resp.StatusCode == 200 {
-			defer resp.Body.Close()
-			if data, err := ioutil.ReadAll(resp.Body); err == nil {
-				var etcMeisaiSwagger map[string]interface{}
-				if err := json.Unmarshal(data, &etcMeisaiSwagger); err == nil {
-					log.Printf("Successfully loaded etc_meisai_scraper swagger from GitHub")
-					// Merge paths and definitions
-					if paths, ok := etcMeisaiSwagger["paths"].(map[string]interface{}); ok {
-						for k, v := range paths {
-							mergedSwagger["paths"].(map[string]interface{})[k] = v
-						}
-					}
-					if defs, ok := etcMeisaiSwagger["definitions"].(map[string]interface{}); ok {
-						for k, v := range defs {
-							mergedSwagger["definitions"].(map[string]interface{})[k] = v
-						}
-					}
-				}
-			}
-		} else {
-			// Fallback to local file if GitHub fetch fails
-			etcMeisaiSwaggerPath := filepath.Join("..", "etc_meisai_scraper", "swagger", "etc_meisai.swagger.json")
-			if data, err := ioutil.ReadFile(etcMeisaiSwaggerPath); err == nil {
-				var etcMeisaiSwagger map[string]interface{}
-				if err := json.Unmarshal(data, &etcMeisaiSwagger); err == nil {
-					log.Printf("Successfully loaded etc_meisai_scraper swagger from local file")
-					// Merge paths and definitions
-					if paths, ok := etcMeisaiSwagger["paths"].(map[string]interface{}); ok {
-						for k, v := range paths {
-							mergedSwagger["paths"].(map[string]interface{})[k] = v
-						}
-					}
-					if defs, ok := etcMeisaiSwagger["definitions"].(map[string]interface{}); ok {
-						for k, v := range defs {
-							mergedSwagger["definitions"].(map[string]interface{})[k] = v
-						}
-					}
-				}
-			}
+		g.swaggerOnce.Do(func() {
+			g.swaggerJSON, g.swaggerErr = json.Marshal(g.buildMergedSwagger())
+		})
+		if g.swaggerErr != nil {
+			return restError(c, fiber.StatusInternalServerError, "Failed to build swagger spec")
 		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(g.swaggerJSON)
+	})
 
-		// Return merged swagger
-		return c.JSON(mergedSwagger)
+	// Serve the OpenAPI spec generated from registered routes and proto services
+	g.app.Get("/openapi.json", func(c *fiber.Ctx) error {
+		return c.JSON(g.generateSwaggerSpec())
 	})
 
 	// Serve Swagger UI
@@ -137,15 +83,130 @@ func (g *SimpleGateway) SetupSwaggerUI() {
 			"endpoints": []string{
 				"/docs",
 				"/swagger.json",
+				"/openapi.json",
 				"/api-docs",
 			},
 		})
 	})
 }
 
-// generateSwaggerSpec creates OpenAPI 3.0 specification
+// buildMergedSwagger merges the db_service and etc_meisai_scraper specs into
+// a single swagger 2.0 document
+func (g *SimpleGateway) buildMergedSwagger() map[string]interface{} {
+	merged := map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":       "ETC Meisai Gateway API",
+			"description": "Combined API documentation for all services",
+			"version":     "1.0.0",
+		},
+		"schemes":     []string{"http", "https"},
+		"consumes":    []string{"application/json"},
+		"produces":    []string{"application/json"},
+		"paths":       make(map[string]interface{}),
+		"definitions": make(map[string]interface{}),
+	}
+	cfg := g.config.Swagger
+
+	if spec, path, ok := loadSwaggerFile(cfg.DBServicePaths); ok {
+		applogger.Debugf("Swagger: db_service spec loaded from %s", path)
+		mergeSwagger(merged, spec)
+	} else {
+		applogger.Debugf("Swagger: db_service spec not found in %v, using embedded spec", cfg.DBServicePaths)
+		mergeSwagger(merged, embeddedSwagger())
+	}
+
+	if cfg.ETCMeisaiURL != "" {
+		if spec, err := fetchSwagger(cfg.ETCMeisaiURL); err == nil {
+			applogger.Debugf("Swagger: etc_meisai_scraper spec loaded from %s", cfg.ETCMeisaiURL)
+			mergeSwagger(merged, spec)
+			return merged
+		}
+	}
+	if spec, path, ok := loadSwaggerFile(cfg.ETCMeisaiPaths); ok {
+		applogger.Debugf("Swagger: etc_meisai_scraper spec loaded from %s", path)
+		mergeSwagger(merged, spec)
+	} else {
+		applogger.Debugf("Swagger: etc_meisai_scraper spec unavailable, skipping")
+	}
+	return merged
+}
+
+// loadSwaggerFile parses the first of paths holding a valid spec
+func loadSwaggerFile(paths []string) (map[string]interface{}, string, bool) {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var spec map[string]interface{}
+		if err := json.Unmarshal(data, &spec); err != nil {
+			applogger.Debugf("Swagger: ignoring %s: %v", path, err)
+			continue
+		}
+		return spec, path, true
+	}
+	return nil, "", false
+}
+
+// fetchSwagger downloads and parses the spec at url
+func fetchSwagger(url string) (map[string]interface{}, error) {
+	client := &http.Client{Timeout: swaggerFetchTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var spec map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// embeddedSwagger merges the specs generated from this module's protos
+func embeddedSwagger() map[string]interface{} {
+	merged := map[string]interface{}{
+		"paths":       make(map[string]interface{}),
+		"definitions": make(map[string]interface{}),
+	}
+	files, _ := fs.Glob(swaggerspec.Files, "*.swagger.json")
+	for _, name := range files {
+		data, err := swaggerspec.Files.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		var spec map[string]interface{}
+		if err := json.Unmarshal(data, &spec); err == nil {
+			mergeSwagger(merged, spec)
+		}
+	}
+	return merged
+}
+
+// mergeSwagger copies the paths and definitions of src into dst
+func mergeSwagger(dst, src map[string]interface{}) {
+	for _, key := range []string{"paths", "definitions"} {
+		entries, ok := src[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		target := dst[key].(map[string]interface{})
+		for k, v := range entries {
+			target[k] = v
+		}
+	}
+}
+
+// generateSwaggerSpec creates OpenAPI 3.0 specification from the registered
+// Fiber routes and the HTTP annotations of the registered proto services
 func (g *SimpleGateway) generateSwaggerSpec() *SwaggerSpec {
-	return &SwaggerSpec{
+	paths := make(map[string]interface{})
+	spec := &SwaggerSpec{
 		OpenAPI: "3.0.0",
 		Info: SwaggerInfo{
 			Title:       "gRPC-First Multi-Protocol Gateway API",
@@ -154,295 +215,213 @@ func (g *SimpleGateway) generateSwaggerSpec() *SwaggerSpec {
 		},
 		Servers: []SwaggerServer{
 			{
-				URL:         "http://localhost:8081",
+				URL:         fmt.Sprintf("http://localhost:%d", g.config.Server.HTTPPort),
 				Description: "Development server",
 			},
 		},
-		Paths: map[string]interface{}{
-			"/health": map[string]interface{}{
-				"get": map[string]interface{}{
-					"summary":     "Health check",
-					"description": "Returns the health status of the gateway",
-					"responses": map[string]interface{}{
-						"200": map[string]interface{}{
-							"description": "Healthy",
-							"content": map[string]interface{}{
-								"application/json": map[string]interface{}{
-									"schema": map[string]interface{}{
-										"type": "object",
-										"properties": map[string]interface{}{
-											"status": map[string]interface{}{
-												"type": "string",
-											},
-											"timestamp": map[string]interface{}{
-												"type": "string",
-												"format": "date-time",
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"/api/v1/users": map[string]interface{}{
-				"get": map[string]interface{}{
-					"summary":     "List users",
-					"description": "Retrieve a list of users with pagination",
-					"parameters": []map[string]interface{}{
-						{
-							"name":        "page_size",
-							"in":          "query",
-							"description": "Number of users to return",
-							"schema": map[string]interface{}{
-								"type":    "integer",
-								"default": 10,
-							},
-						},
-						{
-							"name":        "page_token",
-							"in":          "query",
-							"description": "Token for pagination",
-							"schema": map[string]interface{}{
-								"type": "string",
-							},
-						},
-					},
-					"responses": map[string]interface{}{
-						"200": map[string]interface{}{
-							"description": "List of users",
-							"content": map[string]interface{}{
-								"application/json": map[string]interface{}{
-									"schema": map[string]interface{}{
-										"type": "object",
-										"properties": map[string]interface{}{
-											"users": map[string]interface{}{
-												"type": "array",
-												"items": map[string]interface{}{
-													"$ref": "#/components/schemas/User",
-												},
-											},
-											"next_page_token": map[string]interface{}{
-												"type": "string",
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-				"post": map[string]interface{}{
-					"summary":     "Create user",
-					"description": "Create a new user",
-					"requestBody": map[string]interface{}{
-						"required": true,
-						"content": map[string]interface{}{
-							"application/json": map[string]interface{}{
-								"schema": map[string]interface{}{
-									"$ref": "#/components/schemas/CreateUserRequest",
-								},
-							},
-						},
-					},
-					"responses": map[string]interface{}{
-						"201": map[string]interface{}{
-							"description": "User created",
-							"content": map[string]interface{}{
-								"application/json": map[string]interface{}{
-									"schema": map[string]interface{}{
-										"$ref": "#/components/schemas/User",
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"/api/v1/users/{id}": map[string]interface{}{
-				"get": map[string]interface{}{
-					"summary": "Get user by ID",
-					"parameters": []map[string]interface{}{
-						{
-							"name":     "id",
-							"in":       "path",
-							"required": true,
-							"schema": map[string]interface{}{
-								"type": "string",
-							},
-						},
-					},
-					"responses": map[string]interface{}{
-						"200": map[string]interface{}{
-							"description": "User details",
-							"content": map[string]interface{}{
-								"application/json": map[string]interface{}{
-									"schema": map[string]interface{}{
-										"$ref": "#/components/schemas/User",
-									},
-								},
-							},
-						},
-						"404": map[string]interface{}{
-							"description": "User not found",
-						},
-					},
-				},
-			},
-			"/api/v1/transactions/{id}": map[string]interface{}{
-				"get": map[string]interface{}{
-					"summary": "Get transaction by ID",
-					"parameters": []map[string]interface{}{
-						{
-							"name":     "id",
-							"in":       "path",
-							"required": true,
-							"schema": map[string]interface{}{
-								"type": "string",
-							},
-						},
-					},
-					"responses": map[string]interface{}{
-						"200": map[string]interface{}{
-							"description": "Transaction details",
-							"content": map[string]interface{}{
-								"application/json": map[string]interface{}{
-									"schema": map[string]interface{}{
-										"$ref": "#/components/schemas/Transaction",
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"/jsonrpc": map[string]interface{}{
-				"post": map[string]interface{}{
-					"summary":     "JSON-RPC 2.0 endpoint",
-					"description": "Execute JSON-RPC 2.0 methods",
-					"requestBody": map[string]interface{}{
-						"required": true,
-						"content": map[string]interface{}{
-							"application/json": map[string]interface{}{
-								"schema": map[string]interface{}{
-									"$ref": "#/components/schemas/JsonRpcRequest",
-								},
-							},
-						},
-					},
-					"responses": map[string]interface{}{
-						"200": map[string]interface{}{
-							"description": "JSON-RPC response",
-							"content": map[string]interface{}{
-								"application/json": map[string]interface{}{
-									"schema": map[string]interface{}{
-										"$ref": "#/components/schemas/JsonRpcResponse",
-									},
-								},
-							},
-						},
-					},
-				},
+		Paths: paths,
+	}
+	spec.AddSwaggerSchemas()
+	schemas, _ := spec.Components["schemas"].(map[string]interface{})
+
+	// Proto annotations first so RPC names are used as operation IDs
+	if g.grpcServer != nil {
+		for serviceName := range g.grpcServer.GetServiceInfo() {
+			desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+			if err != nil {
+				continue
+			}
+			service, ok := desc.(protoreflect.ServiceDescriptor)
+			if !ok {
+				continue
+			}
+			for _, binding := range protoHTTPBindings(service) {
+				operationID := string(service.Name()) + "." + binding.rpc
+				addSwaggerOperation(paths, binding.method, binding.path, operationID)
+				addSchemaRefs(paths, binding, operationID, schemas)
+			}
+		}
+	}
+
+	// Fiber routes, including ones without proto annotations
+	for _, route := range g.app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || route.Method == fiber.MethodConnect || route.Method == fiber.MethodTrace {
+			continue
+		}
+		addSwaggerOperation(paths, route.Method, fiberPathToOpenAPI(route.Path), route.Name)
+	}
+
+	return spec
+}
+
+// protoHTTPBinding is a single google.api.http binding of an RPC method
+type protoHTTPBinding struct {
+	rpc    string
+	method string
+	path   string
+	body   string
+	input  string
+	output string
+}
+
+// protoHTTPBindings returns the HTTP bindings declared on a service's methods
+func protoHTTPBindings(service protoreflect.ServiceDescriptor) []protoHTTPBinding {
+	var bindings []protoHTTPBinding
+	methods := service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || rule == nil {
+			continue
+		}
+		for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+			httpMethod, path := httpRuleMethodAndPath(r)
+			if path == "" {
+				continue
+			}
+			bindings = append(bindings, protoHTTPBinding{
+				rpc:    string(method.Name()),
+				method: httpMethod,
+				path:   protoPathToOpenAPI(path),
+				body:   r.GetBody(),
+				input:  string(method.Input().Name()),
+				output: string(method.Output().Name()),
+			})
+		}
+	}
+	return bindings
+}
+
+// httpRuleMethodAndPath extracts the HTTP verb and path template of a rule
+func httpRuleMethodAndPath(rule *annotations.HttpRule) (string, string) {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return fiber.MethodGet, pattern.Get
+	case *annotations.HttpRule_Post:
+		return fiber.MethodPost, pattern.Post
+	case *annotations.HttpRule_Put:
+		return fiber.MethodPut, pattern.Put
+	case *annotations.HttpRule_Delete:
+		return fiber.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Patch:
+		return fiber.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Custom:
+		return strings.ToUpper(pattern.Custom.GetKind()), pattern.Custom.GetPath()
+	}
+	return "", ""
+}
+
+// fiberPathToOpenAPI converts Fiber path parameters (":id", ":id?", "*") to {id}
+func fiberPathToOpenAPI(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segments[i] = "{" + strings.TrimRight(segment[1:], "?+") + "}"
+		case segment == "*" || segment == "+":
+			segments[i] = "{wildcard}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// protoPathToOpenAPI drops sub-patterns from proto path templates ({name=shelves/*} -> {name})
+func protoPathToOpenAPI(path string) string {
+	var b strings.Builder
+	for len(path) > 0 {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			b.WriteString(path)
+			break
+		}
+		end := strings.Index(path[start:], "}")
+		if end < 0 {
+			b.WriteString(path)
+			break
+		}
+		variable := path[start+1 : start+end]
+		if eq := strings.Index(variable, "="); eq >= 0 {
+			variable = variable[:eq]
+		}
+		b.WriteString(path[:start] + "{" + variable + "}")
+		path = path[start+end+1:]
+	}
+	return b.String()
+}
+
+// addSwaggerOperation adds a stub operation for method and path unless one is already documented
+func addSwaggerOperation(paths map[string]interface{}, method, path, operationID string) {
+	item, ok := paths[path].(map[string]interface{})
+	if !ok {
+		item = make(map[string]interface{})
+		paths[path] = item
+	}
+
+	key := strings.ToLower(method)
+	if _, exists := item[key]; exists {
+		return
+	}
+
+	operation := map[string]interface{}{
+		"summary": method + " " + path,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Successful response",
 			},
-			"/api/v1/etc/meisai": map[string]interface{}{
-				"get": map[string]interface{}{
-					"tags":        []string{"ETC明細"},
-					"summary":     "ETC明細一覧取得",
-					"description": "ETC明細データの一覧を取得します",
-					"parameters": []map[string]interface{}{
-						{
-							"name":        "start_date",
-							"in":          "query",
-							"description": "開始日 (YYYY-MM-DD)",
-							"schema": map[string]interface{}{
-								"type": "string",
-								"format": "date",
-							},
-						},
-						{
-							"name":        "end_date",
-							"in":          "query",
-							"description": "終了日 (YYYY-MM-DD)",
-							"schema": map[string]interface{}{
-								"type": "string",
-								"format": "date",
-							},
-						},
-						{
-							"name":        "page_size",
-							"in":          "query",
-							"description": "1ページあたりの件数",
-							"schema": map[string]interface{}{
-								"type": "integer",
-								"default": 10,
-								"minimum": 1,
-								"maximum": 100,
-							},
-						},
-					},
-					"responses": map[string]interface{}{
-						"200": map[string]interface{}{
-							"description": "ETC明細一覧",
-							"content": map[string]interface{}{
-								"application/json": map[string]interface{}{
-									"schema": map[string]interface{}{
-										"$ref": "#/components/schemas/ETCMeisaiListResponse",
-									},
-								},
-							},
-						},
-					},
+		},
+	}
+	if operationID != "" {
+		operation["operationId"] = operationID
+	}
+
+	var parameters []map[string]interface{}
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			parameters = append(parameters, map[string]interface{}{
+				"name":     segment[1 : len(segment)-1],
+				"in":       "path",
+				"required": true,
+				"schema": map[string]interface{}{
+					"type": "string",
 				},
-			},
-			"/api/v1/etc/summary": map[string]interface{}{
-				"get": map[string]interface{}{
-					"tags":        []string{"ETC明細"},
-					"summary":     "ETC利用サマリー取得",
-					"description": "ETC明細データのサマリー情報を取得します",
-					"parameters": []map[string]interface{}{
-						{
-							"name":        "start_date",
-							"in":          "query",
-							"description": "集計開始日 (YYYY-MM-DD)",
-							"schema": map[string]interface{}{
-								"type": "string",
-								"format": "date",
-							},
-						},
-						{
-							"name":        "end_date",
-							"in":          "query",
-							"description": "集計終了日 (YYYY-MM-DD)",
-							"schema": map[string]interface{}{
-								"type": "string",
-								"format": "date",
-							},
-						},
-						{
-							"name":        "user_id",
-							"in":          "query",
-							"description": "ユーザーID（指定時は該当ユーザーのみ集計）",
-							"schema": map[string]interface{}{
-								"type": "string",
-							},
-						},
-					},
-					"responses": map[string]interface{}{
-						"200": map[string]interface{}{
-							"description": "ETC利用サマリー",
-							"content": map[string]interface{}{
-								"application/json": map[string]interface{}{
-									"schema": map[string]interface{}{
-										"$ref": "#/components/schemas/ETCSummaryResponse",
-									},
-								},
-							},
-						},
-					},
+			})
+		}
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	item[key] = operation
+}
+
+// addSchemaRefs points the request body and response of the operation added
+// for binding at the component schemas of its proto messages, when defined
+func addSchemaRefs(paths map[string]interface{}, binding protoHTTPBinding, operationID string, schemas map[string]interface{}) {
+	item, _ := paths[binding.path].(map[string]interface{})
+	operation, ok := item[strings.ToLower(binding.method)].(map[string]interface{})
+	if !ok || operation["operationId"] != operationID {
+		return
+	}
+
+	jsonContent := func(schema string) map[string]interface{} {
+		return map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{
+					"$ref": "#/components/schemas/" + schema,
 				},
 			},
-		},
+		}
+	}
+
+	if _, ok := schemas[binding.output]; ok {
+		responses := operation["responses"].(map[string]interface{})
+		responses["200"].(map[string]interface{})["content"] = jsonContent(binding.output)
+	}
+	if _, ok := schemas[binding.input]; ok && binding.body != "" {
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  jsonContent(binding.input),
+		}
 	}
 }
 
@@ -674,13 +653,5 @@ func (spec *SwaggerSpec) AddSwaggerSchemas() {
 		},
 	}
 
-	// Convert spec to map and add components
-	specMap := make(map[string]interface{})
-	specBytes, _ := json.Marshal(spec)
-	json.Unmarshal(specBytes, &specMap)
-	specMap["components"] = components
-
-	// Update spec
-	updatedBytes, _ := json.Marshal(specMap)
-	json.Unmarshal(updatedBytes, spec)
+	spec.Components = components
 }
\ No newline at end of file
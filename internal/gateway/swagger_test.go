@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwaggerSpecIncludesRegisteredRoutes(t *testing.T) {
+	gw := NewSimpleGateway(newTestConfig("single"))
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	resp, err := gw.GetHTTPHandler().Test(httptest.NewRequest("GET", "/openapi.json", nil), 5000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			OperationID string                   `json:"operationId"`
+			Parameters  []map[string]interface{} `json:"parameters"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&spec))
+
+	// From the PaymentService proto annotations
+	payment, ok := spec.Paths["/api/v1/payments/{id}"]["get"]
+	require.True(t, ok, "GET /api/v1/payments/{id} should be in the spec")
+	assert.Equal(t, "PaymentService.GetPayment", payment.OperationID)
+	require.Len(t, payment.Parameters, 1)
+	assert.Equal(t, "id", payment.Parameters[0]["name"])
+
+	// From the registered Fiber routes
+	assert.Contains(t, spec.Paths, "/health")
+	assert.Contains(t, spec.Paths["/jsonrpc"], "post")
+	assert.Contains(t, spec.Paths["/openapi.json"], "get")
+	assert.NotContains(t, spec.Paths["/health"], "head")
+}
+
+func getSwaggerPaths(t *testing.T, gw *SimpleGateway) map[string]interface{} {
+	t.Helper()
+
+	resp, err := gw.GetHTTPHandler().Test(httptest.NewRequest("GET", "/swagger.json", nil), 5000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var spec struct {
+		Paths map[string]interface{} `json:"paths"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&spec))
+	return spec.Paths
+}
+
+func TestSwaggerJSONServesConfiguredFileAndCaches(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "apidocs.swagger.json")
+	require.NoError(t, os.WriteFile(specPath, []byte(`{"paths":{"/from-file":{"get":{}}}}`), 0o644))
+
+	cfg := newTestConfig("single")
+	cfg.Swagger.DBServicePaths = []string{filepath.Join(t.TempDir(), "missing.json"), specPath}
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	paths := getSwaggerPaths(t, gw)
+	assert.Contains(t, paths, "/from-file")
+	assert.NotContains(t, paths, "/api/v1/users", "embedded spec should not be used when a file is found")
+
+	// The spec is cached, so changes on disk are not picked up
+	require.NoError(t, os.WriteFile(specPath, []byte(`{"paths":{"/changed":{"get":{}}}}`), 0o644))
+	paths = getSwaggerPaths(t, gw)
+	assert.Contains(t, paths, "/from-file")
+	assert.NotContains(t, paths, "/changed")
+}
+
+func TestSwaggerJSONFallsBackToEmbeddedSpec(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Swagger.DBServicePaths = []string{filepath.Join(t.TempDir(), "missing.json")}
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	assert.Contains(t, getSwaggerPaths(t, gw), "/api/v1/users")
+}
+
+// collectSwaggerRefs returns every $ref value found in v
+func collectSwaggerRefs(v interface{}) []string {
+	var refs []string
+	switch node := v.(type) {
+	case map[string]interface{}:
+		for key, child := range node {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				refs = append(refs, ref)
+				continue
+			}
+			refs = append(refs, collectSwaggerRefs(child)...)
+		}
+	case []interface{}:
+		for _, child := range node {
+			refs = append(refs, collectSwaggerRefs(child)...)
+		}
+	}
+	return refs
+}
+
+// resolveSwaggerRef follows a local "#/a/b" reference within doc
+func resolveSwaggerRef(doc map[string]interface{}, ref string) (interface{}, bool) {
+	var node interface{} = doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if node, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+func getSwaggerDocument(t *testing.T, gw *SimpleGateway, path string) map[string]interface{} {
+	t.Helper()
+
+	resp, err := gw.GetHTTPHandler().Test(httptest.NewRequest("GET", path, nil), 5000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	return doc
+}
+
+func TestSwaggerSchemaRefsResolve(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Swagger.DBServicePaths = []string{filepath.Join(t.TempDir(), "missing.json")}
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	openAPI := getSwaggerDocument(t, gw, "/openapi.json")
+	user, ok := resolveSwaggerRef(openAPI, "#/components/schemas/User")
+	require.True(t, ok, "components.schemas.User should be present")
+	assert.IsType(t, map[string]interface{}{}, user)
+
+	getUser := openAPI["paths"].(map[string]interface{})["/api/v1/users/{id}"].(map[string]interface{})["get"]
+	assert.Contains(t, collectSwaggerRefs(getUser), "#/components/schemas/User")
+
+	for _, path := range []string{"/openapi.json", "/swagger.json"} {
+		doc := getSwaggerDocument(t, gw, path)
+		refs := collectSwaggerRefs(doc)
+		assert.NotEmpty(t, refs, "%s should reference schemas", path)
+		for _, ref := range refs {
+			_, ok := resolveSwaggerRef(doc, ref)
+			assert.True(t, ok, "%s: unresolved reference %s", path, ref)
+		}
+	}
+}
\ No newline at end of file
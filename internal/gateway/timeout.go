@@ -0,0 +1,78 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+)
+
+// DefaultRequestTimeout is the request deadline for routes without an override
+const DefaultRequestTimeout = 30 * time.Second
+
+// RequestTimeouts holds the default request deadline and per-path overrides
+type RequestTimeouts struct {
+	Default time.Duration
+	// Overrides maps a path prefix to its deadline; the longest matching prefix
+	// wins and zero disables the deadline
+	Overrides map[string]time.Duration
+}
+
+// requestTimeoutsFromConfig builds request timeouts from configuration, filling
+// in defaults. The transaction feed is long-lived and has no deadline.
+func requestTimeoutsFromConfig(cfg config.ServerConfig) RequestTimeouts {
+	timeouts := RequestTimeouts{
+		Default: cfg.RequestTimeout,
+		Overrides: map[string]time.Duration{
+			TransactionFeedPath: 0,
+		},
+	}
+	if timeouts.Default <= 0 {
+		timeouts.Default = DefaultRequestTimeout
+	}
+	for path, timeout := range cfg.RequestTimeouts {
+		timeouts.Overrides[path] = timeout
+	}
+	return timeouts
+}
+
+// TimeoutFor returns the request deadline that applies to path
+func (t RequestTimeouts) TimeoutFor(path string) time.Duration {
+	timeout := t.Default
+	matched := ""
+	for prefix, override := range t.Overrides {
+		if strings.HasPrefix(path, prefix) && len(prefix) > len(matched) {
+			matched = prefix
+			timeout = override
+		}
+	}
+	return timeout
+}
+
+// newTimeoutMiddleware runs each handler with a deadline on its user context,
+// which handlers pass to their gRPC calls so those are cancelled with it.
+// Requests still running at the deadline answer 504 once the handler returns.
+func newTimeoutMiddleware(timeouts RequestTimeouts) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		timeout := timeouts.TimeoutFor(c.Path())
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		parent := c.UserContext()
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+		defer c.SetUserContext(parent)
+
+		err := c.Next()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return restError(c, fiber.StatusGatewayTimeout, fmt.Sprintf("Request timed out after %s", timeout))
+		}
+		return err
+	}
+}
\ No newline at end of file
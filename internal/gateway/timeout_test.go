@@ -0,0 +1,72 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutMiddlewareReturns504(t *testing.T) {
+	cfg := newTestConfig("single")
+	cfg.Server.RequestTimeout = 50 * time.Millisecond
+	cfg.Server.RequestTimeouts = map[string]time.Duration{"/api/patient": time.Second}
+	app := NewSimpleGateway(cfg).GetHTTPHandler()
+
+	// The slow handler waits on its context as a gRPC call would
+	handlerErr := make(chan error, 1)
+	slow := func(c *fiber.Ctx) error {
+		select {
+		case <-c.UserContext().Done():
+			handlerErr <- c.UserContext().Err()
+			return fiber.ErrInternalServerError
+		case <-time.After(200 * time.Millisecond):
+			handlerErr <- nil
+			return c.SendString("done")
+		}
+	}
+	app.Get("/api/slow", slow)
+	app.Get("/api/patient/slow", slow)
+
+	start := time.Now()
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/slow", nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusGatewayTimeout, resp.StatusCode)
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	errorObj := body["error"].(map[string]interface{})
+	assert.Equal(t, "DEADLINE_EXCEEDED", errorObj["code"])
+	assert.Equal(t, "Request timed out after 50ms", errorObj["message"])
+	assert.ErrorIs(t, <-handlerErr, context.DeadlineExceeded)
+
+	// The per-path override gives the same handler enough time
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/patient/slow", nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NoError(t, <-handlerErr)
+}
+
+func TestRequestTimeoutsLongestPrefixWins(t *testing.T) {
+	timeouts := RequestTimeouts{
+		Default: 30 * time.Second,
+		Overrides: map[string]time.Duration{
+			"/api":               5 * time.Second,
+			"/api/v1/etc/meisai": time.Minute,
+			TransactionFeedPath:  0,
+		},
+	}
+
+	assert.Equal(t, 30*time.Second, timeouts.TimeoutFor("/health"))
+	assert.Equal(t, 5*time.Second, timeouts.TimeoutFor("/api/v1/users"))
+	assert.Equal(t, time.Minute, timeouts.TimeoutFor("/api/v1/etc/meisai/bulk"))
+	assert.Equal(t, time.Duration(0), timeouts.TimeoutFor(TransactionFeedPath))
+}
\ No newline at end of file
@@ -0,0 +1,200 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+	"github.com/yhonda-ohishi/db-handler-server/internal/tlsconfig"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// testCerts holds PEM file paths for a self-signed CA and leaf certificates
+type testCerts struct {
+	caFile, serverCert, serverKey, clientCert, clientKey string
+}
+
+func writeTestCerts(t *testing.T) testCerts {
+	t.Helper()
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	writePEM := func(name, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+		return path
+	}
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			DNSNames:     []string{"localhost"},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		return writePEM(name+".crt", "CERTIFICATE", der), writePEM(name+".key", "EC PRIVATE KEY", keyDER)
+	}
+
+	certs := testCerts{caFile: writePEM("ca.crt", "CERTIFICATE", caDER)}
+	certs.serverCert, certs.serverKey = issue("server", 2, x509.ExtKeyUsageServerAuth)
+	certs.clientCert, certs.clientKey = issue("client", 3, x509.ExtKeyUsageClientAuth)
+	return certs
+}
+
+func startTLSGateway(t *testing.T, tlsCfg config.TLSConfig) string {
+	t.Helper()
+
+	cfg := newTestConfig("single")
+	cfg.Server.GRPCPort = 0
+	cfg.Server.GRPCListen = true
+	cfg.Server.TLS = tlsCfg
+
+	gw := NewSimpleGateway(cfg)
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	_, port, err := net.SplitHostPort(gw.GRPCAddress())
+	require.NoError(t, err)
+	return net.JoinHostPort("127.0.0.1", port)
+}
+
+func callGetUser(t *testing.T, address string, creds credentials.TransportCredentials) error {
+	t.Helper()
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = pb.NewUserServiceClient(conn).GetUser(ctx, &pb.GetUserRequest{Id: "missing-user"})
+	return err
+}
+
+func TestGRPCServerTLS(t *testing.T) {
+	certs := writeTestCerts(t)
+	address := startTLSGateway(t, config.TLSConfig{
+		Enabled:  true,
+		CertFile: certs.serverCert,
+		KeyFile:  certs.serverKey,
+	})
+
+	creds, err := tlsconfig.ClientCredentials(config.ClientTLSConfig{
+		Enabled:    true,
+		CAFile:     certs.caFile,
+		ServerName: "localhost",
+	})
+	require.NoError(t, err)
+
+	// The handshake succeeds, so the call reaches the service
+	err = callGetUser(t, address, creds)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	err = callGetUser(t, address, insecure.NewCredentials())
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestGRPCServerMutualTLS(t *testing.T) {
+	certs := writeTestCerts(t)
+	address := startTLSGateway(t, config.TLSConfig{
+		Enabled:      true,
+		CertFile:     certs.serverCert,
+		KeyFile:      certs.serverKey,
+		ClientCAFile: certs.caFile,
+	})
+
+	withClientCert, err := tlsconfig.ClientCredentials(config.ClientTLSConfig{
+		Enabled:    true,
+		CAFile:     certs.caFile,
+		CertFile:   certs.clientCert,
+		KeyFile:    certs.clientKey,
+		ServerName: "localhost",
+	})
+	require.NoError(t, err)
+	err = callGetUser(t, address, withClientCert)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	withoutClientCert, err := tlsconfig.ClientCredentials(config.ClientTLSConfig{
+		Enabled:    true,
+		CAFile:     certs.caFile,
+		ServerName: "localhost",
+	})
+	require.NoError(t, err)
+	err = callGetUser(t, address, withoutClientCert)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestSeparateModeHandlersTLS(t *testing.T) {
+	certs := writeTestCerts(t)
+	address := startTLSGateway(t, config.TLSConfig{
+		Enabled:  true,
+		CertFile: certs.serverCert,
+		KeyFile:  certs.serverKey,
+	})
+
+	getUser := func(handlersTLS config.ClientTLSConfig) int {
+		cfg := newTestConfig("separate")
+		cfg.External.HandlersGRPCURL = address
+		cfg.External.HandlersTLS = handlersTLS
+		gw := NewSimpleGateway(cfg)
+		t.Cleanup(func() { _ = gw.Stop() })
+		require.NoError(t, gw.Initialize())
+
+		code, _ := doRequest(t, gw.GetHTTPHandler(), "GET", "/api/v1/users/missing-user", "")
+		return code
+	}
+
+	// With handlers TLS the call reaches the user service
+	assert.Equal(t, fiber.StatusNotFound, getUser(config.ClientTLSConfig{
+		Enabled:    true,
+		CAFile:     certs.caFile,
+		ServerName: "localhost",
+	}))
+
+	// A plaintext connection fails the handshake
+	assert.Equal(t, fiber.StatusServiceUnavailable, getUser(config.ClientTLSConfig{}))
+}
\ No newline at end of file
@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/yhonda-ohishi/db-handler-server/internal/services"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+)
+
+// TransactionFeedPath is the WebSocket endpoint streaming new transactions
+const TransactionFeedPath = "/ws/transactions"
+
+var feedUpgrader = websocket.FastHTTPUpgrader{}
+
+// TransactionFeedRoutes pushes transactions created by the TransactionService
+// to WebSocket clients as JSON text messages
+type TransactionFeedRoutes struct {
+	service    *services.TransactionService
+	bufferSize int
+}
+
+// NewTransactionFeedRoutes creates a new transaction feed handler
+func NewTransactionFeedRoutes(service *services.TransactionService) *TransactionFeedRoutes {
+	return &TransactionFeedRoutes{
+		service:    service,
+		bufferSize: services.DefaultSubscriptionBuffer,
+	}
+}
+
+// RegisterRoutes registers the transaction feed endpoint
+func (r *TransactionFeedRoutes) RegisterRoutes(app *fiber.App) {
+	app.Get(TransactionFeedPath, r.streamTransactions)
+}
+
+func (r *TransactionFeedRoutes) streamTransactions(c *fiber.Ctx) error {
+	if r.service == nil {
+		return serviceUnavailable(c)
+	}
+	if !websocket.FastHTTPIsWebSocketUpgrade(c.Context()) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	// Subscribe before upgrading so transactions created once the client sees
+	// the handshake complete are not missed
+	updates, unsubscribe := r.service.Subscribe(r.bufferSize)
+	err := feedUpgrader.Upgrade(c.Context(), func(conn *websocket.Conn) {
+		defer unsubscribe()
+		defer conn.Close()
+		streamFeed(conn, updates)
+	})
+	if err != nil {
+		// The upgrader has already written the error response
+		unsubscribe()
+	}
+	return nil
+}
+
+// streamFeed writes updates to conn until the client disconnects
+func streamFeed(conn *websocket.Conn, updates <-chan *pb.Transaction) {
+	// Clients don't send anything; reading detects the close
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case transaction, ok := <-updates:
+			if !ok {
+				return
+			}
+			data, err := restMarshalOptions.Marshal(transaction)
+			if err != nil {
+				continue
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		}
+	}
+}
\ No newline at end of file
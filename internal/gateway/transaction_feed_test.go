@@ -0,0 +1,54 @@
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+)
+
+func TestTransactionFeedStreamsCreatedTransactions(t *testing.T) {
+	gw := NewSimpleGateway(newTestConfig("single"))
+	t.Cleanup(func() { _ = gw.Stop() })
+	require.NoError(t, gw.Initialize())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = gw.GetHTTPHandler().Listener(listener) }()
+
+	conn, resp, err := websocket.DefaultDialer.Dial("ws://"+listener.Addr().String()+TransactionFeedPath, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	resp.Body.Close()
+
+	now := time.Now()
+	created, err := gw.serviceRegistry.TransactionService.CreateTransaction(context.Background(),
+		"card-feed", "gate-001", "gate-002", now.Add(-time.Hour), now, 12.5, 800)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	messageType, data, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, websocket.TextMessage, messageType)
+
+	var received pb.Transaction
+	require.NoError(t, restUnmarshalOptions.Unmarshal(data, &received))
+	assert.Equal(t, created.Id, received.Id)
+	assert.Equal(t, "card-feed", received.CardId)
+	assert.EqualValues(t, 800, received.TollAmount)
+}
+
+func TestTransactionFeedRequiresUpgrade(t *testing.T) {
+	app := newInitializedGateway(t)
+
+	resp, err := app.Test(httptest.NewRequest("GET", TransactionFeedPath, nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, 426, resp.StatusCode)
+}
\ No newline at end of file
@@ -0,0 +1,135 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultCheckTimeout bounds a single dependency check
+const DefaultCheckTimeout = 2 * time.Second
+
+// ProbeFunc performs a lightweight RPC against a connection
+type ProbeFunc func(ctx context.Context, conn *grpc.ClientConn) error
+
+// GRPCConnChecker checks a downstream gRPC dependency through its client connection
+type GRPCConnChecker struct {
+	name    string
+	conn    *grpc.ClientConn
+	probe   ProbeFunc
+	timeout time.Duration
+}
+
+// NewGRPCConnChecker creates a checker that reports the connection state of conn
+func NewGRPCConnChecker(name string, conn *grpc.ClientConn) *GRPCConnChecker {
+	return &GRPCConnChecker{
+		name:    name,
+		conn:    conn,
+		timeout: DefaultCheckTimeout,
+	}
+}
+
+// WithProbe adds a lightweight RPC that must succeed once the connection is ready
+func (g *GRPCConnChecker) WithProbe(probe ProbeFunc) *GRPCConnChecker {
+	g.probe = probe
+	return g
+}
+
+// WithTimeout sets how long a check may wait for the connection and probe
+func (g *GRPCConnChecker) WithTimeout(timeout time.Duration) *GRPCConnChecker {
+	if timeout > 0 {
+		g.timeout = timeout
+	}
+	return g
+}
+
+func (g *GRPCConnChecker) Name() string {
+	return g.name
+}
+
+func (g *GRPCConnChecker) Check(ctx context.Context) error {
+	if g.conn == nil {
+		return fmt.Errorf("no gRPC connection available")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	if err := waitForReady(ctx, g.conn); err != nil {
+		return err
+	}
+
+	if g.probe != nil {
+		if err := g.probe(ctx, g.conn); err != nil {
+			return fmt.Errorf("probe failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// waitForReady waits until the connection is ready, failing fast on
+// transient failure or shutdown
+func waitForReady(ctx context.Context, conn *grpc.ClientConn) error {
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("connection state is %s", state)
+		case connectivity.Idle:
+			conn.Connect()
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("connection not ready: state is %s", state)
+		}
+	}
+}
+
+// HealthCheckProbe calls the standard gRPC health service for service. Servers
+// that don't implement the health service are considered reachable.
+func HealthCheckProbe(service string) ProbeFunc {
+	return func(ctx context.Context, conn *grpc.ClientConn) error {
+		resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{
+			Service: service,
+		})
+		if err != nil {
+			if status.Code(err) == codes.Unimplemented {
+				return nil
+			}
+			return err
+		}
+
+		if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+			return fmt.Errorf("service status is %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// CheckerFunc adapts a function to the HealthChecker interface
+type CheckerFunc struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// NewCheckerFunc creates a HealthChecker from a check function
+func NewCheckerFunc(name string, check func(ctx context.Context) error) *CheckerFunc {
+	return &CheckerFunc{name: name, check: check}
+}
+
+func (f *CheckerFunc) Name() string {
+	return f.name
+}
+
+func (f *CheckerFunc) Check(ctx context.Context) error {
+	return f.check(ctx)
+}
\ No newline at end of file
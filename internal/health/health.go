@@ -2,6 +2,7 @@ package health
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -20,6 +21,7 @@ type ComponentHealth struct {
 	Name    string    `json:"name"`
 	Status  Status    `json:"status"`
 	Message string    `json:"message,omitempty"`
+	Critical bool     `json:"critical"`
 	LastCheck time.Time `json:"last_check"`
 }
 
@@ -31,60 +33,142 @@ type HealthChecker interface {
 type Service struct {
 	mu       sync.RWMutex
 	checkers map[string]HealthChecker
+	critical map[string]bool
 	status   map[string]*ComponentHealth
+	observer func(components []ComponentHealth)
 }
 
 func NewService() *Service {
 	return &Service{
 		checkers: make(map[string]HealthChecker),
+		critical: make(map[string]bool),
 		status:   make(map[string]*ComponentHealth),
 	}
 }
 
+// RegisterChecker registers a critical dependency; when its check fails the
+// service is reported unhealthy
 func (s *Service) RegisterChecker(name string, checker HealthChecker) {
+	s.register(name, checker, true)
+}
+
+// RegisterOptionalChecker registers a non-critical dependency; when its check
+// fails the service is reported degraded but still serves traffic
+func (s *Service) RegisterOptionalChecker(name string, checker HealthChecker) {
+	s.register(name, checker, false)
+}
+
+// SetObserver calls observer with the per-dependency results every time the
+// checks run, e.g. to export them as metrics
+func (s *Service) SetObserver(observer func(components []ComponentHealth)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observer = observer
+}
+
+func (s *Service) register(name string, checker HealthChecker, critical bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.checkers[name] = checker
+	s.critical[name] = critical
 	s.status[name] = &ComponentHealth{
-		Name:   name,
-		Status: StatusHealthy,
+		Name:     name,
+		Status:   StatusHealthy,
+		Critical: critical,
 		LastCheck: time.Now(),
 	}
 }
 
-func (s *Service) LivenessHandler(c *fiber.Ctx) error {
-	// Simple liveness check - just return OK if the service is running
-	return c.JSON(fiber.Map{
-		"status": "alive",
-		"timestamp": time.Now().Unix(),
-	})
-}
+// CheckAll runs every registered checker and returns the aggregated status
+// along with the per-dependency results, sorted by name
+func (s *Service) CheckAll(ctx context.Context) (Status, []ComponentHealth) {
+	s.mu.RLock()
+	checkers := make(map[string]HealthChecker, len(s.checkers))
+	for name, checker := range s.checkers {
+		checkers[name] = checker
+	}
+	critical := make(map[string]bool, len(s.critical))
+	for name, isCritical := range s.critical {
+		critical[name] = isCritical
+	}
+	s.mu.RUnlock()
 
-func (s *Service) ReadinessHandler(c *fiber.Ctx) error {
-	ctx := c.Context()
 	overallStatus := StatusHealthy
-	components := make([]ComponentHealth, 0)
+	components := make([]ComponentHealth, 0, len(checkers))
 
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	for name, checker := range s.checkers {
+	for name, checker := range checkers {
 		health := ComponentHealth{
 			Name:      name,
 			Status:    StatusHealthy,
+			Critical:  critical[name],
 			LastCheck: time.Now(),
 		}
 
 		if err := checker.Check(ctx); err != nil {
-			health.Status = StatusUnhealthy
 			health.Message = err.Error()
-			overallStatus = StatusUnhealthy
+			if health.Critical {
+				health.Status = StatusUnhealthy
+				overallStatus = StatusUnhealthy
+			} else {
+				health.Status = StatusDegraded
+				if overallStatus == StatusHealthy {
+					overallStatus = StatusDegraded
+				}
+			}
 		}
 
-		s.status[name] = &health
 		components = append(components, health)
 	}
 
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+
+	s.mu.Lock()
+	for i := range components {
+		health := components[i]
+		s.status[health.Name] = &health
+	}
+	observer := s.observer
+	s.mu.Unlock()
+
+	if observer != nil {
+		observer(components)
+	}
+
+	return overallStatus, components
+}
+
+// HealthHandler reports the aggregated health of all dependencies, responding
+// with 503 when a critical dependency is down
+func (s *Service) HealthHandler(c *fiber.Ctx) error {
+	overallStatus, components := s.CheckAll(c.Context())
+
+	statusCode := fiber.StatusOK
+	if overallStatus == StatusUnhealthy {
+		statusCode = fiber.StatusServiceUnavailable
+	}
+
+	return c.Status(statusCode).JSON(fiber.Map{
+		"status":     overallStatus,
+		"components": components,
+		"timestamp":  time.Now().Unix(),
+		"version":    getVersion(),
+		"uptime":     getUptime(),
+	})
+}
+
+func (s *Service) LivenessHandler(c *fiber.Ctx) error {
+	// Simple liveness check - just return OK if the service is running
+	return c.JSON(fiber.Map{
+		"status": "alive",
+		"timestamp": time.Now().Unix(),
+	})
+}
+
+func (s *Service) ReadinessHandler(c *fiber.Ctx) error {
+	overallStatus, components := s.CheckAll(c.Context())
+
 	statusCode := fiber.StatusOK
 	if overallStatus == StatusUnhealthy {
 		statusCode = fiber.StatusServiceUnavailable
@@ -122,9 +206,12 @@ func (s *Service) StatusHandler(c *fiber.Ctx) error {
 	})
 }
 
+// StartBackgroundChecks runs the checks right away and then every interval
+// until ctx is done
 func (s *Service) StartBackgroundChecks(ctx context.Context, interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	go func() {
+		s.runChecks(ctx)
 		for {
 			select {
 			case <-ctx.Done():
@@ -138,23 +225,7 @@ func (s *Service) StartBackgroundChecks(ctx context.Context, interval time.Durat
 }
 
 func (s *Service) runChecks(ctx context.Context) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	for name, checker := range s.checkers {
-		health := &ComponentHealth{
-			Name:      name,
-			Status:    StatusHealthy,
-			LastCheck: time.Now(),
-		}
-
-		if err := checker.Check(ctx); err != nil {
-			health.Status = StatusUnhealthy
-			health.Message = err.Error()
-		}
-
-		s.status[name] = health
-	}
+	s.CheckAll(ctx)
 }
 
 var startTime = time.Now()
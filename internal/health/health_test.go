@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startBackend serves the standard health service on bufconn and returns a client connection to it
+func startBackend(t *testing.T, servingStatus grpc_health_v1.HealthCheckResponse_ServingStatus) (*grpc.ClientConn, *grpc.Server) {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	healthServer := grpchealth.NewServer()
+	healthServer.SetServingStatus("", servingStatus)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn, server
+}
+
+func getHealth(t *testing.T, service *Service) (int, map[string]interface{}) {
+	t.Helper()
+
+	app := fiber.New()
+	app.Get("/health", service.HealthHandler)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/health", nil), 5000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestHealthHandlerHealthyBackend(t *testing.T) {
+	conn, _ := startBackend(t, grpc_health_v1.HealthCheckResponse_SERVING)
+
+	service := NewService()
+	service.RegisterChecker("db_service", NewGRPCConnChecker("db_service", conn).WithProbe(HealthCheckProbe("")))
+
+	code, body := getHealth(t, service)
+	assert.Equal(t, fiber.StatusOK, code)
+	assert.Equal(t, string(StatusHealthy), body["status"])
+
+	components := body["components"].([]interface{})
+	require.Len(t, components, 1)
+	component := components[0].(map[string]interface{})
+	assert.Equal(t, "db_service", component["name"])
+	assert.Equal(t, string(StatusHealthy), component["status"])
+	assert.Equal(t, true, component["critical"])
+}
+
+func TestHealthHandlerUnavailableBackend(t *testing.T) {
+	conn, server := startBackend(t, grpc_health_v1.HealthCheckResponse_SERVING)
+	server.Stop()
+
+	service := NewService()
+	service.RegisterChecker("db_service", NewGRPCConnChecker("db_service", conn).WithTimeout(500*time.Millisecond))
+
+	code, body := getHealth(t, service)
+	assert.Equal(t, fiber.StatusServiceUnavailable, code)
+	assert.Equal(t, string(StatusUnhealthy), body["status"])
+
+	component := body["components"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, string(StatusUnhealthy), component["status"])
+	assert.NotEmpty(t, component["message"])
+}
+
+func TestHealthHandlerNotServingBackend(t *testing.T) {
+	conn, _ := startBackend(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	service := NewService()
+	service.RegisterChecker("db_service", NewGRPCConnChecker("db_service", conn).WithProbe(HealthCheckProbe("")))
+
+	code, body := getHealth(t, service)
+	assert.Equal(t, fiber.StatusServiceUnavailable, code)
+	assert.Equal(t, string(StatusUnhealthy), body["status"])
+}
+
+func TestHealthHandlerDegradedOptionalDependency(t *testing.T) {
+	service := NewService()
+	service.RegisterChecker("grpc_server", NewCheckerFunc("grpc_server", func(ctx context.Context) error {
+		return nil
+	}))
+	service.RegisterOptionalChecker("cache", NewGRPCConnChecker("cache", nil))
+
+	code, body := getHealth(t, service)
+	assert.Equal(t, fiber.StatusOK, code)
+	assert.Equal(t, string(StatusDegraded), body["status"])
+
+	components := body["components"].([]interface{})
+	require.Len(t, components, 2)
+	cache := components[0].(map[string]interface{})
+	assert.Equal(t, "cache", cache["name"])
+	assert.Equal(t, string(StatusDegraded), cache["status"])
+	assert.Equal(t, false, cache["critical"])
+}
\ No newline at end of file
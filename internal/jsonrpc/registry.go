@@ -0,0 +1,91 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeServerError    = -32000
+)
+
+// Error represents a JSON-RPC 2.0 error
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// NewError creates a JSON-RPC error
+func NewError(code int, message string, data interface{}) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+		Data:    data,
+	}
+}
+
+// HandlerFunc handles a single JSON-RPC method call
+type HandlerFunc func(ctx context.Context, params json.RawMessage) (interface{}, *Error)
+
+// Registry maps JSON-RPC method names to their handlers
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[string]HandlerFunc
+}
+
+// NewRegistry creates an empty method registry
+func NewRegistry() *Registry {
+	return &Registry{
+		handlers: make(map[string]HandlerFunc),
+	}
+}
+
+// Register adds a handler for method, replacing any existing handler
+func (r *Registry) Register(method string, handler HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[method] = handler
+}
+
+// Has reports whether a handler is registered for method
+func (r *Registry) Has(method string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.handlers[method]
+	return ok
+}
+
+// Methods returns the registered method names in sorted order
+func (r *Registry) Methods() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	methods := make([]string, 0, len(r.handlers))
+	for method := range r.handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Dispatch invokes the handler registered for method, returning a
+// method-not-found error when there is none
+func (r *Registry) Dispatch(ctx context.Context, method string, params json.RawMessage) (interface{}, *Error) {
+	r.mu.RLock()
+	handler, ok := r.handlers[method]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, NewError(CodeMethodNotFound, "Method not found", method)
+	}
+	return handler(ctx, params)
+}
\ No newline at end of file
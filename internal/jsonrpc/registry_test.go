@@ -0,0 +1,71 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRegistryDispatch(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("math.add", func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		var args []int
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, NewError(CodeInvalidParams, "Invalid params", err.Error())
+		}
+		sum := 0
+		for _, arg := range args {
+			sum += arg
+		}
+		return sum, nil
+	})
+
+	result, rpcErr := registry.Dispatch(context.Background(), "math.add", json.RawMessage(`[1, 2, 3]`))
+	if rpcErr != nil {
+		t.Fatalf("unexpected error: %+v", rpcErr)
+	}
+	if result != 6 {
+		t.Errorf("expected 6, got %v", result)
+	}
+
+	_, rpcErr = registry.Dispatch(context.Background(), "math.add", json.RawMessage(`"oops"`))
+	if rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Errorf("expected invalid params error, got %+v", rpcErr)
+	}
+}
+
+func TestRegistryMethodNotFound(t *testing.T) {
+	registry := NewRegistry()
+
+	_, rpcErr := registry.Dispatch(context.Background(), "nonexistent.method", nil)
+	if rpcErr == nil {
+		t.Fatal("expected an error for an unknown method")
+	}
+	if rpcErr.Code != CodeMethodNotFound {
+		t.Errorf("expected code %d, got %d", CodeMethodNotFound, rpcErr.Code)
+	}
+	if rpcErr.Data != "nonexistent.method" {
+		t.Errorf("expected the method name as data, got %v", rpcErr.Data)
+	}
+}
+
+func TestRegistryReplaceAndMethods(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("b.echo", func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		return "first", nil
+	})
+	registry.Register("a.echo", func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		return "a", nil
+	})
+	registry.Register("b.echo", func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		return "second", nil
+	})
+
+	if result, _ := registry.Dispatch(context.Background(), "b.echo", nil); result != "second" {
+		t.Errorf("expected the later registration to win, got %v", result)
+	}
+	if methods := registry.Methods(); !reflect.DeepEqual(methods, []string{"a.echo", "b.echo"}) {
+		t.Errorf("unexpected methods: %v", methods)
+	}
+}
\ No newline at end of file
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -94,10 +95,21 @@ func GetConfigFromEnv() Config {
 	}
 
 	return Config{
-		Level:  level,
-		Format: format,
-		Output: nil,
+		Level:            level,
+		Format:           format,
+		Output:           nil,
+		SampleFirst:      getUint32Env("LOG_SAMPLE_FIRST"),
+		SampleThereafter: getUint32Env("LOG_SAMPLE_THEREAFTER"),
+	}
+}
+
+// getUint32Env parses an unsigned integer environment variable, returning 0 when unset or invalid
+func getUint32Env(key string) uint32 {
+	value, err := strconv.ParseUint(os.Getenv(key), 10, 32)
+	if err != nil {
+		return 0
 	}
+	return uint32(value)
 }
 
 // IsProductionEnv checks if we're running in production
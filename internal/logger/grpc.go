@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDMetadataKey is the gRPC metadata key carrying the request ID
+const RequestIDMetadataKey = "x-request-id"
+
+// UserIDMetadataKey is the gRPC metadata key carrying the user ID
+const UserIDMetadataKey = "x-user-id"
+
+// UnaryClientMetadataInterceptor returns a gRPC client interceptor that sends
+// the request ID and user ID from the caller's context as outgoing metadata,
+// so service-layer logs can be correlated with the originating HTTP request
+func UnaryClientMetadataInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(OutgoingContextWithRequestMetadata(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerMetadataInterceptor returns a gRPC server interceptor that reads
+// the request ID and user ID from incoming metadata into the service context
+func UnaryServerMetadataInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if requestID := requestIDFromMetadata(ctx); requestID != "" {
+			ctx = ContextWithRequestID(ctx, requestID)
+		}
+		if userID := metadataValue(ctx, UserIDMetadataKey); userID != "" {
+			ctx = ContextWithUserID(ctx, userID)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// OutgoingContextWithRequestMetadata appends the request ID and user ID found
+// in ctx to its outgoing gRPC metadata, unless the metadata already has them
+func OutgoingContextWithRequestMetadata(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if requestID, ok := GetRequestIDFromContext(ctx); ok && requestID != "" && len(md.Get(RequestIDMetadataKey)) == 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, requestID)
+	}
+	if userID, ok := GetUserIDFromContext(ctx); ok && userID != "" && len(md.Get(UserIDMetadataKey)) == 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, UserIDMetadataKey, userID)
+	}
+	return ctx
+}
+
+// UnaryServerLoggingInterceptor returns a gRPC unary interceptor for request logging
+func UnaryServerLoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		// Use the request ID already in the context, from incoming metadata,
+		// or generate one
+		if requestID, ok := GetRequestIDFromContext(ctx); !ok || requestID == "" {
+			requestID = requestIDFromMetadata(ctx)
+			if requestID == "" {
+				requestID = NewRequestID()
+			}
+			ctx = ContextWithRequestID(ctx, requestID)
+		}
+
+		resp, err := handler(ctx, req)
+
+		// Log call completion
+		code := status.Code(err)
+		logger := WithContext(ctx).WithFields(map[string]interface{}{
+			"grpc.method": info.FullMethod,
+			"grpc.code":   code.String(),
+			"duration_ms": time.Since(start).Milliseconds(),
+		})
+
+		switch code {
+		case codes.OK:
+			logger.Info("gRPC call completed")
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+			logger.WithError(err).Error("gRPC call completed with server error")
+		default:
+			logger.WithError(err).Warn("gRPC call completed with client error")
+		}
+
+		return resp, err
+	}
+}
+
+// requestIDFromMetadata extracts the request ID from incoming gRPC metadata
+func requestIDFromMetadata(ctx context.Context) string {
+	return metadataValue(ctx, RequestIDMetadataKey)
+}
+
+// metadataValue returns the first incoming gRPC metadata value for key
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
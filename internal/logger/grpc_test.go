@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// stubUserService stands in for the real services package, which imports
+// logger and so cannot be used from this package's tests.
+type stubUserService struct {
+	pb.UnimplementedUserServiceServer
+}
+
+func (stubUserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	return nil, status.Errorf(codes.NotFound, "user not found: %s", req.Id)
+}
+
+func (stubUserService) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	return &pb.ListUsersResponse{}, nil
+}
+
+func TestUnaryServerLoggingInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	config := Config{
+		Level:  "info",
+		Format: "json",
+		Output: &buf,
+	}
+
+	err := Initialize(config)
+	if err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+
+	// Start a user service on bufconn with the logging interceptor
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(UnaryServerLoggingInterceptor()))
+	pb.RegisterUserServiceServer(grpcServer, stubUserService{})
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to get bufconn connection: %v", err)
+	}
+	defer conn.Close()
+
+	userClient := pb.NewUserServiceClient(conn)
+
+	// Call with a request ID in metadata
+	ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDMetadataKey, "grpc-request-1")
+	_, _ = userClient.GetUser(ctx, &pb.GetUserRequest{Id: "missing-user"})
+
+	var logEntry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("failed to parse log entry: %v", err)
+	}
+
+	if logEntry["grpc.method"] != "/etc_meisai.v1.UserService/GetUser" {
+		t.Errorf("expected grpc.method '/etc_meisai.v1.UserService/GetUser', got %v", logEntry["grpc.method"])
+	}
+
+	if logEntry["grpc.code"] != "NotFound" {
+		t.Errorf("expected grpc.code 'NotFound', got %v", logEntry["grpc.code"])
+	}
+
+	if logEntry["request_id"] != "grpc-request-1" {
+		t.Errorf("expected request_id 'grpc-request-1', got %v", logEntry["request_id"])
+	}
+
+	if _, exists := logEntry["duration_ms"]; !exists {
+		t.Error("expected duration_ms field")
+	}
+
+	// Without metadata a request ID is generated
+	buf.Reset()
+	_, _ = userClient.ListUsers(context.Background(), &pb.ListUsersRequest{})
+
+	logEntry = map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("failed to parse log entry: %v", err)
+	}
+
+	if logEntry["level"] != "info" {
+		t.Errorf("expected level 'info', got %v", logEntry["level"])
+	}
+
+	if requestID, _ := logEntry["request_id"].(string); strings.TrimSpace(requestID) == "" {
+		t.Error("expected a generated request_id")
+	}
+}
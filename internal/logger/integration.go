@@ -16,6 +16,11 @@ func InitializeFromAppConfig(cfg *config.Config) error {
 		Level:  cfg.Logging.Level,
 		Format: cfg.Logging.Format,
 		Output: nil, // Use default (stdout)
+		// Optional rotating file output
+		FilePath:   cfg.Logging.FilePath,
+		MaxSizeMB:  cfg.Logging.MaxSizeMB,
+		MaxBackups: cfg.Logging.MaxBackups,
+		MaxAgeDays: cfg.Logging.MaxAgeDays,
 	}
 
 	// Validate configuration
@@ -7,11 +7,15 @@ import (
 	"os"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // ContextKey is used for context-based values
@@ -22,11 +26,15 @@ const (
 	RequestIDKey ContextKey = "request_id"
 	// UserIDKey is the context key for user ID
 	UserIDKey ContextKey = "user_id"
+	// AuthenticatedUserIDKey is the context key for a user ID verified by an
+	// authentication step; unlike UserIDKey it is never taken from a header
+	AuthenticatedUserIDKey ContextKey = "authenticated_user_id"
 )
 
 // Logger wraps zerolog.Logger with additional functionality
 type Logger struct {
-	logger zerolog.Logger
+	logger       zerolog.Logger
+	redactFields map[string]struct{}
 }
 
 // Config holds logger configuration
@@ -34,11 +42,41 @@ type Config struct {
 	Level  string // debug, info, warn, error
 	Format string // json, console
 	Output io.Writer
-}
+	// RedactFields lists field keys whose values are masked before being
+	// written. When nil, DefaultRedactFields is used; an empty slice
+	// disables redaction.
+	RedactFields []string
+	// SampleFirst is the number of debug/info messages logged per
+	// SamplePeriod before sampling starts. Zero disables sampling.
+	SampleFirst uint32
+	// SampleThereafter logs 1 in N debug/info messages once SampleFirst
+	// is exceeded within the period (default DefaultSampleThereafter)
+	SampleThereafter uint32
+	// SamplePeriod is the sampling window (default 1s)
+	SamplePeriod time.Duration
+	// FilePath enables writing logs to a size-rotated file in addition to
+	// Output; the file is always JSON, even with the console format
+	FilePath string
+	// MaxSizeMB is the maximum size of the log file before it is rotated (default 100)
+	MaxSizeMB int
+	// MaxBackups is the maximum number of rotated files to keep (0 keeps all)
+	MaxBackups int
+	// MaxAgeDays is the maximum number of days to keep rotated files (0 keeps all)
+	MaxAgeDays int
+}
+
+// DefaultSampleThereafter is the 1-in-N rate of sampled messages past the
+// burst when Config.SampleThereafter is zero
+const DefaultSampleThereafter = 100
+
+// DefaultRedactFields are the field keys masked when Config.RedactFields is nil
+var DefaultRedactFields = []string{"card_number", "email", "phone_number"}
 
 var (
 	// Global logger instance
 	globalLogger *Logger
+	// Rotating file writer of the global logger, if any
+	globalFile *lumberjack.Logger
 )
 
 // Initialize sets up the global logger with the provided configuration
@@ -57,22 +95,49 @@ func Initialize(config Config) error {
 		output = config.Output
 	}
 
-	// Configure format
-	var logger zerolog.Logger
-	switch strings.ToLower(config.Format) {
-	case "console":
-		logger = zerolog.New(zerolog.ConsoleWriter{
+	// Close the file of a previous initialization
+	if globalFile != nil {
+		_ = globalFile.Close()
+		globalFile = nil
+	}
+
+	// Configure format; anything other than console is JSON
+	if strings.ToLower(config.Format) == "console" {
+		output = zerolog.ConsoleWriter{
 			Out:        output,
 			TimeFormat: time.RFC3339,
 			NoColor:    false,
-		}).With().Timestamp().Logger()
-	case "json":
-		fallthrough
-	default:
-		logger = zerolog.New(output).With().Timestamp().Logger()
+		}
+	}
+
+	// Add rotating file output, always as JSON so the file has no color codes
+	if config.FilePath != "" {
+		globalFile = &lumberjack.Logger{
+			Filename:   config.FilePath,
+			MaxSize:    config.MaxSizeMB,
+			MaxBackups: config.MaxBackups,
+			MaxAge:     config.MaxAgeDays,
+		}
+		output = zerolog.MultiLevelWriter(output, globalFile)
 	}
 
-	globalLogger = &Logger{logger: logger}
+	logger := zerolog.New(output).With().Timestamp().Logger()
+
+	// Configure sampling (warn and above are never sampled)
+	if sampler := newSampler(config); sampler != nil {
+		logger = logger.Sample(sampler)
+	}
+
+	redactFields := config.RedactFields
+	if redactFields == nil {
+		redactFields = DefaultRedactFields
+	}
+	redactSet := make(map[string]struct{}, len(redactFields))
+	for _, key := range redactFields {
+		redactSet[strings.ToLower(key)] = struct{}{}
+	}
+
+	globalLogger = &Logger{logger: logger, redactFields: redactSet}
 
 	// Set zerolog global logger
 	log.Logger = logger
@@ -80,6 +145,31 @@ func Initialize(config Config) error {
 	return nil
 }
 
+// Close closes the rotating log file, if one is configured
+func Close() error {
+	if globalFile == nil {
+		return nil
+	}
+	err := globalFile.Close()
+	globalFile = nil
+	return err
+}
+
+// SetLevel changes the global log level without reinitializing the logger
+func SetLevel(level string) error {
+	parsed, err := parseLogLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+	zerolog.SetGlobalLevel(parsed)
+	return nil
+}
+
+// GetLevel returns the current global log level
+func GetLevel() string {
+	return zerolog.GlobalLevel().String()
+}
+
 // GetLogger returns the global logger instance
 func GetLogger() *Logger {
 	if globalLogger == nil {
@@ -92,6 +182,36 @@ func GetLogger() *Logger {
 	return globalLogger
 }
 
+// newSampler builds a level sampler for debug and info messages from the config
+func newSampler(config Config) zerolog.Sampler {
+	if config.SampleFirst == 0 && config.SampleThereafter == 0 {
+		return nil
+	}
+
+	period := config.SamplePeriod
+	if period <= 0 {
+		period = time.Second
+	}
+
+	// Past the burst keep 1 in N messages; without a next sampler the
+	// burst sampler would drop them all
+	thereafter := config.SampleThereafter
+	if thereafter == 0 {
+		thereafter = DefaultSampleThereafter
+	}
+
+	sampler := &zerolog.BurstSampler{
+		Burst:       config.SampleFirst,
+		Period:      period,
+		NextSampler: &zerolog.BasicSampler{N: thereafter},
+	}
+
+	return zerolog.LevelSampler{
+		DebugSampler: sampler,
+		InfoSampler:  sampler,
+	}
+}
+
 // parseLogLevel converts string level to zerolog.Level
 func parseLogLevel(level string) (zerolog.Level, error) {
 	switch strings.ToLower(level) {
@@ -128,26 +248,69 @@ func (l *Logger) WithContext(ctx context.Context) *Logger {
 		logger = logger.With().Str("user_id", userID.(string)).Logger()
 	}
 
-	return &Logger{logger: logger}
+	// Add OpenTelemetry trace and span IDs if a span is present
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		logger = logger.With().
+			Str("trace_id", spanCtx.TraceID().String()).
+			Str("span_id", spanCtx.SpanID().String()).
+			Logger()
+	}
+
+	return &Logger{logger: logger, redactFields: l.redactFields}
 }
 
 // WithFields returns a new logger with additional fields
 func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
 	event := l.logger.With()
 	for key, value := range fields {
-		event = event.Interface(key, value)
+		event = event.Interface(key, l.redact(key, value))
 	}
-	return &Logger{logger: event.Logger()}
+	return &Logger{logger: event.Logger(), redactFields: l.redactFields}
 }
 
 // WithField returns a new logger with an additional field
 func (l *Logger) WithField(key string, value interface{}) *Logger {
-	return &Logger{logger: l.logger.With().Interface(key, value).Logger()}
+	return &Logger{logger: l.logger.With().Interface(key, l.redact(key, value)).Logger(), redactFields: l.redactFields}
 }
 
 // WithError returns a new logger with error field
 func (l *Logger) WithError(err error) *Logger {
-	return &Logger{logger: l.logger.With().Err(err).Logger()}
+	return &Logger{logger: l.logger.With().Err(err).Logger(), redactFields: l.redactFields}
+}
+
+// redact masks the value if its key is in the redaction set
+func (l *Logger) redact(key string, value interface{}) interface{} {
+	if value == nil || len(l.redactFields) == 0 {
+		return value
+	}
+	if _, ok := l.redactFields[strings.ToLower(key)]; !ok {
+		return value
+	}
+	return MaskValue(fmt.Sprint(value))
+}
+
+// MaskValue replaces every letter and digit except the last four with '*',
+// keeping separators so the shape of the value stays recognizable
+// (e.g. "4111-1111-1111-1234" becomes "****-****-****-1234").
+func MaskValue(value string) string {
+	runes := []rune(value)
+	keep := 0
+	for i := len(runes) - 1; i >= 0; i-- {
+		if !isMaskable(runes[i]) {
+			continue
+		}
+		if keep < 4 {
+			keep++
+			continue
+		}
+		runes[i] = '*'
+	}
+	return string(runes)
+}
+
+// isMaskable reports whether r is a letter or digit
+func isMaskable(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
 }
 
 // Debug logs a debug message
@@ -299,6 +462,13 @@ func ContextWithUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, UserIDKey, userID)
 }
 
+// ContextWithAuthenticatedUserID adds a verified user ID to the context. Only
+// call it after authenticating the caller. Nothing in the gateway calls it
+// yet; an authentication middleware must, for per-user rate limiting to work.
+func ContextWithAuthenticatedUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, AuthenticatedUserIDKey, userID)
+}
+
 // GetRequestIDFromContext extracts request ID from context
 func GetRequestIDFromContext(ctx context.Context) (string, bool) {
 	requestID, ok := ctx.Value(RequestIDKey).(string)
@@ -311,6 +481,12 @@ func GetUserIDFromContext(ctx context.Context) (string, bool) {
 	return userID, ok
 }
 
+// GetAuthenticatedUserIDFromContext extracts the verified user ID from context
+func GetAuthenticatedUserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(AuthenticatedUserIDKey).(string)
+	return userID, ok
+}
+
 // Specialized logging methods for common scenarios
 
 // LogRequest logs HTTP request details
@@ -398,6 +574,19 @@ func FiberRequestLogger() fiber.Handler {
 
 		// Add request ID to context
 		ctx := ContextWithRequestID(c.Context(), requestID)
+
+		// Pick up the trace from the W3C traceparent header when the
+		// context doesn't already carry a span
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			ctx = propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{
+				"traceparent": c.Get("traceparent"),
+				"tracestate":  c.Get("tracestate"),
+			})
+		}
+		if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+			c.Set("X-Trace-ID", spanCtx.TraceID().String())
+		}
+
 		c.SetUserContext(ctx)
 
 		// Continue with request
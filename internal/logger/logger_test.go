@@ -5,11 +5,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 func TestInitialize(t *testing.T) {
@@ -217,6 +222,140 @@ func TestWithFields(t *testing.T) {
 	}
 }
 
+func TestRedactFields(t *testing.T) {
+	var buf bytes.Buffer
+	config := Config{
+		Level:  "info",
+		Format: "json",
+		Output: &buf,
+	}
+
+	err := Initialize(config)
+	if err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+
+	GetLogger().WithFields(map[string]interface{}{
+		"card_number": "4111-1111-1111-1234",
+		"user":        "alice",
+	}).Info("payment processed")
+
+	var logEntry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("failed to parse log entry: %v", err)
+	}
+
+	if logEntry["card_number"] != "****-****-****-1234" {
+		t.Errorf("expected card_number '****-****-****-1234', got %v", logEntry["card_number"])
+	}
+
+	if logEntry["user"] != "alice" {
+		t.Errorf("expected user 'alice', got %v", logEntry["user"])
+	}
+
+	if strings.Contains(buf.String(), "4111-1111-1111-1234") {
+		t.Error("expected full card number to be redacted from output")
+	}
+
+	// Test WithField and specialized helpers
+	buf.Reset()
+	LogBusinessEvent(context.Background(), "user_created", map[string]interface{}{
+		"email": "alice@example.com",
+	})
+
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("failed to parse log entry: %v", err)
+	}
+
+	if logEntry["email"] != "*****@******e.com" {
+		t.Errorf("expected masked email, got %v", logEntry["email"])
+	}
+
+	// Test that an empty redaction set disables masking
+	buf.Reset()
+	config.RedactFields = []string{}
+	if err := Initialize(config); err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+
+	GetLogger().WithField("card_number", "4111-1111-1111-1234").Info("payment processed")
+
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("failed to parse log entry: %v", err)
+	}
+
+	if logEntry["card_number"] != "4111-1111-1111-1234" {
+		t.Errorf("expected unmasked card_number, got %v", logEntry["card_number"])
+	}
+}
+
+func TestLogSampling(t *testing.T) {
+	var buf bytes.Buffer
+	config := Config{
+		Level:            "info",
+		Format:           "json",
+		Output:           &buf,
+		SampleFirst:      10,
+		SampleThereafter: 100,
+	}
+
+	err := Initialize(config)
+	if err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+
+	logger := GetLogger()
+	for i := 0; i < 1000; i++ {
+		logger.Info("sampled message")
+	}
+
+	infoCount := strings.Count(buf.String(), "\n")
+	if infoCount >= 100 {
+		t.Errorf("expected sampled info logs to be far below 1000, got %d", infoCount)
+	}
+	if infoCount < 10 {
+		t.Errorf("expected at least the first 10 info logs, got %d", infoCount)
+	}
+
+	// Warn and error logs are never sampled
+	buf.Reset()
+	for i := 0; i < 100; i++ {
+		logger.Warn("warn message")
+		logger.Error("error message")
+	}
+
+	if count := strings.Count(buf.String(), "\n"); count != 200 {
+		t.Errorf("expected 200 warn/error logs, got %d", count)
+	}
+}
+
+func TestLogSamplingKeepsMessagesPastBurst(t *testing.T) {
+	var buf bytes.Buffer
+	config := Config{
+		Level:        "info",
+		Format:       "json",
+		Output:       &buf,
+		SampleFirst:  10,
+		SamplePeriod: time.Hour,
+	}
+
+	err := Initialize(config)
+	if err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+
+	logger := GetLogger()
+	for i := 0; i < 1000; i++ {
+		logger.Info("sampled message")
+	}
+
+	// The burst plus 1 in DefaultSampleThereafter of the rest
+	want := 10 + 990/DefaultSampleThereafter
+	if count := strings.Count(buf.String(), "\n"); count < want || count > want+1 {
+		t.Errorf("expected about %d info logs, got %d", want, count)
+	}
+}
+
 func TestWithError(t *testing.T) {
 	var buf bytes.Buffer
 	config := Config{
@@ -427,4 +566,185 @@ func TestContextHelpers(t *testing.T) {
 	if ok {
 		t.Error("expected no user ID in empty context")
 	}
-}
\ No newline at end of file
+}
+
+func TestTraceContextLogging(t *testing.T) {
+	var buf bytes.Buffer
+	config := Config{
+		Level:  "info",
+		Format: "json",
+		Output: &buf,
+	}
+
+	err := Initialize(config)
+	if err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+
+	tracer := sdktrace.NewTracerProvider().Tracer("logger-test")
+	ctx, span := tracer.Start(context.Background(), "test-span")
+	defer span.End()
+
+	WithContext(ctx).Info("traced message")
+
+	var logEntry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("failed to parse log entry: %v", err)
+	}
+
+	if logEntry["trace_id"] != span.SpanContext().TraceID().String() {
+		t.Errorf("expected trace_id %s, got %v", span.SpanContext().TraceID(), logEntry["trace_id"])
+	}
+
+	if logEntry["span_id"] != span.SpanContext().SpanID().String() {
+		t.Errorf("expected span_id %s, got %v", span.SpanContext().SpanID(), logEntry["span_id"])
+	}
+
+	// Without a span no trace fields are added
+	buf.Reset()
+	WithContext(context.Background()).Info("untraced message")
+
+	logEntry = map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("failed to parse log entry: %v", err)
+	}
+
+	if _, exists := logEntry["trace_id"]; exists {
+		t.Error("expected no trace_id without a span")
+	}
+}
+
+func TestFiberRequestLoggerTraceHeader(t *testing.T) {
+	var buf bytes.Buffer
+	err := Initialize(Config{
+		Level:  "info",
+		Format: "json",
+		Output: &buf,
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+
+	app := fiber.New()
+	app.Use(FiberRequestLogger())
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to make test request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("X-Trace-ID"); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected X-Trace-ID from traceparent, got %q", got)
+	}
+
+	if !strings.Contains(buf.String(), `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`) {
+		t.Errorf("expected request log to include trace_id, got %s", buf.String())
+	}
+
+	// Non-traced requests don't get the header
+	resp, err = app.Test(httptest.NewRequest("GET", "/test", nil))
+	if err != nil {
+		t.Fatalf("failed to make test request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("X-Trace-ID"); got != "" {
+		t.Errorf("expected no X-Trace-ID header, got %q", got)
+	}
+}
+
+func TestRotatingFileOutput(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "app.log")
+
+	var buf bytes.Buffer
+	config := Config{
+		Level:      "info",
+		Format:     "json",
+		Output:     &buf,
+		FilePath:   logFile,
+		MaxSizeMB:  1,
+		MaxBackups: 3,
+	}
+
+	err := Initialize(config)
+	if err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+	defer Close()
+
+	// Write a little over 1MB to trigger a rotation
+	payload := strings.Repeat("x", 1024)
+	for i := 0; i < 1100; i++ {
+		GetLogger().WithField("payload", payload).Info("rotation test")
+	}
+
+	if err := Close(); err != nil {
+		t.Fatalf("failed to close log file: %v", err)
+	}
+
+	if _, err := os.Stat(logFile); err != nil {
+		t.Errorf("expected current log file to exist: %v", err)
+	}
+
+	backups, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	if err != nil {
+		t.Fatalf("failed to list backups: %v", err)
+	}
+	if len(backups) == 0 {
+		t.Error("expected a rotated backup file to be created")
+	}
+
+	// Stdout sink still receives every line
+	if count := strings.Count(buf.String(), "\n"); count != 1100 {
+		t.Errorf("expected 1100 lines in primary output, got %d", count)
+	}
+}
+
+func TestRotatingFileOutputIsJSONWithConsoleFormat(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+
+	var buf bytes.Buffer
+	err := Initialize(Config{
+		Level:    "info",
+		Format:   "console",
+		Output:   &buf,
+		FilePath: logFile,
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+	defer Close()
+
+	GetLogger().WithField("user_id", "user-1").Info("console and file")
+	if err := Close(); err != nil {
+		t.Fatalf("failed to close log file: %v", err)
+	}
+
+	// The console sink is formatted for terminals
+	if !strings.Contains(buf.String(), "console and file") || strings.HasPrefix(buf.String(), "{") {
+		t.Errorf("expected console formatted output, got %q", buf.String())
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if strings.Contains(string(data), "\x1b[") {
+		t.Errorf("expected no ANSI escape codes in the log file, got %q", data)
+	}
+
+	var logEntry map[string]interface{}
+	if err := json.Unmarshal(data, &logEntry); err != nil {
+		t.Fatalf("expected a JSON line in the log file: %v", err)
+	}
+	if logEntry["message"] != "console and file" || logEntry["user_id"] != "user-1" {
+		t.Errorf("unexpected file entry: %v", logEntry)
+	}
+}
@@ -0,0 +1,21 @@
+package metrics
+
+// SetDependencyUp records in dependency_up whether the named dependency
+// passed its last health check
+func (s *Service) SetDependencyUp(name string, up bool) {
+	s.dependencies.Store(name, struct{}{})
+	value := 0.0
+	if up {
+		value = 1
+	}
+	s.dependencyUp.WithLabelValues(name).Set(value)
+}
+
+// MarkDependenciesDown sets dependency_up to 0 for every dependency recorded
+// so far, e.g. while shutting down
+func (s *Service) MarkDependenciesDown() {
+	s.dependencies.Range(func(name, _ interface{}) bool {
+		s.dependencyUp.WithLabelValues(name.(string)).Set(0)
+		return true
+	})
+}
\ No newline at end of file
@@ -46,16 +46,13 @@ func ExampleUsage() {
 	app.Use(recover.New())
 	app.Use(cors.New())
 
-	// Add metrics middleware with custom configuration
+	// Add metrics middleware with custom configuration. IDs in paths are
+	// collapsed by the service's default path rules:
+	// /api/users/123 -> /api/users/:id
+	// /api/orders/456/items/789 -> /api/orders/:id/items/:id
 	metricsConfig := MiddlewareConfig{
 		Service:   metricsService,
 		SkipPaths: []string{"/health", "/metrics"},
-		PathNormalizer: func(path string) string {
-			// Example: normalize paths with IDs
-			// /api/users/123 -> /api/users/:id
-			// /api/orders/456/items/789 -> /api/orders/:id/items/:id
-			return normalizePath(path)
-		},
 	}
 	app.Use(MiddlewareWithConfig(metricsConfig))
 
@@ -245,20 +242,6 @@ func setupRoutes(app *fiber.App, metricsService *Service) {
 	})
 }
 
-// normalizePath normalizes URL paths to control metric cardinality
-func normalizePath(path string) string {
-	// Example implementation - replace with your actual path normalization logic
-	// This is a simple implementation that replaces common ID patterns
-
-	// Handle common patterns:
-	// /api/v1/users/123 -> /api/v1/users/:id
-	// /api/v1/orders/456/items/789 -> /api/v1/orders/:id/items/:id
-
-	// You would implement more sophisticated logic here based on your API structure
-	// For now, just return the original path
-	return path
-}
-
 // simulateExternalAPICall simulates an external API call
 func simulateExternalAPICall(service, endpoint string) bool {
 	// Simulate random success/failure and variable latency
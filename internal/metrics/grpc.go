@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// RecordGRPCRequest records gRPC call metrics
+func (s *Service) RecordGRPCRequest(fullMethod, code string, duration time.Duration) {
+	service, method := splitFullMethod(fullMethod)
+
+	s.grpcRequestCount.WithLabelValues(service, method, code).Inc()
+	s.grpcRequestDuration.WithLabelValues(service, method, code).Observe(duration.Seconds())
+}
+
+// UnaryServerInterceptor returns a gRPC unary interceptor for metrics collection
+func (s *Service) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		s.RecordGRPCRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+
+		return resp, err
+	}
+}
+
+// splitFullMethod splits "/package.Service/Method" into service and method
+func splitFullMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
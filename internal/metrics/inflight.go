@@ -0,0 +1,11 @@
+package metrics
+
+// RequestStarted increments requests_in_flight; pair it with RequestFinished
+func (s *Service) RequestStarted() {
+	s.requestsInFlight.Inc()
+}
+
+// RequestFinished decrements requests_in_flight
+func (s *Service) RequestFinished() {
+	s.requestsInFlight.Dec()
+}
\ No newline at end of file
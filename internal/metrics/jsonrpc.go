@@ -0,0 +1,13 @@
+package metrics
+
+import "time"
+
+// JSONRPCOutcomeSuccess is the outcome label of JSON-RPC calls that returned
+// a result; failed calls are labelled with their error code
+const JSONRPCOutcomeSuccess = "success"
+
+// RecordJSONRPCRequest records JSON-RPC call metrics
+func (s *Service) RecordJSONRPCRequest(method, outcome string, duration time.Duration) {
+	s.jsonrpcRequestCount.WithLabelValues(method, outcome).Inc()
+	s.jsonrpcRequestDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
\ No newline at end of file
@@ -2,25 +2,54 @@ package metrics
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
 	"github.com/prometheus/common/expfmt"
+	"github.com/yhonda-ohishi/db-handler-server/internal/logger"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Service provides metrics collection and reporting functionality
 type Service struct {
 	registry *prometheus.Registry
+	// registerer adds the configured constant labels to every metric
+	registerer prometheus.Registerer
 
 	// HTTP metrics
-	requestCount      *prometheus.CounterVec
-	requestDuration   *prometheus.HistogramVec
-	requestSize       *prometheus.HistogramVec
-	responseSize      *prometheus.HistogramVec
+	requestCount    *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	requestSize     *prometheus.HistogramVec
+	requestWireSize *prometheus.HistogramVec
+	responseSize    *prometheus.HistogramVec
+	// requestsInFlight counts HTTP requests being handled
+	requestsInFlight prometheus.Gauge
+
+	// gRPC metrics
+	grpcRequestCount    *prometheus.CounterVec
+	grpcRequestDuration *prometheus.HistogramVec
+
+	// JSON-RPC metrics
+	jsonrpcRequestCount    *prometheus.CounterVec
+	jsonrpcRequestDuration *prometheus.HistogramVec
+
+	// Dependency health, keyed by dependency name
+	dependencyUp *prometheus.GaugeVec
+	dependencies sync.Map
 
 	// Custom metrics storage
 	customMetrics sync.Map
@@ -37,12 +66,42 @@ type Config struct {
 	Subsystem string
 	// Buckets for duration histogram (in seconds)
 	DurationBuckets []float64
+	// Buckets for gRPC duration histogram (in seconds)
+	GRPCDurationBuckets []float64
 	// Buckets for size histogram (in bytes)
 	SizeBuckets []float64
 	// Labels to exclude from metrics (for cardinality control)
 	ExcludeLabels []string
 	// MaxPathCardinality limits the number of unique paths tracked
 	MaxPathCardinality int
+	// StatusClassOnly records the status label as its class ("2xx", "4xx",
+	// ...) instead of the exact code, for cardinality control
+	StatusClassOnly bool
+	// PathRules collapse variable path segments such as IDs; the first rule
+	// matching a segment replaces it
+	PathRules []PathRule
+	// ConstLabels are added to every metric, e.g. DeploymentModeLabel so
+	// instances in different modes sharing a Prometheus don't collide
+	ConstLabels prometheus.Labels
+}
+
+// DeploymentModeLabel is the constant label carrying the deployment mode
+const DeploymentModeLabel = "deployment_mode"
+
+// PathRule replaces every path segment fully matching Pattern with Replacement
+type PathRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// DefaultPathRules collapse UUID and numeric segments to :id and hex hashes
+// (16 or more hex digits) to :hash, e.g. /api/users/123 -> /api/users/:id
+func DefaultPathRules() []PathRule {
+	return []PathRule{
+		{Pattern: regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`), Replacement: ":id"},
+		{Pattern: regexp.MustCompile(`^[0-9]+$`), Replacement: ":id"},
+		{Pattern: regexp.MustCompile(`^[0-9a-fA-F]{16,}$`), Replacement: ":hash"},
+	}
 }
 
 // DefaultConfig returns default metrics configuration
@@ -53,11 +112,15 @@ func DefaultConfig() Config {
 		DurationBuckets: []float64{
 			0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0,
 		},
+		GRPCDurationBuckets: []float64{
+			0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 5.0,
+		},
 		SizeBuckets: []float64{
 			100, 1000, 10000, 100000, 1000000, 10000000,
 		},
 		ExcludeLabels:      []string{},
 		MaxPathCardinality: 100,
+		PathRules:          DefaultPathRules(),
 	}
 }
 
@@ -68,6 +131,10 @@ func NewService(config Config) *Service {
 	}
 
 	registry := prometheus.NewRegistry()
+	var registerer prometheus.Registerer = registry
+	if len(config.ConstLabels) > 0 {
+		registerer = prometheus.WrapRegistererWith(config.ConstLabels, registry)
+	}
 
 	// Create HTTP metrics
 	requestCount := prometheus.NewCounterVec(
@@ -96,7 +163,18 @@ func NewService(config Config) *Service {
 			Namespace: config.Namespace,
 			Subsystem: config.Subsystem,
 			Name:      "request_size_bytes",
-			Help:      "HTTP request size in bytes",
+			Help:      "HTTP request size in bytes, after decompression",
+			Buckets:   config.SizeBuckets,
+		},
+		[]string{"method", "path"},
+	)
+
+	requestWireSize := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: config.Namespace,
+			Subsystem: config.Subsystem,
+			Name:      "request_wire_bytes",
+			Help:      "HTTP request body size in bytes as received, before decompression",
 			Buckets:   config.SizeBuckets,
 		},
 		[]string{"method", "path"},
@@ -113,23 +191,102 @@ func NewService(config Config) *Service {
 		[]string{"method", "path", "status"},
 	)
 
+	if len(config.GRPCDurationBuckets) == 0 {
+		config.GRPCDurationBuckets = DefaultConfig().GRPCDurationBuckets
+	}
+
+	// Create gRPC metrics
+	grpcRequestCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "grpc",
+			Subsystem: config.Subsystem,
+			Name:      "handled_total",
+			Help:      "Total number of gRPC calls by service, method, and status code",
+		},
+		[]string{"grpc_service", "grpc_method", "grpc_code"},
+	)
+
+	grpcRequestDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "grpc",
+			Subsystem: config.Subsystem,
+			Name:      "handling_seconds",
+			Help:      "gRPC call duration in seconds",
+			Buckets:   config.GRPCDurationBuckets,
+		},
+		[]string{"grpc_service", "grpc_method", "grpc_code"},
+	)
+
+	// Create JSON-RPC metrics
+	jsonrpcRequestCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jsonrpc",
+			Name:      "requests_total",
+			Help:      "Total number of JSON-RPC calls by method and outcome",
+		},
+		[]string{"method", "outcome"},
+	)
+
+	jsonrpcRequestDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "jsonrpc",
+			Name:      "request_duration_seconds",
+			Help:      "JSON-RPC call duration in seconds",
+			Buckets:   config.DurationBuckets,
+		},
+		[]string{"method"},
+	)
+
+	requestsInFlight := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: config.Namespace,
+			Subsystem: config.Subsystem,
+			Name:      "requests_in_flight",
+			Help:      "Number of HTTP requests currently being handled",
+		},
+	)
+
+	// Create dependency health metrics
+	dependencyUp := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "dependency_up",
+			Help: "Whether a dependency passed its last health check (1) or not (0)",
+		},
+		[]string{"name"},
+	)
+
 	// Register metrics
-	registry.MustRegister(requestCount)
-	registry.MustRegister(requestDuration)
-	registry.MustRegister(requestSize)
-	registry.MustRegister(responseSize)
+	registerer.MustRegister(requestCount)
+	registerer.MustRegister(requestDuration)
+	registerer.MustRegister(requestSize)
+	registerer.MustRegister(requestWireSize)
+	registerer.MustRegister(responseSize)
+	registerer.MustRegister(requestsInFlight)
+	registerer.MustRegister(grpcRequestCount)
+	registerer.MustRegister(grpcRequestDuration)
+	registerer.MustRegister(jsonrpcRequestCount)
+	registerer.MustRegister(jsonrpcRequestDuration)
+	registerer.MustRegister(dependencyUp)
 
 	// Register Go runtime metrics
-	registry.MustRegister(prometheus.NewGoCollector())
-	registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	registerer.MustRegister(prometheus.NewGoCollector())
+	registerer.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 
 	return &Service{
-		registry:        registry,
-		requestCount:    requestCount,
-		requestDuration: requestDuration,
-		requestSize:     requestSize,
-		responseSize:    responseSize,
-		config:          config,
+		registry:               registry,
+		registerer:             registerer,
+		requestCount:           requestCount,
+		requestDuration:        requestDuration,
+		requestSize:            requestSize,
+		requestWireSize:        requestWireSize,
+		responseSize:           responseSize,
+		requestsInFlight:       requestsInFlight,
+		grpcRequestCount:       grpcRequestCount,
+		grpcRequestDuration:    grpcRequestDuration,
+		jsonrpcRequestCount:    jsonrpcRequestCount,
+		jsonrpcRequestDuration: jsonrpcRequestDuration,
+		dependencyUp:           dependencyUp,
+		config:                 config,
 	}
 }
 
@@ -140,29 +297,85 @@ func NewServiceWithDefaults() *Service {
 
 // RecordRequest records HTTP request metrics
 func (s *Service) RecordRequest(method, path string, statusCode int, duration time.Duration, requestSize, responseSize int64) {
-	status := strconv.Itoa(statusCode)
+	s.RecordRequestContext(context.Background(), method, path, statusCode, duration, requestSize, responseSize)
+}
+
+// RecordRequestContext records HTTP request metrics like RecordRequest,
+// attaching the trace and request IDs in ctx, if any, as an exemplar to the
+// request duration observation
+func (s *Service) RecordRequestContext(ctx context.Context, method, path string, statusCode int, duration time.Duration, requestSize, responseSize int64) {
+	status := s.statusLabel(statusCode)
 
 	// Normalize path to control cardinality
 	normalizedPath := s.normalizePath(path)
 
 	// Record metrics
 	s.requestCount.WithLabelValues(method, normalizedPath, status).Inc()
-	s.requestDuration.WithLabelValues(method, normalizedPath, status).Observe(duration.Seconds())
+	observer := s.requestDuration.WithLabelValues(method, normalizedPath, status)
+	exemplarObserver, ok := observer.(prometheus.ExemplarObserver)
+	if exemplar := exemplarLabels(ctx); ok && exemplar != nil {
+		exemplarObserver.ObserveWithExemplar(duration.Seconds(), exemplar)
+	} else {
+		observer.Observe(duration.Seconds())
+	}
 	s.requestSize.WithLabelValues(method, normalizedPath).Observe(float64(requestSize))
 	s.responseSize.WithLabelValues(method, normalizedPath, status).Observe(float64(responseSize))
 }
 
-// normalizePath normalizes URL paths to control metric cardinality
+// statusLabel returns the status label value for statusCode
+func (s *Service) statusLabel(statusCode int) string {
+	if s.config.StatusClassOnly && statusCode >= 100 && statusCode < 600 {
+		return strconv.Itoa(statusCode/100) + "xx"
+	}
+	return strconv.Itoa(statusCode)
+}
+
+// exemplarLabels returns the trace_id and request_id found in ctx as exemplar
+// labels, or nil when neither is present. A request ID that would push the
+// labels past prometheus.ExemplarMaxRunes is left out.
+func exemplarLabels(ctx context.Context) prometheus.Labels {
+	labels := prometheus.Labels{}
+	runes := 0
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		labels["trace_id"] = spanCtx.TraceID().String()
+		runes += len("trace_id") + len(labels["trace_id"])
+	}
+	if requestID, ok := logger.GetRequestIDFromContext(ctx); ok && requestID != "" {
+		if runes+len("request_id")+utf8.RuneCountInString(requestID) <= prometheus.ExemplarMaxRunes {
+			labels["request_id"] = requestID
+		}
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// RecordRequestWireSize records the size of a request body as received
+func (s *Service) RecordRequestWireSize(method, path string, wireSize int64) {
+	s.requestWireSize.WithLabelValues(method, s.normalizePath(path)).Observe(float64(wireSize))
+}
+
+// normalizePath normalizes URL paths to control metric cardinality, applying
+// the configured path rules to each segment
 func (s *Service) normalizePath(path string) string {
-	// Simple normalization - replace IDs with placeholders
-	// In a real implementation, you might want more sophisticated path normalization
 	if len(path) > 100 {
 		return "/long_path"
 	}
+	if len(s.config.PathRules) == 0 {
+		return path
+	}
 
-	// You can add more sophisticated path normalization here
-	// For example, replacing UUIDs, numeric IDs, etc.
-	return path
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		for _, rule := range s.config.PathRules {
+			if segment != "" && rule.Pattern.MatchString(segment) {
+				segments[i] = rule.Replacement
+				break
+			}
+		}
+	}
+	return strings.Join(segments, "/")
 }
 
 // RegisterCounter registers a custom counter metric
@@ -177,7 +390,7 @@ func (s *Service) RegisterCounter(name, help string, labels []string) *prometheu
 		labels,
 	)
 
-	s.registry.MustRegister(counter)
+	s.registerer.MustRegister(counter)
 	s.customMetrics.Store(name, counter)
 	return counter
 }
@@ -194,7 +407,7 @@ func (s *Service) RegisterGauge(name, help string, labels []string) *prometheus.
 		labels,
 	)
 
-	s.registry.MustRegister(gauge)
+	s.registerer.MustRegister(gauge)
 	s.customMetrics.Store(name, gauge)
 	return gauge
 }
@@ -216,7 +429,7 @@ func (s *Service) RegisterHistogram(name, help string, labels []string, buckets
 		labels,
 	)
 
-	s.registry.MustRegister(histogram)
+	s.registerer.MustRegister(histogram)
 	s.customMetrics.Store(name, histogram)
 	return histogram
 }
@@ -238,7 +451,7 @@ func (s *Service) RegisterSummary(name, help string, labels []string, objectives
 		labels,
 	)
 
-	s.registry.MustRegister(summary)
+	s.registerer.MustRegister(summary)
 	s.customMetrics.Store(name, summary)
 	return summary
 }
@@ -295,20 +508,57 @@ func (s *Service) Handler() fiber.Handler {
 		// Create buffer for output
 		buf := &bytes.Buffer{}
 
-		// Use Prometheus exposition format
-		encoder := expfmt.NewEncoder(buf, expfmt.FmtText)
+		// Use the Prometheus text format, or OpenMetrics (which carries
+		// exemplars) when the scraper asks for it
+		format := expfmt.NegotiateIncludingOpenMetrics(http.Header{
+			fiber.HeaderAccept: []string{c.Get(fiber.HeaderAccept)},
+		})
+		encoder := expfmt.NewEncoder(buf, format)
 		for _, mf := range metricFamilies {
 			if err := encoder.Encode(mf); err != nil {
 				return c.Status(fiber.StatusInternalServerError).SendString("Error encoding metrics")
 			}
 		}
+		if closer, ok := encoder.(expfmt.Closer); ok {
+			if err := closer.Close(); err != nil {
+				return c.Status(fiber.StatusInternalServerError).SendString("Error encoding metrics")
+			}
+		}
 
 		// Set content type and return metrics
-		c.Set("Content-Type", string(expfmt.FmtText))
+		c.Set("Content-Type", string(format))
 		return c.SendString(buf.String())
 	}
 }
 
+// Push sends all metrics of the service to a Prometheus Pushgateway, replacing
+// every metric previously pushed under jobName and grouping. Short-lived batch
+// jobs call it before exiting so their metrics outlive the process.
+func (s *Service) Push(ctx context.Context, pushgatewayURL, jobName string, grouping map[string]string) error {
+	if err := s.pusher(pushgatewayURL, jobName, grouping).PushContext(ctx); err != nil {
+		return fmt.Errorf("failed to push metrics: %w", err)
+	}
+	return nil
+}
+
+// PushAdd is like Push but only replaces metrics with the same names, keeping
+// other metrics previously pushed under jobName and grouping
+func (s *Service) PushAdd(ctx context.Context, pushgatewayURL, jobName string, grouping map[string]string) error {
+	if err := s.pusher(pushgatewayURL, jobName, grouping).AddContext(ctx); err != nil {
+		return fmt.Errorf("failed to push metrics: %w", err)
+	}
+	return nil
+}
+
+// pusher builds a Pushgateway client for the service's registry
+func (s *Service) pusher(pushgatewayURL, jobName string, grouping map[string]string) *push.Pusher {
+	pusher := push.New(pushgatewayURL, jobName).Gatherer(s.registry)
+	for name, value := range grouping {
+		pusher = pusher.Grouping(name, value)
+	}
+	return pusher
+}
+
 // Helper function to format float values
 func formatFloat(f float64) string {
 	return fmt.Sprintf("%g", f)
@@ -327,6 +577,13 @@ type MiddlewareConfig struct {
 	SkipPaths []string
 	// PathNormalizer is a function to normalize paths for metrics
 	PathNormalizer func(string) string
+	// DecompressRequests gunzips request bodies sent with Content-Encoding:
+	// gzip so handlers and request_size_bytes see the decompressed body
+	DecompressRequests bool
+	// MaxDecompressedBytes returns the largest decompressed body accepted
+	// for a path; larger bodies are rejected with 413. Nil or a limit of
+	// zero or less leaves decompressed bodies uncapped.
+	MaxDecompressedBytes func(path string) int
 }
 
 // DefaultMiddlewareConfig returns default middleware configuration
@@ -355,37 +612,106 @@ func MiddlewareWithConfig(config MiddlewareConfig) fiber.Handler {
 
 		start := time.Now()
 
-		// Get request size
-		requestSize := int64(len(c.Request().Body()))
+		// Get request size; wire and request size differ only when decompressed
+		wireSize := int64(len(c.Request().Body()))
+		requestSize := wireSize
 
-		// Continue with request
-		err := c.Next()
+		var err error
+		if config.DecompressRequests && isGzipEncoded(c) {
+			limit := 0
+			if config.MaxDecompressedBytes != nil {
+				limit = config.MaxDecompressedBytes(c.Path())
+			}
+
+			var body []byte
+			if body, err = gunzipLimited(c.Request().Body(), limit); errors.Is(err, errDecompressedTooLarge) {
+				err = fiber.NewError(fiber.StatusRequestEntityTooLarge, "Request body too large")
+			} else if err != nil {
+				err = fiber.NewError(fiber.StatusBadRequest, "Invalid gzip request body")
+			} else {
+				c.Request().SetBody(body)
+				c.Request().Header.Del(fiber.HeaderContentEncoding)
+				c.Request().Header.SetContentLength(len(body))
+				requestSize = int64(len(body))
+				err = c.Next()
+			}
+		} else {
+			// Continue with request
+			err = c.Next()
+		}
+
+		// Let the app's error handler write the response now, so the
+		// recorded status is the one the client receives
+		if err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+			err = nil
+		}
 
 		// Calculate metrics
 		duration := time.Since(start)
 		statusCode := c.Response().StatusCode()
 		responseSize := int64(len(c.Response().Body()))
 
+		// Copy the method and path: fiber reuses their memory for later
+		// requests, which would corrupt the label values of new series
+		method := utils.CopyString(c.Method())
+		path := utils.CopyString(c.Path())
+
 		// Normalize path
-		path := c.Path()
 		if config.PathNormalizer != nil {
 			path = config.PathNormalizer(path)
 		}
 
-		// Record metrics
-		config.Service.RecordRequest(
-			c.Method(),
+		// Record metrics, with the request's trace and request IDs as exemplar
+		config.Service.RecordRequestContext(
+			c.UserContext(),
+			method,
 			path,
 			statusCode,
 			duration,
 			requestSize,
 			responseSize,
 		)
+		config.Service.RecordRequestWireSize(method, path, wireSize)
 
 		return err
 	}
 }
 
+// errDecompressedTooLarge is returned by gunzipLimited when the decompressed
+// body exceeds its limit
+var errDecompressedTooLarge = errors.New("decompressed body too large")
+
+// gunzipLimited decompresses body, reading at most limit bytes so a small
+// gzip bomb can't expand into an unbounded allocation. A limit of zero or
+// less reads the whole body.
+func gunzipLimited(body []byte, limit int) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	if limit <= 0 {
+		return io.ReadAll(reader)
+	}
+	decompressed, err := io.ReadAll(io.LimitReader(reader, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(decompressed) > limit {
+		return nil, errDecompressedTooLarge
+	}
+	return decompressed, nil
+}
+
+// isGzipEncoded reports whether the request body is gzip-compressed
+func isGzipEncoded(c *fiber.Ctx) bool {
+	return strings.EqualFold(strings.TrimSpace(c.Get(fiber.HeaderContentEncoding)), "gzip")
+}
+
 // Middleware returns Fiber middleware for metrics collection with default config
 func (s *Service) Middleware() fiber.Handler {
 	return MiddlewareWithConfig(DefaultMiddlewareConfig(s))
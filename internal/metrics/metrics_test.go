@@ -1,15 +1,28 @@
 package metrics
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/yhonda-ohishi/db-handler-server/internal/logger"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 )
 
 func TestNewService(t *testing.T) {
@@ -222,6 +235,149 @@ func TestMiddlewareWithConfig(t *testing.T) {
 	}
 }
 
+func TestMiddlewareDecompressedRequestSize(t *testing.T) {
+	service := NewServiceWithDefaults()
+	app := fiber.New()
+
+	config := DefaultMiddlewareConfig(service)
+	config.DecompressRequests = true
+	app.Use(MiddlewareWithConfig(config))
+
+	var received string
+	app.Post("/upload", func(c *fiber.Ctx) error {
+		received = string(c.Body())
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	payload := strings.Repeat(`{"name": "compressible"}`, 200)
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write([]byte(payload)); err != nil {
+		t.Fatalf("Failed to compress payload: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to compress payload: %v", err)
+	}
+	wireSize := compressed.Len()
+
+	req := httptest.NewRequest("POST", "/upload", &compressed)
+	req.Header.Set("Content-Encoding", "gzip")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to make test request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", resp.StatusCode)
+	}
+	if received != payload {
+		t.Errorf("Expected handler to receive the decompressed body")
+	}
+
+	sums := map[string]float64{}
+	families, err := service.registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			if metric.GetHistogram() != nil {
+				sums[family.GetName()] += metric.GetHistogram().GetSampleSum()
+			}
+		}
+	}
+
+	if sums["http_server_request_size_bytes"] != float64(len(payload)) {
+		t.Errorf("Expected request_size_bytes %d, got %v", len(payload), sums["http_server_request_size_bytes"])
+	}
+	if sums["http_server_request_wire_bytes"] != float64(wireSize) {
+		t.Errorf("Expected request_wire_bytes %d, got %v", wireSize, sums["http_server_request_wire_bytes"])
+	}
+	if wireSize >= len(payload) {
+		t.Errorf("Expected the compressed body to be smaller than the payload")
+	}
+
+	// Corrupt gzip bodies are rejected
+	req = httptest.NewRequest("POST", "/upload", strings.NewReader("not gzip"))
+	req.Header.Set("Content-Encoding", "gzip")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to make test request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid gzip body, got %d", resp.StatusCode)
+	}
+}
+
+func TestMiddlewareCapsDecompressedSize(t *testing.T) {
+	service := NewServiceWithDefaults()
+	app := fiber.New()
+
+	config := DefaultMiddlewareConfig(service)
+	config.DecompressRequests = true
+	config.MaxDecompressedBytes = func(path string) int { return 1024 }
+	app.Use(MiddlewareWithConfig(config))
+
+	handled := false
+	app.Post("/upload", func(c *fiber.Ctx) error {
+		handled = true
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(bytes.Repeat([]byte{0}, 10<<20)); err != nil {
+		t.Fatalf("Failed to compress payload: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to compress payload: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/upload", &compressed)
+	req.Header.Set("Content-Encoding", "gzip")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to make test request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d", resp.StatusCode)
+	}
+	if handled {
+		t.Errorf("Expected the handler not to run")
+	}
+}
+
+func TestMiddlewareRecordsErrorStatus(t *testing.T) {
+	service := NewServiceWithDefaults()
+	app := fiber.New()
+	app.Use(service.Middleware())
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/missing", nil))
+	if err != nil {
+		t.Fatalf("Failed to make test request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("Expected status 404, got %d", resp.StatusCode)
+	}
+
+	expected := `
+# HELP http_server_requests_total Total number of HTTP requests by method, path, and status code
+# TYPE http_server_requests_total counter
+http_server_requests_total{method="GET",path="/missing",status="404"} 1
+`
+	if err := testutil.GatherAndCompare(service.registry, strings.NewReader(expected), "http_server_requests_total"); err != nil {
+		t.Errorf("Expected the returned error's status to be recorded: %v", err)
+	}
+}
+
 func TestMetricsHandler(t *testing.T) {
 	service := NewServiceWithDefaults()
 	app := fiber.New()
@@ -297,6 +453,12 @@ func TestNormalizePath(t *testing.T) {
 		{"/api/users", "/api/users"},
 		{"/", "/"},
 		{strings.Repeat("a", 150), "/long_path"},
+		{"/api/users/123", "/api/users/:id"},
+		{"/api/users/550e8400-e29b-41d4-a716-446655440000", "/api/users/:id"},
+		{"/api/orders/456/items/789", "/api/orders/:id/items/:id"},
+		{"/api/files/d41d8cd98f00b204e9800998ecf8427e", "/api/files/:hash"},
+		{"/api/v1/users/42/", "/api/v1/users/:id/"},
+		{"/api/cards/abc123", "/api/cards/abc123"},
 	}
 
 	for _, test := range tests {
@@ -307,6 +469,374 @@ func TestNormalizePath(t *testing.T) {
 	}
 }
 
+func TestNormalizePathCustomRules(t *testing.T) {
+	config := DefaultConfig()
+	config.PathRules = []PathRule{
+		{Pattern: regexp.MustCompile(`^[A-Z]{2}[0-9]+$`), Replacement: ":code"},
+	}
+	service := NewService(config)
+
+	if got := service.normalizePath("/api/cards/JP1234"); got != "/api/cards/:code" {
+		t.Errorf("normalizePath = %q, expected /api/cards/:code", got)
+	}
+	// Default rules are replaced, not extended
+	if got := service.normalizePath("/api/users/123"); got != "/api/users/123" {
+		t.Errorf("normalizePath = %q, expected /api/users/123", got)
+	}
+
+	config.PathRules = nil
+	service = NewService(config)
+	if got := service.normalizePath("/api/users/123"); got != "/api/users/123" {
+		t.Errorf("normalizePath with no rules = %q, expected /api/users/123", got)
+	}
+}
+
+func TestGRPCDurationBuckets(t *testing.T) {
+	config := DefaultConfig()
+	config.GRPCDurationBuckets = []float64{0.01, 0.1, 1}
+	service := NewService(config)
+
+	interceptor := service.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/etc_meisai.v1.UserService/GetUser"}
+
+	// Record a fast call and a slow call
+	for _, delay := range []time.Duration{0, 50 * time.Millisecond} {
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			time.Sleep(delay)
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected interceptor error: %v", err)
+		}
+	}
+
+	metricFamilies, err := service.registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	var found bool
+	for _, mf := range metricFamilies {
+		if mf.GetName() != "grpc_server_handling_seconds" {
+			continue
+		}
+		found = true
+
+		histogram := mf.GetMetric()[0].GetHistogram()
+		if histogram.GetSampleCount() != 2 {
+			t.Errorf("Expected 2 observations, got %d", histogram.GetSampleCount())
+		}
+
+		buckets := histogram.GetBucket()
+		if len(buckets) != 3 {
+			t.Fatalf("Expected 3 configured buckets, got %d", len(buckets))
+		}
+
+		expected := []struct {
+			upperBound float64
+			count      uint64
+		}{
+			{0.01, 1},
+			{0.1, 2},
+			{1, 2},
+		}
+		for i, bucket := range buckets {
+			if bucket.GetUpperBound() != expected[i].upperBound {
+				t.Errorf("Bucket %d: expected upper bound %v, got %v", i, expected[i].upperBound, bucket.GetUpperBound())
+			}
+			if bucket.GetCumulativeCount() != expected[i].count {
+				t.Errorf("Bucket %d: expected count %d, got %d", i, expected[i].count, bucket.GetCumulativeCount())
+			}
+		}
+
+		labels := map[string]string{}
+		for _, label := range mf.GetMetric()[0].GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		if labels["grpc_service"] != "etc_meisai.v1.UserService" || labels["grpc_method"] != "GetUser" || labels["grpc_code"] != "OK" {
+			t.Errorf("Unexpected labels: %v", labels)
+		}
+	}
+
+	if !found {
+		t.Fatal("Expected grpc_server_handling_seconds to be registered")
+	}
+}
+
+func TestConstLabels(t *testing.T) {
+	config := DefaultConfig()
+	config.ConstLabels = map[string]string{DeploymentModeLabel: "separate"}
+	service := NewService(config)
+
+	service.RecordRequest("GET", "/api/users", 200, 10*time.Millisecond, 0, 0)
+	service.RecordGRPCRequest("/etc_meisai.v1.UserService/GetUser", "OK", time.Millisecond)
+	service.RecordJSONRPCRequest("user.get", JSONRPCOutcomeSuccess, time.Millisecond)
+	service.RegisterCounter("custom_total", "A custom counter", []string{"kind"}).WithLabelValues("a").Inc()
+
+	metricFamilies, err := service.registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	if len(metricFamilies) == 0 {
+		t.Fatal("Expected gathered metrics")
+	}
+
+	// Every metric, including runtime and custom ones, carries the mode
+	for _, mf := range metricFamilies {
+		for _, metric := range mf.GetMetric() {
+			var mode string
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == DeploymentModeLabel {
+					mode = label.GetValue()
+				}
+			}
+			if mode != "separate" {
+				t.Errorf("Expected %s to have %s=\"separate\", got %q", mf.GetName(), DeploymentModeLabel, mode)
+			}
+		}
+	}
+}
+
+func TestStatusClassOnly(t *testing.T) {
+	config := DefaultConfig()
+	config.StatusClassOnly = true
+	service := NewService(config)
+
+	app := fiber.New()
+	app.Use(service.Middleware())
+	for _, code := range []int{200, 201, 404, 500} {
+		code := code
+		app.Get("/status/"+strconv.Itoa(code), func(c *fiber.Ctx) error {
+			return c.SendStatus(code)
+		})
+	}
+
+	for _, code := range []int{200, 201, 404, 500} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/status/"+strconv.Itoa(code), nil))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	statuses := map[string]bool{}
+	families, err := service.registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	for _, mf := range families {
+		if mf.GetName() != "http_server_requests_total" {
+			continue
+		}
+		for _, metric := range mf.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "status" {
+					statuses[label.GetValue()] = true
+				}
+			}
+		}
+	}
+
+	want := map[string]bool{"2xx": true, "4xx": true, "5xx": true}
+	if len(statuses) != len(want) {
+		t.Errorf("expected status classes %v, got %v", want, statuses)
+	}
+	for class := range want {
+		if !statuses[class] {
+			t.Errorf("expected a %s series, got %v", class, statuses)
+		}
+	}
+	if got := testutil.ToFloat64(service.requestCount.WithLabelValues("GET", "/status/:id", "2xx")); got != 2 {
+		t.Errorf("expected two 2xx requests to /status/:id, got %v", got)
+	}
+}
+
+// durationExemplars returns the exemplar labels attached to the request duration buckets
+func durationExemplars(t *testing.T, service *Service) []map[string]string {
+	t.Helper()
+
+	families, err := service.registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	var exemplars []map[string]string
+	for _, mf := range families {
+		if mf.GetName() != "http_server_request_duration_seconds" {
+			continue
+		}
+		for _, metric := range mf.GetMetric() {
+			for _, bucket := range metric.GetHistogram().GetBucket() {
+				if exemplar := bucket.GetExemplar(); exemplar != nil {
+					labels := map[string]string{}
+					for _, pair := range exemplar.GetLabel() {
+						labels[pair.GetName()] = pair.GetValue()
+					}
+					exemplars = append(exemplars, labels)
+				}
+			}
+		}
+	}
+	return exemplars
+}
+
+func TestRecordRequestExemplar(t *testing.T) {
+	t.Run("request and trace IDs", func(t *testing.T) {
+		service := NewServiceWithDefaults()
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}))
+		ctx = logger.ContextWithRequestID(ctx, "req-exemplar-1")
+
+		service.RecordRequestContext(ctx, "GET", "/api/v1/users", 200, 30*time.Millisecond, 0, 100)
+
+		exemplars := durationExemplars(t, service)
+		if len(exemplars) != 1 {
+			t.Fatalf("expected 1 exemplar, got %d", len(exemplars))
+		}
+		if exemplars[0]["request_id"] != "req-exemplar-1" || exemplars[0]["trace_id"] != traceID.String() {
+			t.Errorf("expected exemplar with request and trace IDs, got %v", exemplars[0])
+		}
+	})
+
+	t.Run("no IDs in context", func(t *testing.T) {
+		service := NewServiceWithDefaults()
+		service.RecordRequest("GET", "/api/v1/users", 200, 30*time.Millisecond, 0, 100)
+
+		if exemplars := durationExemplars(t, service); len(exemplars) != 0 {
+			t.Errorf("expected no exemplars without IDs, got %v", exemplars)
+		}
+	})
+
+	t.Run("exposed via OpenMetrics", func(t *testing.T) {
+		service := NewServiceWithDefaults()
+		app := fiber.New()
+		app.Use(func(c *fiber.Ctx) error {
+			c.SetUserContext(logger.ContextWithRequestID(c.UserContext(), "req-exemplar-2"))
+			return c.Next()
+		})
+		app.Use(service.Middleware())
+		app.Get("/metrics", service.Handler())
+		app.Get("/api/test", func(c *fiber.Ctx) error {
+			return c.SendString("ok")
+		})
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/test", nil))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		req := httptest.NewRequest("GET", "/metrics", nil)
+		req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+		resp, err = app.Test(req)
+		if err != nil {
+			t.Fatalf("metrics request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+
+		if !strings.Contains(resp.Header.Get("Content-Type"), "application/openmetrics-text") {
+			t.Errorf("expected OpenMetrics content type, got %s", resp.Header.Get("Content-Type"))
+		}
+		if !strings.Contains(string(body), `# {request_id="req-exemplar-2"}`) {
+			t.Errorf("expected exemplar in OpenMetrics output")
+		}
+	})
+}
+
+// pushedRequest records a request received by the stub Pushgateway
+type pushedRequest struct {
+	method   string
+	path     string
+	families map[string]bool
+}
+
+// newStubPushgateway starts a server that decodes pushed metric families
+func newStubPushgateway(t *testing.T) (*httptest.Server, func() []pushedRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []pushedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pushed := pushedRequest{method: r.Method, path: r.URL.Path, families: map[string]bool{}}
+		decoder := expfmt.NewDecoder(r.Body, expfmt.ResponseFormat(r.Header))
+		for {
+			var mf dto.MetricFamily
+			if err := decoder.Decode(&mf); err != nil {
+				if !errors.Is(err, io.EOF) {
+					t.Errorf("failed to decode pushed metrics: %v", err)
+				}
+				break
+			}
+			pushed.families[mf.GetName()] = true
+		}
+
+		mu.Lock()
+		requests = append(requests, pushed)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []pushedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]pushedRequest{}, requests...)
+	}
+}
+
+func TestPush(t *testing.T) {
+	server, received := newStubPushgateway(t)
+
+	service := NewServiceWithDefaults()
+	service.RecordRequest("GET", "/api/v1/download", 200, 50*time.Millisecond, 0, 512)
+	jobs := service.RegisterCounter("download_jobs_total", "Completed download jobs", []string{"result"})
+	jobs.WithLabelValues("success").Inc()
+
+	ctx := context.Background()
+	if err := service.Push(ctx, server.URL, "etc_download", map[string]string{"instance": "batch-1"}); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if err := service.PushAdd(ctx, server.URL, "etc_download", nil); err != nil {
+		t.Fatalf("PushAdd failed: %v", err)
+	}
+
+	requests := received()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 pushes, got %d", len(requests))
+	}
+
+	// Push replaces the group with PUT, PushAdd merges into it with POST
+	if requests[0].method != http.MethodPut || requests[0].path != "/metrics/job/etc_download/instance/batch-1" {
+		t.Errorf("expected PUT to the grouping path, got %s %s", requests[0].method, requests[0].path)
+	}
+	if requests[1].method != http.MethodPost || requests[1].path != "/metrics/job/etc_download" {
+		t.Errorf("expected POST to the job path, got %s %s", requests[1].method, requests[1].path)
+	}
+
+	for _, name := range []string{"http_server_requests_total", "http_server_request_duration_seconds", "http_server_download_jobs_total"} {
+		if !requests[0].families[name] {
+			t.Errorf("expected pushed payload to contain %s, got %v", name, requests[0].families)
+		}
+	}
+}
+
+func TestPushError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewServiceWithDefaults().Push(context.Background(), server.URL, "etc_download", nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to push metrics") {
+		t.Errorf("expected push error, got %v", err)
+	}
+}
+
 func BenchmarkRecordRequest(b *testing.B) {
 	service := NewServiceWithDefaults()
 
@@ -329,4 +859,20 @@ func BenchmarkMiddleware(b *testing.B) {
 		req := httptest.NewRequest("GET", "/test", nil)
 		_, _ = app.Test(req)
 	}
+}
+
+func TestRequestsInFlight(t *testing.T) {
+	service := NewServiceWithDefaults()
+
+	service.RequestStarted()
+	service.RequestStarted()
+	if got := testutil.ToFloat64(service.requestsInFlight); got != 2 {
+		t.Errorf("Expected 2 requests in flight, got %v", got)
+	}
+
+	service.RequestFinished()
+	service.RequestFinished()
+	if got := testutil.ToFloat64(service.requestsInFlight); got != 0 {
+		t.Errorf("Expected no requests in flight, got %v", got)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,71 @@
+package readonly
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Mode is a global switch that blocks writes while reads continue
+type Mode struct {
+	enabled atomic.Bool
+	// writeMethods holds the full gRPC method names that modify data
+	writeMethods map[string]struct{}
+}
+
+// New creates a read-only switch with the given initial state that blocks
+// writeMethods, given as full gRPC method names
+func New(enabled bool, writeMethods []string) *Mode {
+	m := &Mode{writeMethods: make(map[string]struct{}, len(writeMethods))}
+	for _, method := range writeMethods {
+		m.writeMethods[method] = struct{}{}
+	}
+	m.enabled.Store(enabled)
+	return m
+}
+
+// Enabled reports whether writes are currently blocked
+func (m *Mode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// SetEnabled turns read-only mode on or off
+func (m *Mode) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// IsWriteMethod reports whether a full gRPC method name refers to a write
+func (m *Mode) IsWriteMethod(fullMethod string) bool {
+	_, ok := m.writeMethods[fullMethod]
+	return ok
+}
+
+// UnaryServerInterceptor rejects write RPCs with FailedPrecondition while read-only
+func (m *Mode) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if m.Enabled() && m.IsWriteMethod(info.FullMethod) {
+			return nil, status.Error(codes.FailedPrecondition, "service is in read-only mode")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// FiberMiddleware rejects REST writes with 403 while read-only
+func (m *Mode) FiberMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !m.Enabled() {
+			return c.Next()
+		}
+
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+			// Rendered by the app's error handler, like other REST errors
+			return fiber.NewError(fiber.StatusForbidden, "Service is in read-only mode")
+		}
+		return c.Next()
+	}
+}
\ No newline at end of file
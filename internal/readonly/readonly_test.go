@@ -0,0 +1,63 @@
+package readonly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yhonda-ohishi/db-handler-server/internal/services"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsWriteMethod(t *testing.T) {
+	mode := New(false, services.WriteMethods)
+
+	tests := map[string]bool{
+		"/etc_meisai.v1.UserService/CreateUser":           true,
+		"/etc_meisai.v1.UserService/UpdateUser":           true,
+		"/etc_meisai.v1.CardService/DeleteCard":           true,
+		"/etc_meisai.v1.ETCService/BulkUpdateETCMeisai":   true,
+		"/etc_meisai.v1.ETCService/DeduplicateETCMeisai":  true,
+		"/db_service.ETCMeisaiService/Create":             true,
+		"/etc_meisai.v1.UserService/GetUser":              false,
+		"/etc_meisai.v1.UserService/ListUsers":            false,
+		"/etc_meisai.v1.ETCService/CheckDuplicatesByHash": false,
+		"/etc_meisai.v1.ETCService/BatchGenerateHash":     false,
+	}
+
+	for method, want := range tests {
+		if got := mode.IsWriteMethod(method); got != want {
+			t.Errorf("IsWriteMethod(%q) = %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestUnaryServerInterceptorBlocksDeduplicate(t *testing.T) {
+	mode := New(true, services.WriteMethods)
+	interceptor := mode.UnaryServerInterceptor()
+
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return &pb.DeduplicateResponse{}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: pb.ETCService_DeduplicateETCMeisai_FullMethodName}
+
+	_, err := interceptor(context.Background(), &pb.DeduplicateRequest{}, info, handler)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition while read-only, got %v", err)
+	}
+	if called {
+		t.Error("expected the deduplication not to run while read-only")
+	}
+
+	mode.SetEnabled(false)
+	if _, err := interceptor(context.Background(), &pb.DeduplicateRequest{}, info, handler); err != nil {
+		t.Errorf("expected the call to pass once writable, got %v", err)
+	}
+	if !called {
+		t.Error("expected the deduplication to run once writable")
+	}
+}
\ No newline at end of file
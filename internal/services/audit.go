@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/yhonda-ohishi/db-handler-server/internal/audit"
+	"github.com/yhonda-ohishi/db-handler-server/internal/logger"
+)
+
+// Entity types reported in audit entries
+const (
+	AuditEntityUser        = "user"
+	AuditEntityCard        = "card"
+	AuditEntityPayment     = "payment"
+	AuditEntityETCMeisai   = "etc_meisai"
+	AuditEntityTransaction = "transaction"
+)
+
+// auditReporter is embedded by services that report their mutating
+// operations to an audit.Recorder
+type auditReporter struct {
+	recorder audit.Recorder
+}
+
+// SetAuditRecorder sets where the service reports create, update and delete
+// operations; nil stops reporting. Set it before serving requests.
+func (a *auditReporter) SetAuditRecorder(recorder audit.Recorder) {
+	a.recorder = recorder
+}
+
+// recordAudit reports a successful operation on an entity, attributed to the
+// user ID carried by ctx. That ID comes from the caller's X-User-ID header or
+// x-user-id metadata, so the entry marks it as unverified.
+func (a *auditReporter) recordAudit(ctx context.Context, method, entityType, entityID string) {
+	if a.recorder == nil {
+		return
+	}
+	entry := audit.Entry{
+		Method:     method,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	if userID, _ := logger.GetUserIDFromContext(ctx); userID != "" {
+		entry.UserID = userID
+		entry.UserIDSource = audit.UserIDSourceHeader
+	}
+	a.recorder.Record(entry)
+}
+
+// etcAuditID formats an ETC明細 record ID for an audit entry
+func etcAuditID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
\ No newline at end of file
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
+	"github.com/yhonda-ohishi/db-handler-server/internal/logger"
 	pb "github.com/yhonda-ohishi/db-handler-server/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -18,10 +22,22 @@ import (
 // CardService implements the CardServiceServer interface
 type CardService struct {
 	pb.UnimplementedCardServiceServer
+	auditReporter
+	pageLimit
 	mu    sync.RWMutex
 	cards map[string]*pb.ETCCard
+	// exposeCardNumber controls whether responses include the raw card number;
+	// it is off by default so only the masked and last-4 forms leave the service
+	exposeCardNumber bool
+
+	// sweepStop and sweepDone coordinate the expiry sweeper goroutine
+	sweepStop chan struct{}
+	sweepDone chan struct{}
 }
 
+// DefaultCardExpirySweepInterval is how often the expiry sweeper runs by default
+const DefaultCardExpirySweepInterval = time.Hour
+
 // NewCardService creates a new CardService instance with mock data
 func NewCardService() *CardService {
 	service := &CardService{
@@ -101,7 +117,7 @@ func (s *CardService) GetCard(ctx context.Context, req *pb.GetCardRequest) (*pb.
 		return nil, status.Error(codes.NotFound, "card not found")
 	}
 
-	return card, nil
+	return s.cardView(card), nil
 }
 
 // CreateCard creates a new card
@@ -150,7 +166,8 @@ func (s *CardService) CreateCard(ctx context.Context, req *pb.CreateCardRequest)
 	}
 
 	s.cards[card.Id] = card
-	return card, nil
+	s.recordAudit(ctx, "CreateCard", AuditEntityCard, card.Id)
+	return s.cardView(card), nil
 }
 
 // UpdateCard updates an existing card
@@ -169,15 +186,7 @@ func (s *CardService) UpdateCard(ctx context.Context, req *pb.UpdateCardRequest)
 
 	// Update fields if provided
 	if req.Status != pb.CardStatus_CARD_STATUS_UNSPECIFIED {
-		card.Status = req.Status
-		// Update activation/deactivation timestamps based on status
-		now := time.Now()
-		if req.Status == pb.CardStatus_CARD_STATUS_ACTIVE && card.ActivatedAt == nil {
-			card.ActivatedAt = timestamppb.New(now)
-			card.DeactivatedAt = nil
-		} else if req.Status != pb.CardStatus_CARD_STATUS_ACTIVE && card.DeactivatedAt == nil {
-			card.DeactivatedAt = timestamppb.New(now)
-		}
+		setCardStatus(card, req.Status, time.Now())
 	}
 	if req.VehicleType != pb.VehicleType_VEHICLE_TYPE_UNSPECIFIED {
 		card.VehicleType = req.VehicleType
@@ -186,7 +195,59 @@ func (s *CardService) UpdateCard(ctx context.Context, req *pb.UpdateCardRequest)
 		card.VehicleNumber = req.VehicleNumber
 	}
 
-	return card, nil
+	s.recordAudit(ctx, "UpdateCard", AuditEntityCard, card.Id)
+	return s.cardView(card), nil
+}
+
+// BulkUpdateCardStatus sets the status of every card belonging to a user and
+// reports the cards that changed
+func (s *CardService) BulkUpdateCardStatus(ctx context.Context, req *pb.BulkUpdateCardStatusRequest) (*pb.BulkUpdateCardStatusResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user ID is required")
+	}
+	if _, ok := pb.CardStatus_name[int32(req.Status)]; !ok || req.Status == pb.CardStatus_CARD_STATUS_UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid card status %d", req.Status)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var updated []*pb.ETCCard
+	for _, card := range s.cards {
+		if card.UserId != req.UserId || card.Status == req.Status {
+			continue
+		}
+		setCardStatus(card, req.Status, now)
+		s.recordAudit(ctx, "BulkUpdateCardStatus", AuditEntityCard, card.Id)
+		updated = append(updated, card)
+	}
+
+	// Sort cards by creation date (newest first), then ID for a stable response
+	sort.Slice(updated, func(i, j int) bool {
+		return newerFirst(updated[i].CreatedAt.AsTime(), updated[j].CreatedAt.AsTime(), updated[i].Id, updated[j].Id)
+	})
+	cards := make([]*pb.ETCCard, 0, len(updated))
+	for _, card := range updated {
+		cards = append(cards, s.cardView(card))
+	}
+
+	return &pb.BulkUpdateCardStatusResponse{
+		UpdatedCount: int32(len(cards)),
+		UpdatedCards: cards,
+	}, nil
+}
+
+// setCardStatus sets a card's status along with its activation or
+// deactivation timestamp
+func setCardStatus(card *pb.ETCCard, cardStatus pb.CardStatus, now time.Time) {
+	card.Status = cardStatus
+	if cardStatus == pb.CardStatus_CARD_STATUS_ACTIVE && card.ActivatedAt == nil {
+		card.ActivatedAt = timestamppb.New(now)
+		card.DeactivatedAt = nil
+	} else if cardStatus != pb.CardStatus_CARD_STATUS_ACTIVE && card.DeactivatedAt == nil {
+		card.DeactivatedAt = timestamppb.New(now)
+	}
 }
 
 // DeleteCard deletes a card by ID
@@ -204,6 +265,7 @@ func (s *CardService) DeleteCard(ctx context.Context, req *pb.DeleteCardRequest)
 	}
 
 	delete(s.cards, req.Id)
+	s.recordAudit(ctx, "DeleteCard", AuditEntityCard, req.Id)
 	return &emptypb.Empty{}, nil
 }
 
@@ -216,19 +278,6 @@ func (s *CardService) ListCards(ctx context.Context, req *pb.ListCardsRequest) (
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	// Default pagination values
-	pageSize := req.PageSize
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	// For simplicity, ignore page token for now in mock implementation
-	skip := 0
-	if req.PageToken != "" {
-		// In real implementation, decode page token to get skip value
-		skip = 0
-	}
-
 	// Filter cards for the specified user
 	var userCards []*pb.ETCCard
 	for _, card := range s.cards {
@@ -237,38 +286,138 @@ func (s *CardService) ListCards(ctx context.Context, req *pb.ListCardsRequest) (
 		}
 	}
 
-	// Sort cards by creation date (newest first)
-	for i := 0; i < len(userCards)-1; i++ {
-		for j := i + 1; j < len(userCards); j++ {
-			if userCards[i].CreatedAt.AsTime().Before(userCards[j].CreatedAt.AsTime()) {
-				userCards[i], userCards[j] = userCards[j], userCards[i]
-			}
-		}
+	// Sort cards by creation date (newest first), then ID so pages are stable
+	sort.Slice(userCards, func(i, j int) bool {
+		return newerFirst(userCards[i].CreatedAt.AsTime(), userCards[j].CreatedAt.AsTime(), userCards[i].Id, userCards[j].Id)
+	})
+
+	p := s.pageOf(len(userCards), req.PageSize, req.PageToken)
+	cards := make([]*pb.ETCCard, 0, p.end-p.start)
+	for _, card := range userCards[p.start:p.end] {
+		cards = append(cards, s.cardView(card))
 	}
 
-	start := skip
-	end := start + int(pageSize)
+	return &pb.ListCardsResponse{
+		Cards:         cards,
+		NextPageToken: p.nextPageToken,
+		TotalCount:    int32(len(userCards)),
+		PageSize:      p.size,
+	}, nil
+}
 
-	var cards []*pb.ETCCard
-	var nextPageToken string
+// SetExposeCardNumber sets whether responses include the raw card number.
+// The masked and last-4 forms are always included; the raw number is hidden
+// unless this is enabled.
+func (s *CardService) SetExposeCardNumber(expose bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exposeCardNumber = expose
+}
+
+// cardView returns the card as it should appear in a response: the masked and
+// last-4 forms are derived from the stored number, which is only included when
+// exposure is enabled. Active cards past their expiry date are reported as
+// expired even before the sweeper runs.
+func (s *CardService) cardView(card *pb.ETCCard) *pb.ETCCard {
+	view := proto.Clone(card).(*pb.ETCCard)
+	view.CardNumberMasked = logger.MaskValue(card.CardNumber)
+	view.CardLast4 = cardLast4(card.CardNumber)
+	if !s.exposeCardNumber {
+		view.CardNumber = ""
+	}
+	if isCardExpired(card, time.Now()) {
+		view.Status = pb.CardStatus_CARD_STATUS_EXPIRED
+	}
+	return view
+}
+
+// isCardExpired reports whether an active card's expiry date is before now
+func isCardExpired(card *pb.ETCCard, now time.Time) bool {
+	return card.Status == pb.CardStatus_CARD_STATUS_ACTIVE &&
+		card.ExpiryDate != nil &&
+		card.ExpiryDate.AsTime().Before(now)
+}
 
-	if start < len(userCards) {
-		if end > len(userCards) {
-			end = len(userCards)
+// ExpireCards marks active cards whose expiry date is before now as expired,
+// setting DeactivatedAt, and returns how many cards changed
+func (s *CardService) ExpireCards(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired := 0
+	for _, card := range s.cards {
+		if isCardExpired(card, now) {
+			card.Status = pb.CardStatus_CARD_STATUS_EXPIRED
+			card.DeactivatedAt = timestamppb.New(now)
+			expired++
 		}
-		cards = userCards[start:end]
-		// Generate next page token if there are more cards
-		if end < len(userCards) {
-			nextPageToken = fmt.Sprintf("next_%d", end)
+	}
+	return expired
+}
+
+// StartExpirySweeper runs ExpireCards every interval until StopExpirySweeper
+// is called. Starting an already running sweeper has no effect.
+func (s *CardService) StartExpirySweeper(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sweepStop != nil {
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultCardExpirySweepInterval
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	s.sweepStop = stop
+	s.sweepDone = done
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				s.ExpireCards(now)
+			}
 		}
-	} else {
-		cards = []*pb.ETCCard{}
+	}()
+}
+
+// StopExpirySweeper stops the expiry sweeper and waits for it to exit
+func (s *CardService) StopExpirySweeper() {
+	s.mu.Lock()
+	stop, done := s.sweepStop, s.sweepDone
+	s.sweepStop, s.sweepDone = nil, nil
+	s.mu.Unlock()
+
+	if stop == nil {
+		return
 	}
+	close(stop)
+	<-done
+}
 
-	return &pb.ListCardsResponse{
-		Cards:         cards,
-		NextPageToken: nextPageToken,
-	}, nil
+// Close stops the card service's background work
+func (s *CardService) Close() {
+	s.StopExpirySweeper()
+}
+
+// cardLast4 returns the last four digits of a card number
+func cardLast4(cardNumber string) string {
+	digits := make([]rune, 0, 4)
+	runes := []rune(cardNumber)
+	for i := len(runes) - 1; i >= 0 && len(digits) < 4; i-- {
+		if unicode.IsDigit(runes[i]) {
+			digits = append([]rune{runes[i]}, digits...)
+		}
+	}
+	return string(digits)
 }
 
 // generateCardNumber generates a random card number in the format XXXX-XXXX-XXXX-XXXX
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCardDerivedNumberFields(t *testing.T) {
+	ctx := context.Background()
+	service := NewCardService()
+
+	card, err := service.CreateCard(ctx, &pb.CreateCardRequest{
+		UserId:      "user-derived",
+		CardNumber:  "9876-5432-1098-7654",
+		VehicleType: pb.VehicleType_VEHICLE_TYPE_REGULAR,
+	})
+	if err != nil {
+		t.Fatalf("failed to create card: %v", err)
+	}
+
+	if card.CardLast4 != "7654" {
+		t.Errorf("expected last4 7654, got %q", card.CardLast4)
+	}
+	if card.CardNumberMasked != "****-****-****-7654" {
+		t.Errorf("expected masked number ****-****-****-7654, got %q", card.CardNumberMasked)
+	}
+	if card.CardNumber != "" {
+		t.Errorf("expected raw card number to be hidden by default, got %q", card.CardNumber)
+	}
+
+	t.Run("raw number hidden when not exposed", func(t *testing.T) {
+		fetched, err := service.GetCard(ctx, &pb.GetCardRequest{Id: card.Id})
+		if err != nil {
+			t.Fatalf("failed to get card: %v", err)
+		}
+		if fetched.CardNumber != "" {
+			t.Errorf("expected raw card number to be hidden, got %q", fetched.CardNumber)
+		}
+		if fetched.CardLast4 != "7654" || fetched.CardNumberMasked != "****-****-****-7654" {
+			t.Errorf("expected derived fields to remain, got %q and %q", fetched.CardLast4, fetched.CardNumberMasked)
+		}
+
+		list, err := service.ListCards(ctx, &pb.ListCardsRequest{UserId: "user-derived"})
+		if err != nil {
+			t.Fatalf("failed to list cards: %v", err)
+		}
+		if len(list.Cards) != 1 || list.Cards[0].CardNumber != "" {
+			t.Errorf("expected listed card without raw number, got %v", list.Cards)
+		}
+
+		// The stored card keeps its number
+		stored, err := service.GetCardByNumber("9876-5432-1098-7654")
+		if err != nil || stored.Id != card.Id {
+			t.Errorf("expected stored card to keep its number, got %v, %v", stored, err)
+		}
+	})
+
+	t.Run("raw number included when exposed", func(t *testing.T) {
+		service.SetExposeCardNumber(true)
+		defer service.SetExposeCardNumber(false)
+
+		fetched, err := service.GetCard(ctx, &pb.GetCardRequest{Id: card.Id})
+		if err != nil {
+			t.Fatalf("failed to get card: %v", err)
+		}
+		if fetched.CardNumber != "9876-5432-1098-7654" {
+			t.Errorf("expected raw card number, got %q", fetched.CardNumber)
+		}
+		if fetched.CardLast4 != "7654" || fetched.CardNumberMasked != "****-****-****-7654" {
+			t.Errorf("expected derived fields alongside the raw number, got %q and %q", fetched.CardLast4, fetched.CardNumberMasked)
+		}
+	})
+}
+
+func createExpiredCard(t *testing.T, service *CardService, userID string) *pb.ETCCard {
+	t.Helper()
+
+	card, err := service.CreateCard(context.Background(), &pb.CreateCardRequest{
+		UserId:      userID,
+		ExpiryDate:  timestamppb.New(time.Now().Add(-24 * time.Hour)),
+		VehicleType: pb.VehicleType_VEHICLE_TYPE_REGULAR,
+	})
+	if err != nil {
+		t.Fatalf("failed to create card: %v", err)
+	}
+	return card
+}
+
+func TestExpiredCardReportsExpired(t *testing.T) {
+	ctx := context.Background()
+	service := NewCardService()
+	card := createExpiredCard(t, service, "user-expired")
+
+	got, err := service.GetCard(ctx, &pb.GetCardRequest{Id: card.Id})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != pb.CardStatus_CARD_STATUS_EXPIRED {
+		t.Errorf("expected GetCard to report EXPIRED, got %v", got.Status)
+	}
+
+	list, err := service.ListCards(ctx, &pb.ListCardsRequest{UserId: "user-expired"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Cards) != 1 || list.Cards[0].Status != pb.CardStatus_CARD_STATUS_EXPIRED {
+		t.Errorf("expected ListCards to report one EXPIRED card, got %v", list.Cards)
+	}
+
+	// Reads don't transition the stored card; only the sweeper does
+	service.mu.RLock()
+	stored := service.cards[card.Id].Status
+	service.mu.RUnlock()
+	if stored != pb.CardStatus_CARD_STATUS_ACTIVE {
+		t.Errorf("expected stored card to stay ACTIVE until swept, got %v", stored)
+	}
+}
+
+func TestExpirySweeperTransitionsCards(t *testing.T) {
+	service := NewCardService()
+	card := createExpiredCard(t, service, "user-sweep")
+
+	service.StartExpirySweeper(10 * time.Millisecond)
+	defer service.StopExpirySweeper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		service.mu.RLock()
+		stored := service.cards[card.Id]
+		status, deactivatedAt := stored.Status, stored.DeactivatedAt
+		service.mu.RUnlock()
+
+		if status == pb.CardStatus_CARD_STATUS_EXPIRED {
+			if deactivatedAt == nil {
+				t.Error("expected DeactivatedAt to be set")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("sweeper did not expire the card")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	service.StopExpirySweeper()
+	// Stopping twice is safe
+	service.StopExpirySweeper()
+}
+
+func TestBulkUpdateCardStatusSuspendsUserCards(t *testing.T) {
+	ctx := context.Background()
+	service := NewCardService()
+
+	var ids []string
+	for _, number := range []string{"1111-2222-3333-4444", "5555-6666-7777-8888"} {
+		card, err := service.CreateCard(ctx, &pb.CreateCardRequest{
+			UserId:      "user-fraud",
+			CardNumber:  number,
+			VehicleType: pb.VehicleType_VEHICLE_TYPE_REGULAR,
+		})
+		if err != nil {
+			t.Fatalf("failed to create card: %v", err)
+		}
+		ids = append(ids, card.Id)
+	}
+	other, err := service.CreateCard(ctx, &pb.CreateCardRequest{
+		UserId:      "user-other",
+		CardNumber:  "9999-0000-1111-2222",
+		VehicleType: pb.VehicleType_VEHICLE_TYPE_REGULAR,
+	})
+	if err != nil {
+		t.Fatalf("failed to create card: %v", err)
+	}
+
+	resp, err := service.BulkUpdateCardStatus(ctx, &pb.BulkUpdateCardStatusRequest{
+		UserId: "user-fraud",
+		Status: pb.CardStatus_CARD_STATUS_SUSPENDED,
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdateCardStatus failed: %v", err)
+	}
+	if resp.UpdatedCount != 2 || len(resp.UpdatedCards) != 2 {
+		t.Errorf("expected 2 cards updated, got %d (%d returned)", resp.UpdatedCount, len(resp.UpdatedCards))
+	}
+
+	for _, id := range ids {
+		card, err := service.GetCard(ctx, &pb.GetCardRequest{Id: id})
+		if err != nil {
+			t.Fatalf("failed to get card: %v", err)
+		}
+		if card.Status != pb.CardStatus_CARD_STATUS_SUSPENDED {
+			t.Errorf("card %s: expected SUSPENDED, got %s", id, card.Status)
+		}
+		if card.DeactivatedAt == nil {
+			t.Errorf("card %s: expected DeactivatedAt to be set", id)
+		}
+	}
+
+	otherCard, err := service.GetCard(ctx, &pb.GetCardRequest{Id: other.Id})
+	if err != nil {
+		t.Fatalf("failed to get card: %v", err)
+	}
+	if otherCard.Status != other.Status {
+		t.Errorf("another user's card changed from %s to %s", other.Status, otherCard.Status)
+	}
+
+	// Repeating the suspension changes nothing
+	resp, err = service.BulkUpdateCardStatus(ctx, &pb.BulkUpdateCardStatusRequest{
+		UserId: "user-fraud",
+		Status: pb.CardStatus_CARD_STATUS_SUSPENDED,
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdateCardStatus failed: %v", err)
+	}
+	if resp.UpdatedCount != 0 {
+		t.Errorf("expected no cards updated on repeat, got %d", resp.UpdatedCount)
+	}
+
+	if _, err := service.BulkUpdateCardStatus(ctx, &pb.BulkUpdateCardStatusRequest{UserId: "user-fraud"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument without a status, got %v", err)
+	}
+}
\ No newline at end of file
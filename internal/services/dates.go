@@ -0,0 +1,49 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// normalizeDate converts a date filter to the YYYY-MM-DD form ETC明細 dates
+// are stored in. An RFC3339 timestamp is converted to loc first, so
+// 2024-01-15T20:00:00Z is 2024-01-16 in Asia/Tokyo. It reports false when
+// value is neither.
+func normalizeDate(value string, loc *time.Location) (string, bool) {
+	if _, err := time.Parse(etcDateLayout, value); err == nil {
+		return value, true
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.In(loc).Format(etcDateLayout), true
+	}
+	return "", false
+}
+
+// normalizeDateRange normalizes optional start and end date filters in the
+// server's timezone (time.Local, set from TZ) so they compare correctly
+// against record dates. Unparseable filters fail with InvalidArgument.
+func normalizeDateRange(startDate, endDate string) (string, string, error) {
+	var v fieldViolations
+	dates := []struct {
+		field string
+		value *string
+	}{
+		{"start_date", &startDate},
+		{"end_date", &endDate},
+	}
+	for _, date := range dates {
+		if *date.value == "" {
+			continue
+		}
+		normalized, ok := normalizeDate(*date.value, time.Local)
+		if !ok {
+			v.Add(date.field, ViolationInvalidFormat, fmt.Sprintf("%s %q must be a YYYY-MM-DD date or an RFC3339 timestamp", date.field, *date.value))
+			continue
+		}
+		*date.value = normalized
+	}
+	if err := v.Err(); err != nil {
+		return "", "", err
+	}
+	return startDate, endDate, nil
+}
\ No newline at end of file
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNormalizeDate(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		ok    bool
+	}{
+		{"plain date", "2024-01-15", "2024-01-15", true},
+		{"RFC3339 same day", "2024-01-15T10:00:00+09:00", "2024-01-15", true},
+		{"RFC3339 UTC next day in Tokyo", "2024-01-15T20:00:00Z", "2024-01-16", true},
+		{"RFC3339 fractional seconds", "2024-01-15T00:30:00.5+09:00", "2024-01-15", true},
+		{"slash date", "2024/01/15", "", false},
+		{"impossible date", "2024-02-30", "", false},
+		{"timestamp without zone", "2024-01-15T10:00:00", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := normalizeDate(tt.value, tokyo)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("normalizeDate(%q) = %q, %v; expected %q, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestETCDateFiltersAcceptRFC3339(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	for _, date := range []string{"2030-06-01", "2030-06-02", "2030-06-03"} {
+		if _, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{EtcMeisai: &pb.ETCMeisai{Date: date, CarNumber: date}}); err != nil {
+			t.Fatalf("failed to create record: %v", err)
+		}
+	}
+
+	// Noon UTC falls on the same calendar day in every timezone from -11:00 to +11:00
+	resp, err := service.ListETCMeisai(ctx, &pb.ListETCMeisaiRequest{
+		StartDate: "2030-06-02T12:00:00Z",
+		EndDate:   "2030-06-03",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.EtcMeisaiList) != 2 || resp.EtcMeisaiList[0].Date != "2030-06-02" || resp.EtcMeisaiList[1].Date != "2030-06-03" {
+		t.Errorf("expected the records of 2030-06-02 and 2030-06-03, got %v", resp.EtcMeisaiList)
+	}
+
+	_, err = service.ListETCMeisai(ctx, &pb.ListETCMeisaiRequest{StartDate: "June 2nd"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an unparseable start_date, got %v", err)
+	}
+	_, err = service.GetETCSummary(ctx, &pb.GetETCSummaryRequest{EndDate: "2030-06-31"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an impossible end_date, got %v", err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DBPoolConfig tunes the connection pool of the download service database
+type DBPoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// PingTimeout bounds the startup ping that verifies the DSN
+	PingTimeout time.Duration
+}
+
+// DefaultDBPoolConfig returns the pool settings used when none are configured
+func DefaultDBPoolConfig() DBPoolConfig {
+	return DBPoolConfig{
+		MaxOpenConns:    25,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: 5 * time.Minute,
+		PingTimeout:     5 * time.Second,
+	}
+}
+
+// downloadDBDriver is the database/sql driver DATABASE_URL is opened with
+var downloadDBDriver = "mysql"
+
+// configureDBPool applies the pool settings and pings the database so that a
+// bad DSN is reported at startup rather than on the first query
+func configureDBPool(ctx context.Context, db *sql.DB, cfg DBPoolConfig) error {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	if cfg.PingTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.PingTimeout)
+		defer cancel()
+	}
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping database: %w", err)
+	}
+	return nil
+}
+
+// openDownloadDB opens the MySQL database behind the download service and
+// applies the pool settings, closing it again if the ping fails
+func openDownloadDB(dsn string, cfg DBPoolConfig) (*sql.DB, error) {
+	db, err := sql.Open(downloadDBDriver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	if err := configureDBPool(context.Background(), db, cfg); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
\ No newline at end of file
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestConfigureDBPoolAppliesSettings(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+	mock.ExpectPing()
+
+	cfg := DBPoolConfig{
+		MaxOpenConns:    12,
+		MaxIdleConns:    3,
+		ConnMaxLifetime: 90 * time.Second,
+		PingTimeout:     time.Second,
+	}
+	if err := configureDBPool(context.Background(), db, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := db.Stats().MaxOpenConnections; got != 12 {
+		t.Errorf("expected the *sql.DB to allow 12 open connections, got %d", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected the database to be pinged: %v", err)
+	}
+}
+
+func TestConfigureDBPoolSurfacesPingFailure(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+	pingErr := errors.New("access denied for user")
+	mock.ExpectPing().WillReturnError(pingErr)
+
+	err = configureDBPool(context.Background(), db, DefaultDBPoolConfig())
+	if !errors.Is(err, pingErr) {
+		t.Fatalf("expected the ping error to be returned, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+// useSQLMockDownloadDB points DATABASE_URL at a sqlmock database for the
+// duration of the test
+func useSQLMockDownloadDB(t *testing.T) sqlmock.Sqlmock {
+	dsn := "download-db-" + t.Name()
+	db, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	driver := downloadDBDriver
+	downloadDBDriver = "sqlmock"
+	t.Cleanup(func() { downloadDBDriver = driver })
+	t.Setenv("DATABASE_URL", dsn)
+	return mock
+}
+
+func TestRegistryFailsWhenDownloadDBUnreachable(t *testing.T) {
+	mock := useSQLMockDownloadDB(t)
+	pingErr := errors.New("dial tcp 10.0.0.5:3306: connect: connection refused")
+	mock.ExpectPing().WillReturnError(pingErr)
+
+	registry, err := NewServiceRegistryWithRealDB(DefaultDBPoolConfig())
+	if !errors.Is(err, pingErr) {
+		t.Fatalf("expected startup to fail with the ping error, got %v", err)
+	}
+	if registry != nil {
+		t.Errorf("expected no registry when the database is unreachable")
+	}
+}
+
+func TestRegistryServesDownloadsWithReachableDB(t *testing.T) {
+	mock := useSQLMockDownloadDB(t)
+	mock.ExpectPing()
+
+	registry, err := NewServiceRegistryWithRealDB(DefaultDBPoolConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, disabled := registry.DownloadService.(*disabledDownloadService); disabled {
+		t.Errorf("expected the real DownloadService when the database is reachable")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected the database to be pinged: %v", err)
+	}
+}
\ No newline at end of file
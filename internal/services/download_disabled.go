@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+
+	etcpb "github.com/yhonda-ohishi/etc_meisai_scraper/src/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DownloadDisabledNoDatabaseURL explains why the DownloadService is disabled
+// when DATABASE_URL isn't set
+const DownloadDisabledNoDatabaseURL = "DATABASE_URL not configured"
+
+// disabledDownloadService stands in for the etc_meisai_scraper DownloadService
+// when it can't be created, answering every call with FailedPrecondition and
+// the reason instead of a bare Unimplemented
+type disabledDownloadService struct {
+	reason string
+}
+
+// newDisabledDownloadService creates a DownloadService that is disabled for reason
+func newDisabledDownloadService(reason string) *disabledDownloadService {
+	return &disabledDownloadService{reason: reason}
+}
+
+func (s *disabledDownloadService) err() error {
+	return status.Error(codes.FailedPrecondition, "download service disabled: "+s.reason)
+}
+
+func (s *disabledDownloadService) DownloadSync(ctx context.Context, req *etcpb.DownloadRequest) (*etcpb.DownloadResponse, error) {
+	return nil, s.err()
+}
+
+func (s *disabledDownloadService) DownloadAsync(ctx context.Context, req *etcpb.DownloadRequest) (*etcpb.DownloadResponse, error) {
+	return nil, s.err()
+}
+
+func (s *disabledDownloadService) GetJobStatus(ctx context.Context, req *etcpb.GetJobStatusRequest) (*etcpb.JobStatus, error) {
+	return nil, s.err()
+}
+
+func (s *disabledDownloadService) GetAllAccountIDs(ctx context.Context, req *etcpb.GetAllAccountIDsRequest) (*etcpb.GetAllAccountIDsResponse, error) {
+	return nil, s.err()
+}
\ No newline at end of file
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+)
+
+// TestEmptyListsSerializeAsArrays checks that list endpoints return non-nil
+// slices so an empty result renders as [] rather than null
+func TestEmptyListsSerializeAsArrays(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		list func() (interface{}, error)
+	}{
+		{
+			name: "ListETCMeisai",
+			list: func() (interface{}, error) {
+				resp, err := NewETCServiceServer().ListETCMeisai(ctx, &pb.ListETCMeisaiRequest{PageToken: "1000"})
+				if err != nil {
+					return nil, err
+				}
+				return resp.EtcMeisaiList, nil
+			},
+		},
+		{
+			name: "ListPayments",
+			list: func() (interface{}, error) {
+				resp, err := NewPaymentService().ListPayments(ctx, &pb.ListPaymentsRequest{UserId: "no-such-user"})
+				if err != nil {
+					return nil, err
+				}
+				return resp.Payments, nil
+			},
+		},
+		{
+			name: "ListCards",
+			list: func() (interface{}, error) {
+				resp, err := NewCardService().ListCards(ctx, &pb.ListCardsRequest{UserId: "no-such-user"})
+				if err != nil {
+					return nil, err
+				}
+				return resp.Cards, nil
+			},
+		},
+		{
+			name: "GetTransactionHistory",
+			list: func() (interface{}, error) {
+				resp, err := NewTransactionService().GetTransactionHistory(ctx, &pb.GetTransactionHistoryRequest{CardId: "no-such-card"})
+				if err != nil {
+					return nil, err
+				}
+				return resp.Transactions, nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := tt.list()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			data, err := json.Marshal(map[string]interface{}{"items": list})
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			if got := string(data); got != `{"items":[]}` {
+				t.Errorf("expected empty array, got %s", got)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -5,8 +5,11 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	proto "github.com/yhonda-ohishi/db-handler-server/proto"
 	"google.golang.org/grpc/codes"
@@ -15,20 +18,41 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// ETCHashVersion prefixes generated ETC明細 hashes as "<version>:<sha256>".
+// Hashes without the current prefix were generated from fewer fields and
+// are recomputed by MigrateHashes.
+const ETCHashVersion = "v2"
+
+// DefaultMaxBulkBatchSize is the default maximum number of records accepted by a bulk operation
+const DefaultMaxBulkBatchSize = 1000
+
+const (
+	// DefaultStreamBatchSize is the number of records per StreamETCMeisai
+	// message when the request doesn't set one
+	DefaultStreamBatchSize = 100
+	// MaxStreamBatchSize caps the records per StreamETCMeisai message
+	MaxStreamBatchSize = 1000
+)
+
 // ETCServiceServer implements the ETC明細 gRPC service
 type ETCServiceServer struct {
 	proto.UnimplementedETCServiceServer
+	auditReporter
+	pageLimit
 	// In a real implementation, this would connect to a database
 	// For now, we'll use in-memory storage for testing
-	etcData map[int64]*proto.ETCMeisai
-	nextID  int64
+	mu           sync.RWMutex
+	etcData      map[int64]*proto.ETCMeisai
+	ids          IDSequence
+	maxBatchSize int
 }
 
 // NewETCServiceServer creates a new ETC service server
 func NewETCServiceServer() *ETCServiceServer {
 	server := &ETCServiceServer{
-		etcData: make(map[int64]*proto.ETCMeisai),
-		nextID:  1,
+		etcData:      make(map[int64]*proto.ETCMeisai),
+		ids:          NewAtomicSequence(1),
+		maxBatchSize: DefaultMaxBulkBatchSize,
 	}
 
 	// Add some test data
@@ -44,7 +68,6 @@ func (s *ETCServiceServer) seedTestData() {
 	testData := []*proto.ETCMeisai{
 		{
 			Id:             1,
-			Hash:           s.generateHashForData("2024-01-15", "Tokyo", "Osaka", "1234"),
 			Date:           "2024-01-15",
 			Time:           "08:30:00",
 			CarType:        "普通車",
@@ -63,7 +86,6 @@ func (s *ETCServiceServer) seedTestData() {
 		},
 		{
 			Id:             2,
-			Hash:           s.generateHashForData("2024-01-20", "Yokohama", "Nagoya", "5678"),
 			Date:           "2024-01-20",
 			Time:           "14:15:00",
 			CarType:        "普通車",
@@ -82,7 +104,6 @@ func (s *ETCServiceServer) seedTestData() {
 		},
 		{
 			Id:             3,
-			Hash:           s.generateHashForData("2024-02-01", "Kyoto", "Fukuoka", "9999"),
 			Date:           "2024-02-01",
 			Time:           "10:45:00",
 			CarType:        "大型車",
@@ -102,18 +123,57 @@ func (s *ETCServiceServer) seedTestData() {
 	}
 
 	for _, data := range testData {
+		data.Hash = s.generateHashForData(data)
 		s.etcData[data.Id] = data
-		if data.Id >= s.nextID {
-			s.nextID = data.Id + 1
-		}
+		s.ids.Observe(data.Id)
 	}
 }
 
-// generateHashForData generates a SHA256 hash for ETC data
-func (s *ETCServiceServer) generateHashForData(date, entrance, exit, carNumber string) string {
-	data := fmt.Sprintf("%s-%s-%s-%s", date, entrance, exit, carNumber)
+// SetIDSequence replaces the sequence used to allocate record IDs. The new
+// sequence is advanced past the IDs of existing records.
+func (s *ETCServiceServer) SetIDSequence(seq IDSequence) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id := range s.etcData {
+		seq.Observe(id)
+	}
+	s.ids = seq
+}
+
+// generateHashForData generates a versioned SHA256 hash identifying a trip.
+// Time, toll amount and card number are included so same-day trips between
+// the same gates, such as a round trip, don't collide.
+func (s *ETCServiceServer) generateHashForData(m *proto.ETCMeisai) string {
+	// Fields are NUL-separated since dates and card numbers contain dashes
+	data := strings.Join([]string{
+		m.Date,
+		m.Time,
+		m.EntranceIc,
+		m.ExitIc,
+		m.CarNumber,
+		strconv.FormatInt(int64(m.TollAmount), 10),
+		m.CardNumber,
+	}, "\x00")
 	hash := sha256.Sum256([]byte(data))
-	return hex.EncodeToString(hash[:])
+	return ETCHashVersion + ":" + hex.EncodeToString(hash[:])
+}
+
+// MigrateHashes recomputes the hash of every record not already hashed with
+// ETCHashVersion and returns how many records changed
+func (s *ETCServiceServer) MigrateHashes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	migrated := 0
+	for _, record := range s.etcData {
+		if strings.HasPrefix(record.Hash, ETCHashVersion+":") {
+			continue
+		}
+		record.Hash = s.generateHashForData(record)
+		migrated++
+	}
+	return migrated
 }
 
 // CreateETCMeisai creates a new ETC明細 record
@@ -123,25 +183,34 @@ func (s *ETCServiceServer) CreateETCMeisai(ctx context.Context, req *proto.Creat
 	}
 
 	etcMeisai := req.EtcMeisai
-	etcMeisai.Id = s.nextID
-	s.nextID++
+	if err := validateETCMeisai(etcMeisai); err != nil {
+		return nil, err
+	}
 
 	// Generate hash if not provided
 	if etcMeisai.Hash == "" {
-		etcMeisai.Hash = s.generateHashForData(etcMeisai.Date, etcMeisai.EntranceIc, etcMeisai.ExitIc, etcMeisai.CarNumber)
+		etcMeisai.Hash = s.generateHashForData(etcMeisai)
 	}
 
 	now := timestamppb.Now()
 	etcMeisai.CreatedAt = now
 	etcMeisai.UpdatedAt = now
 
+	// Allocate the ID while holding the lock so IDs increase in insertion order
+	s.mu.Lock()
+	etcMeisai.Id = s.ids.Next()
 	s.etcData[etcMeisai.Id] = etcMeisai
+	s.mu.Unlock()
+	s.recordAudit(ctx, "CreateETCMeisai", AuditEntityETCMeisai, etcAuditID(etcMeisai.Id))
 
 	return &proto.ETCMeisaiResponse{EtcMeisai: etcMeisai}, nil
 }
 
 // GetETCMeisai retrieves an ETC明細 record by ID
 func (s *ETCServiceServer) GetETCMeisai(ctx context.Context, req *proto.GetETCMeisaiRequest) (*proto.ETCMeisaiResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	etcMeisai, exists := s.etcData[req.Id]
 	if !exists {
 		return nil, status.Error(codes.NotFound, "ETC明細 not found")
@@ -152,6 +221,9 @@ func (s *ETCServiceServer) GetETCMeisai(ctx context.Context, req *proto.GetETCMe
 
 // UpdateETCMeisai updates an existing ETC明細 record
 func (s *ETCServiceServer) UpdateETCMeisai(ctx context.Context, req *proto.UpdateETCMeisaiRequest) (*proto.ETCMeisaiResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	existing, exists := s.etcData[req.Id]
 	if !exists {
 		return nil, status.Error(codes.NotFound, "ETC明細 not found")
@@ -160,6 +232,9 @@ func (s *ETCServiceServer) UpdateETCMeisai(ctx context.Context, req *proto.Updat
 	if req.EtcMeisai == nil {
 		return nil, status.Error(codes.InvalidArgument, "ETC明細 data is required")
 	}
+	if err := validateETCMeisai(req.EtcMeisai); err != nil {
+		return nil, err
+	}
 
 	updated := req.EtcMeisai
 	updated.Id = req.Id
@@ -167,35 +242,62 @@ func (s *ETCServiceServer) UpdateETCMeisai(ctx context.Context, req *proto.Updat
 	updated.UpdatedAt = timestamppb.Now()
 
 	s.etcData[req.Id] = updated
+	s.recordAudit(ctx, "UpdateETCMeisai", AuditEntityETCMeisai, etcAuditID(req.Id))
 
 	return &proto.ETCMeisaiResponse{EtcMeisai: updated}, nil
 }
 
 // DeleteETCMeisai deletes an ETC明細 record
 func (s *ETCServiceServer) DeleteETCMeisai(ctx context.Context, req *proto.DeleteETCMeisaiRequest) (*emptypb.Empty, error) {
-	if _, exists := s.etcData[req.Id]; !exists {
-		return nil, status.Error(codes.NotFound, "ETC明細 not found")
-	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	delete(s.etcData, req.Id)
+	if err := s.deleteLocked(req.Id); err != nil {
+		return nil, err
+	}
+	s.recordAudit(ctx, "DeleteETCMeisai", AuditEntityETCMeisai, etcAuditID(req.Id))
 
 	return &emptypb.Empty{}, nil
 }
 
-// ListETCMeisai lists ETC明細 records with pagination
-func (s *ETCServiceServer) ListETCMeisai(ctx context.Context, req *proto.ListETCMeisaiRequest) (*proto.ListETCMeisaiResponse, error) {
-	pageSize := req.PageSize
-	if pageSize <= 0 {
-		pageSize = 10
+// deleteLocked removes the record with id. Callers must hold s.mu.
+func (s *ETCServiceServer) deleteLocked(id int64) error {
+	if _, exists := s.etcData[id]; !exists {
+		return status.Error(codes.NotFound, "ETC明細 not found")
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	delete(s.etcData, id)
+	return nil
+}
+
+// ListETCMeisai lists ETC明細 records with pagination, oldest first. The
+// search, date and user filters combine, so every set filter must match.
+func (s *ETCServiceServer) ListETCMeisai(ctx context.Context, req *proto.ListETCMeisaiRequest) (*proto.ListETCMeisaiResponse, error) {
+	startDate, endDate, err := normalizeDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
 	}
 
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pageSize := s.clampPageSize(req.PageSize)
+
+	search := strings.ToLower(strings.TrimSpace(req.Search))
 	var allRecords []*proto.ETCMeisai
 	for _, record := range s.etcData {
+		if !inDateRange(record, startDate, endDate) {
+			continue
+		}
+		if req.UserId != "" && record.UserId != req.UserId {
+			continue
+		}
+		if search != "" && !matchesSearch(record, search) {
+			continue
+		}
 		allRecords = append(allRecords, record)
 	}
+	sortOldestFirst(allRecords)
 
 	// Simple pagination logic
 	startIndex := 0
@@ -210,7 +312,7 @@ func (s *ETCServiceServer) ListETCMeisai(ctx context.Context, req *proto.ListETC
 		endIndex = len(allRecords)
 	}
 
-	var paginatedRecords []*proto.ETCMeisai
+	paginatedRecords := []*proto.ETCMeisai{}
 	if startIndex < len(allRecords) {
 		paginatedRecords = allRecords[startIndex:endIndex]
 	}
@@ -224,20 +326,106 @@ func (s *ETCServiceServer) ListETCMeisai(ctx context.Context, req *proto.ListETC
 		EtcMeisaiList:   paginatedRecords,
 		NextPageToken:   nextPageToken,
 		TotalCount:      int32(len(allRecords)),
+		PageSize:        pageSize,
 	}, nil
 }
 
+// StreamETCMeisai sends the records matching the date and user filters,
+// oldest first, in batches of req.BatchSize. The matching records are
+// collected up front so the lock isn't held while the client reads, and the
+// stream stops with the context's error once the client cancels.
+func (s *ETCServiceServer) StreamETCMeisai(req *proto.StreamETCMeisaiRequest, stream proto.ETCService_StreamETCMeisaiServer) error {
+	batchSize := int(req.BatchSize)
+	if batchSize <= 0 {
+		batchSize = DefaultStreamBatchSize
+	}
+	if batchSize > MaxStreamBatchSize {
+		batchSize = MaxStreamBatchSize
+	}
+	startDate, endDate, err := normalizeDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	var records []*proto.ETCMeisai
+	for _, record := range s.etcData {
+		if !inDateRange(record, startDate, endDate) {
+			continue
+		}
+		if req.UserId != "" && record.UserId != req.UserId {
+			continue
+		}
+		records = append(records, record)
+	}
+	s.mu.RUnlock()
+	sortOldestFirst(records)
+
+	ctx := stream.Context()
+	for start := 0; start < len(records); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		end := start + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		if err := stream.Send(&proto.StreamETCMeisaiResponse{EtcMeisaiList: records[start:end]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRecordCount returns the current number of ETC明細 records
+func (s *ETCServiceServer) GetRecordCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.etcData)
+}
+
+// SetMaxBatchSize sets the maximum number of records accepted by bulk operations
+func (s *ETCServiceServer) SetMaxBatchSize(max int) {
+	if max <= 0 {
+		max = DefaultMaxBulkBatchSize
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxBatchSize = max
+}
+
+// validateBatchSize rejects bulk requests larger than the configured maximum
+func (s *ETCServiceServer) validateBatchSize(size int) error {
+	s.mu.RLock()
+	maxBatchSize := s.maxBatchSize
+	s.mu.RUnlock()
+
+	if size > maxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch size %d exceeds maximum of %d", size, maxBatchSize)
+	}
+	return nil
+}
+
+// bulkRowError describes the failure of the row at index in a bulk request
+func bulkRowError(index int, err error) string {
+	return fmt.Sprintf("row %d: %s", index, status.Convert(err).Message())
+}
+
 // BulkCreateETCMeisai creates multiple ETC明細 records
 func (s *ETCServiceServer) BulkCreateETCMeisai(ctx context.Context, req *proto.BulkCreateETCMeisaiRequest) (*proto.BulkCreateETCMeisaiResponse, error) {
+	if err := s.validateBatchSize(len(req.EtcMeisaiList)); err != nil {
+		return nil, err
+	}
+
 	var created []*proto.ETCMeisai
 	var errorMessages []string
 	successCount := 0
 
-	for _, etcMeisai := range req.EtcMeisaiList {
+	for i, etcMeisai := range req.EtcMeisaiList {
 		createReq := &proto.CreateETCMeisaiRequest{EtcMeisai: etcMeisai}
 		resp, err := s.CreateETCMeisai(ctx, createReq)
 		if err != nil {
-			errorMessages = append(errorMessages, err.Error())
+			errorMessages = append(errorMessages, bulkRowError(i, err))
 		} else {
 			created = append(created, resp.EtcMeisai)
 			successCount++
@@ -254,15 +442,19 @@ func (s *ETCServiceServer) BulkCreateETCMeisai(ctx context.Context, req *proto.B
 
 // BulkUpdateETCMeisai updates multiple ETC明細 records
 func (s *ETCServiceServer) BulkUpdateETCMeisai(ctx context.Context, req *proto.BulkUpdateETCMeisaiRequest) (*proto.BulkUpdateETCMeisaiResponse, error) {
+	if err := s.validateBatchSize(len(req.EtcMeisaiList)); err != nil {
+		return nil, err
+	}
+
 	var updated []*proto.ETCMeisai
 	var errorMessages []string
 	successCount := 0
 
-	for _, etcMeisai := range req.EtcMeisaiList {
+	for i, etcMeisai := range req.EtcMeisaiList {
 		updateReq := &proto.UpdateETCMeisaiRequest{Id: etcMeisai.Id, EtcMeisai: etcMeisai}
 		resp, err := s.UpdateETCMeisai(ctx, updateReq)
 		if err != nil {
-			errorMessages = append(errorMessages, err.Error())
+			errorMessages = append(errorMessages, bulkRowError(i, err))
 		} else {
 			updated = append(updated, resp.EtcMeisai)
 			successCount++
@@ -277,25 +469,58 @@ func (s *ETCServiceServer) BulkUpdateETCMeisai(ctx context.Context, req *proto.B
 	}, nil
 }
 
-// GetETCMeisaiByDateRange retrieves ETC明細 records within a date range
+// BulkDeleteETCMeisai deletes multiple ETC明細 records under a single lock,
+// reporting the IDs that did not exist
+func (s *ETCServiceServer) BulkDeleteETCMeisai(ctx context.Context, req *proto.BulkDeleteETCMeisaiRequest) (*proto.BulkDeleteETCMeisaiResponse, error) {
+	if err := s.validateBatchSize(len(req.Ids)); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &proto.BulkDeleteETCMeisaiResponse{}
+	for _, id := range req.Ids {
+		if err := s.deleteLocked(id); err != nil {
+			resp.NotFoundIds = append(resp.NotFoundIds, id)
+			resp.ErrorCount++
+			continue
+		}
+		s.recordAudit(ctx, "BulkDeleteETCMeisai", AuditEntityETCMeisai, etcAuditID(id))
+		resp.SuccessCount++
+	}
+
+	return resp, nil
+}
+
+// GetETCMeisaiByDateRange retrieves ETC明細 records within a date range, oldest
+// first, with amount totals over the whole range rather than the page
 func (s *ETCServiceServer) GetETCMeisaiByDateRange(ctx context.Context, req *proto.GetETCMeisaiByDateRangeRequest) (*proto.ListETCMeisaiResponse, error) {
+	startDate, endDate, err := normalizeDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	var filteredRecords []*proto.ETCMeisai
 
+	var totalAmount, totalToll, totalDiscount int64
 	for _, record := range s.etcData {
-		if req.StartDate != "" && record.Date < req.StartDate {
-			continue
+		if inDateRange(record, startDate, endDate) {
+			filteredRecords = append(filteredRecords, record)
+			totalAmount += int64(record.FinalAmount)
+			totalToll += int64(record.TollAmount)
+			totalDiscount += int64(record.DiscountAmount)
 		}
-		if req.EndDate != "" && record.Date > req.EndDate {
-			continue
-		}
-		filteredRecords = append(filteredRecords, record)
 	}
 
+	// Oldest first, so page tokens stay stable across calls
+	sortOldestFirst(filteredRecords)
+
 	// Apply pagination
-	pageSize := req.PageSize
-	if pageSize <= 0 {
-		pageSize = 10
-	}
+	pageSize := s.clampPageSize(req.PageSize)
 
 	startIndex := 0
 	if req.PageToken != "" {
@@ -309,7 +534,7 @@ func (s *ETCServiceServer) GetETCMeisaiByDateRange(ctx context.Context, req *pro
 		endIndex = len(filteredRecords)
 	}
 
-	var paginatedRecords []*proto.ETCMeisai
+	paginatedRecords := []*proto.ETCMeisai{}
 	if startIndex < len(filteredRecords) {
 		paginatedRecords = filteredRecords[startIndex:endIndex]
 	}
@@ -320,14 +545,21 @@ func (s *ETCServiceServer) GetETCMeisaiByDateRange(ctx context.Context, req *pro
 	}
 
 	return &proto.ListETCMeisaiResponse{
-		EtcMeisaiList:   paginatedRecords,
-		NextPageToken:   nextPageToken,
-		TotalCount:      int32(len(filteredRecords)),
+		EtcMeisaiList: paginatedRecords,
+		NextPageToken: nextPageToken,
+		TotalCount:    int32(len(filteredRecords)),
+		TotalAmount:   totalAmount,
+		TotalToll:     totalToll,
+		TotalDiscount: totalDiscount,
+		PageSize:      pageSize,
 	}, nil
 }
 
 // GetETCMeisaiByHash retrieves ETC明細 record by hash
 func (s *ETCServiceServer) GetETCMeisaiByHash(ctx context.Context, req *proto.GetETCMeisaiByHashRequest) (*proto.ETCMeisaiResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	for _, record := range s.etcData {
 		if record.Hash == req.Hash {
 			return &proto.ETCMeisaiResponse{EtcMeisai: record}, nil
@@ -339,6 +571,9 @@ func (s *ETCServiceServer) GetETCMeisaiByHash(ctx context.Context, req *proto.Ge
 
 // GetUnmappedETCMeisai retrieves ETC明細 records that are not mapped (example implementation)
 func (s *ETCServiceServer) GetUnmappedETCMeisai(ctx context.Context, req *proto.GetUnmappedETCMeisaiRequest) (*proto.ListETCMeisaiResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	// For demonstration, we'll consider records without UserId as unmapped
 	var unmappedRecords []*proto.ETCMeisai
 
@@ -349,10 +584,7 @@ func (s *ETCServiceServer) GetUnmappedETCMeisai(ctx context.Context, req *proto.
 	}
 
 	// Apply pagination
-	pageSize := req.PageSize
-	if pageSize <= 0 {
-		pageSize = 10
-	}
+	pageSize := s.clampPageSize(req.PageSize)
 
 	startIndex := 0
 	if req.PageToken != "" {
@@ -366,7 +598,7 @@ func (s *ETCServiceServer) GetUnmappedETCMeisai(ctx context.Context, req *proto.
 		endIndex = len(unmappedRecords)
 	}
 
-	var paginatedRecords []*proto.ETCMeisai
+	paginatedRecords := []*proto.ETCMeisai{}
 	if startIndex < len(unmappedRecords) {
 		paginatedRecords = unmappedRecords[startIndex:endIndex]
 	}
@@ -380,19 +612,21 @@ func (s *ETCServiceServer) GetUnmappedETCMeisai(ctx context.Context, req *proto.
 		EtcMeisaiList:   paginatedRecords,
 		NextPageToken:   nextPageToken,
 		TotalCount:      int32(len(unmappedRecords)),
+		PageSize:        pageSize,
 	}, nil
 }
 
 // CheckDuplicatesByHash checks for duplicate hashes
 func (s *ETCServiceServer) CheckDuplicatesByHash(ctx context.Context, req *proto.CheckDuplicatesByHashRequest) (*proto.CheckDuplicatesResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	var duplicates []string
 
+	groups := s.groupByHashLocked("", "")
 	for _, hash := range req.Hashes {
-		for _, record := range s.etcData {
-			if record.Hash == hash {
-				duplicates = append(duplicates, hash)
-				break
-			}
+		if len(groups[hash]) > 0 {
+			duplicates = append(duplicates, hash)
 		}
 	}
 
@@ -402,32 +636,187 @@ func (s *ETCServiceServer) CheckDuplicatesByHash(ctx context.Context, req *proto
 	}, nil
 }
 
+// DeduplicateETCMeisai deletes records that share a hash with a lower-ID
+// record, optionally only among records within a date range
+func (s *ETCServiceServer) DeduplicateETCMeisai(ctx context.Context, req *proto.DeduplicateRequest) (*proto.DeduplicateResponse, error) {
+	startDate, endDate, err := normalizeDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &proto.DeduplicateResponse{}
+	for hash, records := range s.groupByHashLocked(startDate, endDate) {
+		if hash == "" || len(records) < 2 {
+			continue
+		}
+
+		keep := records[0]
+		for _, record := range records[1:] {
+			if record.Id < keep.Id {
+				keep = record
+			}
+		}
+		for _, record := range records {
+			if record.Id == keep.Id {
+				continue
+			}
+			if err := s.deleteLocked(record.Id); err == nil {
+				s.recordAudit(ctx, "DeduplicateETCMeisai", AuditEntityETCMeisai, etcAuditID(record.Id))
+				resp.RemovedIds = append(resp.RemovedIds, record.Id)
+			}
+		}
+	}
+
+	sort.Slice(resp.RemovedIds, func(i, j int) bool {
+		return resp.RemovedIds[i] < resp.RemovedIds[j]
+	})
+	resp.RemovedCount = int32(len(resp.RemovedIds))
+	return resp, nil
+}
+
+// groupByHashLocked groups the records within the date range by hash.
+// Callers must hold s.mu.
+func (s *ETCServiceServer) groupByHashLocked(startDate, endDate string) map[string][]*proto.ETCMeisai {
+	groups := make(map[string][]*proto.ETCMeisai)
+	for _, record := range s.etcData {
+		if inDateRange(record, startDate, endDate) {
+			groups[record.Hash] = append(groups[record.Hash], record)
+		}
+	}
+	return groups
+}
+
+// inDateRange reports whether the record's date falls within the inclusive
+// range; empty bounds are open
+// Layouts of the ETC明細 date and time fields
+const (
+	etcDateLayout = "2006-01-02"
+	etcTimeLayout = "15:04:05"
+)
+
+// validateETCMeisai checks that the date is a real YYYY-MM-DD calendar date,
+// the time (when set) is HH:MM:SS, and the amounts are non-negative with the
+// final amount equal to the toll less the discount
+func validateETCMeisai(m *proto.ETCMeisai) error {
+	var v fieldViolations
+
+	if m.Date == "" {
+		v.Add("date", ViolationRequired, "date is required")
+	} else if _, err := time.Parse(etcDateLayout, m.Date); err != nil {
+		v.Add("date", ViolationInvalidFormat, fmt.Sprintf("date %q must be a valid YYYY-MM-DD date", m.Date))
+	}
+	if m.Time != "" {
+		if _, err := time.Parse(etcTimeLayout, m.Time); err != nil {
+			v.Add("time", ViolationInvalidFormat, fmt.Sprintf("time %q must be HH:MM:SS", m.Time))
+		}
+	}
+
+	amounts := []struct {
+		field string
+		value int32
+	}{
+		{"toll_amount", m.TollAmount},
+		{"discount_amount", m.DiscountAmount},
+		{"final_amount", m.FinalAmount},
+		{"distance", m.Distance},
+	}
+	negative := false
+	for _, amount := range amounts {
+		if amount.value < 0 {
+			v.Add(amount.field, ViolationOutOfRange, fmt.Sprintf("%s must not be negative", amount.field))
+			negative = true
+		}
+	}
+	if !negative && m.FinalAmount != m.TollAmount-m.DiscountAmount {
+		v.Add("final_amount", ViolationInconsistent, fmt.Sprintf(
+			"final_amount %d must equal toll_amount %d minus discount_amount %d",
+			m.FinalAmount, m.TollAmount, m.DiscountAmount))
+	}
+
+	return v.Err()
+}
+
+// sortOldestFirst orders records by date, time and then ID
+func sortOldestFirst(records []*proto.ETCMeisai) {
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.Time != b.Time {
+			return a.Time < b.Time
+		}
+		return a.Id < b.Id
+	})
+}
+
+func inDateRange(record *proto.ETCMeisai, startDate, endDate string) bool {
+	if startDate != "" && record.Date < startDate {
+		return false
+	}
+	if endDate != "" && record.Date > endDate {
+		return false
+	}
+	return true
+}
+
+// matchesSearch reports whether the lower-cased search term appears in the
+// record's entrance IC, exit IC or car number. strings.Contains compares
+// whole UTF-8 sequences, so a Japanese term never matches part of a rune.
+func matchesSearch(record *proto.ETCMeisai, search string) bool {
+	for _, field := range []string{record.EntranceIc, record.ExitIc, record.CarNumber} {
+		if strings.Contains(strings.ToLower(field), search) {
+			return true
+		}
+	}
+	return false
+}
+
 // GenerateHash generates a hash for ETC明細 data
 func (s *ETCServiceServer) GenerateHash(ctx context.Context, req *proto.GenerateHashRequest) (*proto.GenerateHashResponse, error) {
 	if req.EtcMeisai == nil {
 		return nil, status.Error(codes.InvalidArgument, "ETC明細 data is required")
 	}
 
-	hash := s.generateHashForData(
-		req.EtcMeisai.Date,
-		req.EtcMeisai.EntranceIc,
-		req.EtcMeisai.ExitIc,
-		req.EtcMeisai.CarNumber,
-	)
+	return &proto.GenerateHashResponse{Hash: s.generateHashForData(req.EtcMeisai)}, nil
+}
+
+// BatchGenerateHash generates hashes for a list of ETC明細 records in request
+// order; like GenerateHash it doesn't read or change stored records
+func (s *ETCServiceServer) BatchGenerateHash(ctx context.Context, req *proto.BatchGenerateHashRequest) (*proto.BatchGenerateHashResponse, error) {
+	if err := s.validateBatchSize(len(req.EtcMeisaiList)); err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(req.EtcMeisaiList))
+	for i, etcMeisai := range req.EtcMeisaiList {
+		if etcMeisai == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "ETC明細 data is required at index %d", i)
+		}
+		hashes[i] = s.generateHashForData(etcMeisai)
+	}
 
-	return &proto.GenerateHashResponse{Hash: hash}, nil
+	return &proto.BatchGenerateHashResponse{Hashes: hashes}, nil
 }
 
 // GetETCSummary returns summary statistics for ETC明細 data
 func (s *ETCServiceServer) GetETCSummary(ctx context.Context, req *proto.GetETCSummaryRequest) (*proto.GetETCSummaryResponse, error) {
+	startDate, endDate, err := normalizeDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	var filteredRecords []*proto.ETCMeisai
 
 	for _, record := range s.etcData {
 		// Filter by date range
-		if req.StartDate != "" && record.Date < req.StartDate {
-			continue
-		}
-		if req.EndDate != "" && record.Date > req.EndDate {
+		if !inDateRange(record, startDate, endDate) {
 			continue
 		}
 		// Filter by user ID
@@ -483,6 +872,9 @@ func (s *ETCServiceServer) GetETCSummary(ctx context.Context, req *proto.GetETCS
 
 // GetMonthlyStats returns detailed monthly statistics
 func (s *ETCServiceServer) GetMonthlyStats(ctx context.Context, req *proto.GetMonthlyStatsRequest) (*proto.GetMonthlyStatsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	targetMonth := fmt.Sprintf("%04d-%02d", req.Year, req.Month)
 
 	var monthlyRecords []*proto.ETCMeisai
@@ -541,4 +933,80 @@ func (s *ETCServiceServer) GetMonthlyStats(ctx context.Context, req *proto.GetMo
 		AverageAmount:    averageAmount,
 		DailyStats:       dailyStatsList,
 	}, nil
+}
+
+// MaxDailyStatsDays caps the range of GetDailyStats, since filling gaps
+// returns one entry per day
+const MaxDailyStatsDays = 366
+
+// GetDailyStats aggregates ETC明細 records per day over an inclusive date
+// range, sorted by date. Days without trips are only included when fill_gaps
+// is set.
+func (s *ETCServiceServer) GetDailyStats(ctx context.Context, req *proto.GetDailyStatsRequest) (*proto.GetDailyStatsResponse, error) {
+	startDate, endDate, err := normalizeDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var v fieldViolations
+	start, startErr := time.Parse(etcDateLayout, startDate)
+	if startErr != nil {
+		v.Add("start_date", ViolationInvalidFormat, fmt.Sprintf("start_date %q must be a valid YYYY-MM-DD date", req.StartDate))
+	}
+	end, endErr := time.Parse(etcDateLayout, endDate)
+	if endErr != nil {
+		v.Add("end_date", ViolationInvalidFormat, fmt.Sprintf("end_date %q must be a valid YYYY-MM-DD date", req.EndDate))
+	}
+	if startErr == nil && endErr == nil {
+		if end.Before(start) {
+			v.Add("end_date", ViolationOutOfRange, "end_date must not be before start_date")
+		} else if days := int(end.Sub(start).Hours()/24) + 1; days > MaxDailyStatsDays {
+			v.Add("end_date", ViolationOutOfRange, fmt.Sprintf("date range must not exceed %d days", MaxDailyStatsDays))
+		}
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &proto.GetDailyStatsResponse{StartDate: startDate, EndDate: endDate}
+	byDate := make(map[string]*proto.ETCDateStat)
+
+	s.mu.RLock()
+	for _, record := range s.etcData {
+		if req.UserId != "" && record.UserId != req.UserId {
+			continue
+		}
+		if !inDateRange(record, startDate, endDate) {
+			continue
+		}
+
+		stat, exists := byDate[record.Date]
+		if !exists {
+			stat = &proto.ETCDateStat{Date: record.Date}
+			byDate[record.Date] = stat
+		}
+		stat.TransactionCount++
+		stat.TotalAmount += int64(record.FinalAmount)
+		resp.TransactionCount++
+		resp.TotalAmount += int64(record.FinalAmount)
+	}
+	s.mu.RUnlock()
+
+	if req.FillGaps {
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			date := day.Format(etcDateLayout)
+			if _, exists := byDate[date]; !exists {
+				byDate[date] = &proto.ETCDateStat{Date: date}
+			}
+		}
+	}
+
+	resp.DailyStats = make([]*proto.ETCDateStat, 0, len(byDate))
+	for _, stat := range byDate {
+		resp.DailyStats = append(resp.DailyStats, stat)
+	}
+	sort.Slice(resp.DailyStats, func(i, j int) bool {
+		return resp.DailyStats[i].Date < resp.DailyStats[j].Date
+	})
+	return resp, nil
 }
\ No newline at end of file
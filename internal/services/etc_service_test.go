@@ -0,0 +1,739 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yhonda-ohishi/db-handler-server/internal/client"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSetMaxBatchSizeWhileServing(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	// Run with -race: the limit may change while bulk requests are checked
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(2)
+		go func(max int) {
+			defer wg.Done()
+			service.SetMaxBatchSize(max)
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, _ = service.BulkDeleteETCMeisai(ctx, &pb.BulkDeleteETCMeisaiRequest{Ids: []int64{1000, 1001}})
+		}()
+	}
+	wg.Wait()
+
+	service.SetMaxBatchSize(1)
+	_, err := service.BulkDeleteETCMeisai(ctx, &pb.BulkDeleteETCMeisaiRequest{Ids: []int64{1000, 1001}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument once the limit is 1, got %v", err)
+	}
+}
+
+func TestBulkUpdateETCMeisaiBatchSize(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+	service.SetMaxBatchSize(3)
+
+	batch := func(ids ...int64) []*pb.ETCMeisai {
+		records := make([]*pb.ETCMeisai, 0, len(ids))
+		for _, id := range ids {
+			resp, err := service.GetETCMeisai(ctx, &pb.GetETCMeisaiRequest{Id: id})
+			if err != nil {
+				t.Fatalf("failed to get record %d: %v", id, err)
+			}
+			record := proto.Clone(resp.EtcMeisai).(*pb.ETCMeisai)
+			record.CarNumber = "bulk updated"
+			records = append(records, record)
+		}
+		return records
+	}
+
+	t.Run("over limit rejected", func(t *testing.T) {
+		records := append(batch(1, 2, 3), &pb.ETCMeisai{Id: 4})
+
+		_, err := service.BulkUpdateETCMeisai(ctx, &pb.BulkUpdateETCMeisaiRequest{EtcMeisaiList: records})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+
+		// Nothing was applied
+		resp, _ := service.GetETCMeisai(ctx, &pb.GetETCMeisaiRequest{Id: 1})
+		if resp.EtcMeisai.CarNumber == "bulk updated" {
+			t.Error("expected rejected batch not to update any record")
+		}
+	})
+
+	t.Run("at limit succeeds", func(t *testing.T) {
+		resp, err := service.BulkUpdateETCMeisai(ctx, &pb.BulkUpdateETCMeisaiRequest{EtcMeisaiList: batch(1, 2, 3)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.SuccessCount != 3 || resp.ErrorCount != 0 {
+			t.Errorf("expected 3 successes and no errors, got %d and %d", resp.SuccessCount, resp.ErrorCount)
+		}
+	})
+
+	t.Run("bulk create shares the limit", func(t *testing.T) {
+		records := []*pb.ETCMeisai{{}, {}, {}, {}}
+		_, err := service.BulkCreateETCMeisai(ctx, &pb.BulkCreateETCMeisaiRequest{EtcMeisaiList: records})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestCreateETCMeisaiConcurrentIDsAreUnique(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+	service.SetIDSequence(NewAtomicSequence(1000))
+
+	const workers, perWorker = 20, 50
+	ids := make(chan int64, workers*perWorker)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				resp, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{
+					EtcMeisai: &pb.ETCMeisai{Date: "2024-01-15", CarNumber: "concurrent"},
+				})
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				ids <- resp.EtcMeisai.Id
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[int64]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("id %d allocated more than once", id)
+		}
+		if id < 1000 {
+			t.Errorf("expected ids to start at the configured sequence, got %d", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != workers*perWorker {
+		t.Errorf("expected %d distinct ids, got %d", workers*perWorker, len(seen))
+	}
+}
+
+func TestSetIDSequenceSkipsExistingIDs(t *testing.T) {
+	service := NewETCServiceServer()
+	service.SetIDSequence(NewAtomicSequence(1))
+
+	resp, err := service.CreateETCMeisai(context.Background(), &pb.CreateETCMeisaiRequest{EtcMeisai: &pb.ETCMeisai{Date: "2024-01-15"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, existing := range []int64{1, 2, 3} {
+		if resp.EtcMeisai.Id == existing {
+			t.Fatalf("new record reused seeded id %d", existing)
+		}
+	}
+}
+
+func TestBulkDeleteETCMeisai(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	resp, err := service.BulkDeleteETCMeisai(ctx, &pb.BulkDeleteETCMeisaiRequest{Ids: []int64{1, 404, 2, 405}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.SuccessCount != 2 || resp.ErrorCount != 2 {
+		t.Errorf("expected 2 successes and 2 errors, got %d and %d", resp.SuccessCount, resp.ErrorCount)
+	}
+	if len(resp.NotFoundIds) != 2 || resp.NotFoundIds[0] != 404 || resp.NotFoundIds[1] != 405 {
+		t.Errorf("expected not found ids [404 405], got %v", resp.NotFoundIds)
+	}
+
+	for _, id := range []int64{1, 2} {
+		if _, err := service.GetETCMeisai(ctx, &pb.GetETCMeisaiRequest{Id: id}); status.Code(err) != codes.NotFound {
+			t.Errorf("expected record %d to be deleted, got %v", id, err)
+		}
+	}
+	if _, err := service.GetETCMeisai(ctx, &pb.GetETCMeisaiRequest{Id: 3}); err != nil {
+		t.Errorf("expected record 3 to remain, got %v", err)
+	}
+
+	// Deleting the same IDs again finds none of them
+	resp, err = service.BulkDeleteETCMeisai(ctx, &pb.BulkDeleteETCMeisaiRequest{Ids: []int64{1, 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.SuccessCount != 0 || len(resp.NotFoundIds) != 2 {
+		t.Errorf("expected no deletions and 2 not found ids, got %d and %v", resp.SuccessCount, resp.NotFoundIds)
+	}
+}
+
+func TestDeduplicateETCMeisai(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	create := func(date, hash string) int64 {
+		resp, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{
+			EtcMeisai: &pb.ETCMeisai{Date: date, Hash: hash, CarNumber: "dedup"},
+		})
+		if err != nil {
+			t.Fatalf("failed to create record: %v", err)
+		}
+		return resp.EtcMeisai.Id
+	}
+
+	a1 := create("2024-03-01", "dup-a")
+	a2 := create("2024-03-01", "dup-a")
+	a3 := create("2024-03-02", "dup-a")
+	b1 := create("2024-03-05", "dup-b")
+	b2 := create("2024-03-05", "dup-b")
+	unique := create("2024-03-01", "unique")
+
+	// Scoped to a range, only duplicates inside it are considered
+	resp, err := service.DeduplicateETCMeisai(ctx, &pb.DeduplicateRequest{StartDate: "2024-03-01", EndDate: "2024-03-01"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.RemovedCount != 1 || len(resp.RemovedIds) != 1 || resp.RemovedIds[0] != a2 {
+		t.Errorf("expected only record %d removed, got %v", a2, resp.RemovedIds)
+	}
+
+	resp, err = service.DeduplicateETCMeisai(ctx, &pb.DeduplicateRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.RemovedCount != 2 {
+		t.Errorf("expected 2 records removed, got %d (%v)", resp.RemovedCount, resp.RemovedIds)
+	}
+
+	exists := func(id int64) bool {
+		_, err := service.GetETCMeisai(ctx, &pb.GetETCMeisaiRequest{Id: id})
+		return err == nil
+	}
+	for _, id := range []int64{a1, b1, unique} {
+		if !exists(id) {
+			t.Errorf("expected record %d to survive", id)
+		}
+	}
+	for _, id := range []int64{a2, a3, b2} {
+		if exists(id) {
+			t.Errorf("expected record %d to be removed", id)
+		}
+	}
+
+	dupes, err := service.CheckDuplicatesByHash(ctx, &pb.CheckDuplicatesByHashRequest{Hashes: []string{"dup-a", "missing"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dupes.DuplicateCount != 1 || dupes.DuplicateHashes[0] != "dup-a" {
+		t.Errorf("expected dup-a to still be reported as existing, got %v", dupes.DuplicateHashes)
+	}
+}
+
+func TestGetETCMeisaiByDateRangeSortsAndTotals(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	// Created out of order; the 2030-01-20 record falls outside the range
+	for _, record := range []*pb.ETCMeisai{
+		{Date: "2030-01-10", Time: "09:00:00", CarNumber: "c", TollAmount: 3000, DiscountAmount: 300, FinalAmount: 2700},
+		{Date: "2030-01-02", Time: "18:00:00", CarNumber: "b", TollAmount: 2000, DiscountAmount: 0, FinalAmount: 2000},
+		{Date: "2030-01-20", Time: "08:00:00", CarNumber: "x", TollAmount: 9000, DiscountAmount: 900, FinalAmount: 8100},
+		{Date: "2030-01-02", Time: "07:30:00", CarNumber: "a", TollAmount: 1000, DiscountAmount: 100, FinalAmount: 900},
+	} {
+		if _, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{EtcMeisai: record}); err != nil {
+			t.Fatalf("failed to create record: %v", err)
+		}
+	}
+
+	req := &pb.GetETCMeisaiByDateRangeRequest{StartDate: "2030-01-01", EndDate: "2030-01-15", PageSize: 2}
+	first, err := service.GetETCMeisaiByDateRange(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(first.EtcMeisaiList) != 2 || first.EtcMeisaiList[0].CarNumber != "a" || first.EtcMeisaiList[1].CarNumber != "b" {
+		t.Errorf("expected the first page to hold records a and b, got %v", first.EtcMeisaiList)
+	}
+	if first.TotalCount != 3 {
+		t.Errorf("expected total count 3, got %d", first.TotalCount)
+	}
+	if first.TotalAmount != 5600 || first.TotalToll != 6000 || first.TotalDiscount != 400 {
+		t.Errorf("expected totals 5600/6000/400, got %d/%d/%d", first.TotalAmount, first.TotalToll, first.TotalDiscount)
+	}
+
+	req.PageToken = first.NextPageToken
+	second, err := service.GetETCMeisaiByDateRange(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second.EtcMeisaiList) != 1 || second.EtcMeisaiList[0].CarNumber != "c" {
+		t.Errorf("expected the second page to hold record c, got %v", second.EtcMeisaiList)
+	}
+	if second.NextPageToken != "" {
+		t.Errorf("expected no further pages, got token %q", second.NextPageToken)
+	}
+	if second.TotalAmount != first.TotalAmount {
+		t.Errorf("expected the same totals on every page, got %d and %d", first.TotalAmount, second.TotalAmount)
+	}
+}
+
+func TestListETCMeisaiSearch(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	for _, record := range []*pb.ETCMeisai{
+		{Date: "2030-02-01", CarNumber: "a", UserId: "user-1", EntranceIc: "東名川崎", ExitIc: "名古屋"},
+		{Date: "2030-02-02", CarNumber: "b", UserId: "user-2", EntranceIc: "横浜青葉", ExitIc: "東名江田"},
+		{Date: "2030-02-03", CarNumber: "c", UserId: "user-1", EntranceIc: "新東名浜松", ExitIc: "豊田"},
+		{Date: "2030-03-01", CarNumber: "d", UserId: "user-1", EntranceIc: "東名川崎", ExitIc: "厚木"},
+		{Date: "2030-02-04", CarNumber: "e", UserId: "user-1", EntranceIc: "名神 Kyoto-Higashi", ExitIc: "吹田"},
+		{Date: "2030-02-05", CarNumber: "f", UserId: "user-1", EntranceIc: "中央道 八王子", ExitIc: "大月"},
+	} {
+		if _, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{EtcMeisai: record}); err != nil {
+			t.Fatalf("failed to create record: %v", err)
+		}
+	}
+
+	carNumbers := func(resp *pb.ListETCMeisaiResponse) string {
+		var cars []string
+		for _, record := range resp.EtcMeisaiList {
+			cars = append(cars, record.CarNumber)
+		}
+		return strings.Join(cars, ",")
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.ListETCMeisaiRequest
+		want string
+	}{
+		{"entrance or exit IC", &pb.ListETCMeisaiRequest{Search: "東名", StartDate: "2030-01-01"}, "a,b,c,d"},
+		{"case-insensitive", &pb.ListETCMeisaiRequest{Search: "kyoto", StartDate: "2030-01-01"}, "e"},
+		{"with date range", &pb.ListETCMeisaiRequest{Search: "東名", StartDate: "2030-02-01", EndDate: "2030-02-28"}, "a,b,c"},
+		{"with user", &pb.ListETCMeisaiRequest{Search: "東名", StartDate: "2030-01-01", UserId: "user-1"}, "a,c,d"},
+		{"car number", &pb.ListETCMeisaiRequest{Search: "C", StartDate: "2030-01-01"}, "c"},
+		{"no match", &pb.ListETCMeisaiRequest{Search: "阪神", StartDate: "2030-01-01"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.ListETCMeisai(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := carNumbers(resp); got != tt.want {
+				t.Errorf("expected records %q, got %q", tt.want, got)
+			}
+			if resp.TotalCount != int32(len(resp.EtcMeisaiList)) {
+				t.Errorf("expected total count %d, got %d", len(resp.EtcMeisaiList), resp.TotalCount)
+			}
+		})
+	}
+}
+
+func TestETCMeisaiValidation(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	valid := func() *pb.ETCMeisai {
+		return &pb.ETCMeisai{
+			Date:           "2024-03-01",
+			Time:           "12:34:56",
+			Distance:       25,
+			TollAmount:     1500,
+			DiscountAmount: 200,
+			FinalAmount:    1300,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(m *pb.ETCMeisai)
+		message string
+	}{
+		{"missing date", func(m *pb.ETCMeisai) { m.Date = "" }, "date is required"},
+		{"slash date", func(m *pb.ETCMeisai) { m.Date = "2024/03/01" }, "must be a valid YYYY-MM-DD date"},
+		{"impossible date", func(m *pb.ETCMeisai) { m.Date = "2024-13-40" }, "must be a valid YYYY-MM-DD date"},
+		{"non-leap day", func(m *pb.ETCMeisai) { m.Date = "2023-02-29" }, "must be a valid YYYY-MM-DD date"},
+		{"short time", func(m *pb.ETCMeisai) { m.Time = "12:34" }, "must be HH:MM:SS"},
+		{"impossible time", func(m *pb.ETCMeisai) { m.Time = "25:00:00" }, "must be HH:MM:SS"},
+		{"negative toll", func(m *pb.ETCMeisai) { m.TollAmount = -1 }, "toll_amount must not be negative"},
+		{"negative discount", func(m *pb.ETCMeisai) { m.DiscountAmount = -1 }, "discount_amount must not be negative"},
+		{"negative final", func(m *pb.ETCMeisai) { m.FinalAmount = -1 }, "final_amount must not be negative"},
+		{"negative distance", func(m *pb.ETCMeisai) { m.Distance = -1 }, "distance must not be negative"},
+		{"final mismatch", func(m *pb.ETCMeisai) { m.FinalAmount = 1500 }, "final_amount 1500 must equal toll_amount 1500 minus discount_amount 200"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := valid()
+			tt.mutate(record)
+
+			_, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{EtcMeisai: record})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("create: expected InvalidArgument, got %v", err)
+			}
+			if msg := status.Convert(err).Message(); !strings.Contains(msg, tt.message) {
+				t.Errorf("create: expected message containing %q, got %q", tt.message, msg)
+			}
+
+			_, err = service.UpdateETCMeisai(ctx, &pb.UpdateETCMeisaiRequest{Id: 1, EtcMeisai: record})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("update: expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+
+	if _, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{EtcMeisai: valid()}); err != nil {
+		t.Errorf("expected a valid record to be accepted, got %v", err)
+	}
+}
+
+func TestGetDailyStats(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	for _, record := range []*pb.ETCMeisai{
+		{Date: "2030-05-03", TollAmount: 1000, FinalAmount: 1000, UserId: "daily-user"},
+		{Date: "2030-05-01", TollAmount: 500, DiscountAmount: 100, FinalAmount: 400, UserId: "daily-user"},
+		{Date: "2030-05-03", TollAmount: 2000, FinalAmount: 2000, UserId: "daily-user"},
+		{Date: "2030-05-03", TollAmount: 9999, FinalAmount: 9999, UserId: "other-user"},
+		{Date: "2030-05-05", TollAmount: 7000, FinalAmount: 7000, UserId: "daily-user"},
+	} {
+		if _, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{EtcMeisai: record}); err != nil {
+			t.Fatalf("failed to create record: %v", err)
+		}
+	}
+
+	type day struct {
+		date   string
+		count  int32
+		amount int64
+	}
+	assertDays := func(t *testing.T, resp *pb.GetDailyStatsResponse, want []day) {
+		t.Helper()
+		if len(resp.DailyStats) != len(want) {
+			t.Fatalf("expected %d days, got %v", len(want), resp.DailyStats)
+		}
+		for i, w := range want {
+			got := resp.DailyStats[i]
+			if got.Date != w.date || got.TransactionCount != w.count || got.TotalAmount != w.amount {
+				t.Errorf("day %d: expected %s/%d/%d, got %s/%d/%d",
+					i, w.date, w.count, w.amount, got.Date, got.TransactionCount, got.TotalAmount)
+			}
+		}
+		if resp.TransactionCount != 3 || resp.TotalAmount != 3400 {
+			t.Errorf("expected range totals 3/3400, got %d/%d", resp.TransactionCount, resp.TotalAmount)
+		}
+	}
+
+	req := &pb.GetDailyStatsRequest{StartDate: "2030-05-01", EndDate: "2030-05-04", UserId: "daily-user"}
+
+	t.Run("without gaps", func(t *testing.T) {
+		resp, err := service.GetDailyStats(ctx, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertDays(t, resp, []day{
+			{"2030-05-01", 1, 400},
+			{"2030-05-03", 2, 3000},
+		})
+	})
+
+	t.Run("fill gaps", func(t *testing.T) {
+		filled := proto.Clone(req).(*pb.GetDailyStatsRequest)
+		filled.FillGaps = true
+		resp, err := service.GetDailyStats(ctx, filled)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertDays(t, resp, []day{
+			{"2030-05-01", 1, 400},
+			{"2030-05-02", 0, 0},
+			{"2030-05-03", 2, 3000},
+			{"2030-05-04", 0, 0},
+		})
+	})
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		for _, bad := range []*pb.GetDailyStatsRequest{
+			{StartDate: "2030-05-01"},
+			{StartDate: "2030/05/01", EndDate: "2030-05-04"},
+			{StartDate: "2030-05-04", EndDate: "2030-05-01"},
+			{StartDate: "2030-01-01", EndDate: "2031-12-31"},
+		} {
+			if _, err := service.GetDailyStats(ctx, bad); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument for %v, got %v", bad, err)
+			}
+		}
+	})
+}
+
+func TestBatchGenerateHash(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	tokyo := &pb.ETCMeisai{Date: "2024-03-01", EntranceIc: "Tokyo", ExitIc: "Osaka", CarNumber: "1234"}
+	nagoya := &pb.ETCMeisai{Date: "2024-03-02", EntranceIc: "Nagoya", ExitIc: "Kyoto", CarNumber: "5678"}
+	records := []*pb.ETCMeisai{tokyo, nagoya, proto.Clone(tokyo).(*pb.ETCMeisai)}
+
+	resp, err := service.BatchGenerateHash(ctx, &pb.BatchGenerateHashRequest{EtcMeisaiList: records})
+	if err != nil {
+		t.Fatalf("BatchGenerateHash failed: %v", err)
+	}
+	if len(resp.Hashes) != len(records) {
+		t.Fatalf("expected %d hashes, got %d", len(records), len(resp.Hashes))
+	}
+
+	// Hashes come back in request order and match GenerateHash
+	for i, record := range records {
+		single, err := service.GenerateHash(ctx, &pb.GenerateHashRequest{EtcMeisai: record})
+		if err != nil {
+			t.Fatalf("GenerateHash failed: %v", err)
+		}
+		if resp.Hashes[i] != single.Hash {
+			t.Errorf("hash %d: expected %s, got %s", i, single.Hash, resp.Hashes[i])
+		}
+	}
+	if resp.Hashes[0] != resp.Hashes[2] {
+		t.Errorf("identical records hashed differently: %s and %s", resp.Hashes[0], resp.Hashes[2])
+	}
+	if resp.Hashes[0] == resp.Hashes[1] {
+		t.Errorf("different records share hash %s", resp.Hashes[0])
+	}
+
+	// Stored records don't affect the result
+	if _, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{EtcMeisai: proto.Clone(nagoya).(*pb.ETCMeisai)}); err != nil {
+		t.Fatalf("CreateETCMeisai failed: %v", err)
+	}
+	again, err := service.BatchGenerateHash(ctx, &pb.BatchGenerateHashRequest{EtcMeisaiList: records})
+	if err != nil {
+		t.Fatalf("BatchGenerateHash failed: %v", err)
+	}
+	if !proto.Equal(resp, again) {
+		t.Errorf("expected the same hashes after storing a record, got %v then %v", resp.Hashes, again.Hashes)
+	}
+
+	_, err = service.BatchGenerateHash(ctx, &pb.BatchGenerateHashRequest{EtcMeisaiList: []*pb.ETCMeisai{tokyo, nil}})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "index 1") {
+		t.Errorf("expected InvalidArgument for the nil record at index 1, got %v", err)
+	}
+}
+
+func TestGenerateHashDistinguishesSameDayTrips(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	outbound := &pb.ETCMeisai{
+		Date: "2024-03-01", Time: "08:00:00", EntranceIc: "Tokyo", ExitIc: "Osaka",
+		CarNumber: "1234", TollAmount: 8500, CardNumber: "****-****-****-1234",
+	}
+	sameGatesLater := proto.Clone(outbound).(*pb.ETCMeisai)
+	sameGatesLater.Time = "18:30:00"
+
+	first, err := service.GenerateHash(ctx, &pb.GenerateHashRequest{EtcMeisai: outbound})
+	if err != nil {
+		t.Fatalf("GenerateHash failed: %v", err)
+	}
+	second, err := service.GenerateHash(ctx, &pb.GenerateHashRequest{EtcMeisai: sameGatesLater})
+	if err != nil {
+		t.Fatalf("GenerateHash failed: %v", err)
+	}
+	if first.Hash == second.Hash {
+		t.Errorf("trips at different times share hash %s", first.Hash)
+	}
+	if !strings.HasPrefix(first.Hash, ETCHashVersion+":") {
+		t.Errorf("expected hash prefixed with %s:, got %s", ETCHashVersion, first.Hash)
+	}
+
+	for name, mutate := range map[string]func(m *pb.ETCMeisai){
+		"toll amount": func(m *pb.ETCMeisai) { m.TollAmount = 9000 },
+		"card number": func(m *pb.ETCMeisai) { m.CardNumber = "****-****-****-9999" },
+	} {
+		other := proto.Clone(outbound).(*pb.ETCMeisai)
+		mutate(other)
+		resp, err := service.GenerateHash(ctx, &pb.GenerateHashRequest{EtcMeisai: other})
+		if err != nil {
+			t.Fatalf("GenerateHash failed: %v", err)
+		}
+		if resp.Hash == first.Hash {
+			t.Errorf("changing the %s didn't change the hash", name)
+		}
+	}
+}
+
+func TestMigrateHashes(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	created, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{
+		EtcMeisai: &pb.ETCMeisai{Date: "2024-03-01", Time: "08:00:00", EntranceIc: "Tokyo", ExitIc: "Osaka", Hash: "legacy-hash"},
+	})
+	if err != nil {
+		t.Fatalf("CreateETCMeisai failed: %v", err)
+	}
+
+	if migrated := service.MigrateHashes(); migrated != 1 {
+		t.Errorf("expected 1 legacy hash migrated, got %d", migrated)
+	}
+	if migrated := service.MigrateHashes(); migrated != 0 {
+		t.Errorf("expected nothing left to migrate, got %d", migrated)
+	}
+
+	got, err := service.GetETCMeisai(ctx, &pb.GetETCMeisaiRequest{Id: created.EtcMeisai.Id})
+	if err != nil {
+		t.Fatalf("GetETCMeisai failed: %v", err)
+	}
+	want, err := service.GenerateHash(ctx, &pb.GenerateHashRequest{EtcMeisai: got.EtcMeisai})
+	if err != nil {
+		t.Fatalf("GenerateHash failed: %v", err)
+	}
+	if got.EtcMeisai.Hash != want.Hash {
+		t.Errorf("expected migrated hash %s, got %s", want.Hash, got.EtcMeisai.Hash)
+	}
+}
+
+// slowConsumerStream delivers the first message and then holds each further
+// one until the client goes away, like a consumer that stopped reading
+type slowConsumerStream struct {
+	grpc.ServerStream
+	sends *atomic.Int32
+}
+
+func (s *slowConsumerStream) SendMsg(m interface{}) error {
+	if s.sends.Add(1) > 1 {
+		<-s.Context().Done()
+		return nil
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func TestStreamETCMeisai(t *testing.T) {
+	ctx := context.Background()
+	service := NewETCServiceServer()
+
+	create := func(date, userID string) {
+		if _, err := service.CreateETCMeisai(ctx, &pb.CreateETCMeisaiRequest{
+			EtcMeisai: &pb.ETCMeisai{Date: date, UserId: userID, CarNumber: "stream"},
+		}); err != nil {
+			t.Fatalf("failed to create record: %v", err)
+		}
+	}
+	for day := 25; day >= 1; day-- {
+		create(fmt.Sprintf("2030-01-%02d", day), "stream-user")
+	}
+	create("2030-01-10", "other-user")
+	create("2030-02-01", "stream-user")
+
+	serve := func(t *testing.T, opts ...grpc.ServerOption) pb.ETCServiceClient {
+		bufconnClient := client.NewBufconnClient()
+		grpcServer := grpc.NewServer(opts...)
+		pb.RegisterETCServiceServer(grpcServer, service)
+		go func() {
+			_ = grpcServer.Serve(bufconnClient.GetListener())
+		}()
+		t.Cleanup(func() {
+			grpcServer.Stop()
+			bufconnClient.Close()
+		})
+
+		conn, err := bufconnClient.GetConnection(ctx)
+		if err != nil {
+			t.Fatalf("failed to get bufconn connection: %v", err)
+		}
+		return pb.NewETCServiceClient(conn)
+	}
+
+	request := &pb.StreamETCMeisaiRequest{
+		StartDate: "2030-01-01",
+		EndDate:   "2030-01-31",
+		UserId:    "stream-user",
+		BatchSize: 10,
+	}
+
+	t.Run("receives all matching records", func(t *testing.T) {
+		stream, err := serve(t).StreamETCMeisai(ctx, request)
+		if err != nil {
+			t.Fatalf("failed to open stream: %v", err)
+		}
+
+		var batches []int
+		var dates []string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			batches = append(batches, len(resp.EtcMeisaiList))
+			for _, record := range resp.EtcMeisaiList {
+				dates = append(dates, record.Date)
+			}
+		}
+
+		if len(dates) != 25 {
+			t.Fatalf("expected 25 matching records, got %d", len(dates))
+		}
+		if fmt.Sprint(batches) != "[10 10 5]" {
+			t.Errorf("expected batches of 10, 10 and 5, got %v", batches)
+		}
+		if !sort.StringsAreSorted(dates) || dates[0] != "2030-01-01" {
+			t.Errorf("expected records oldest first, got %v", dates)
+		}
+	})
+
+	t.Run("stops when the client cancels", func(t *testing.T) {
+		var sends atomic.Int32
+		serverErr := make(chan error, 1)
+		etcClient := serve(t, grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			err := handler(srv, &slowConsumerStream{ServerStream: ss, sends: &sends})
+			serverErr <- err
+			return err
+		}))
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := etcClient.StreamETCMeisai(streamCtx, &pb.StreamETCMeisaiRequest{UserId: "stream-user", BatchSize: 1})
+		if err != nil {
+			t.Fatalf("failed to open stream: %v", err)
+		}
+		if _, err := stream.Recv(); err != nil {
+			t.Fatalf("failed to receive the first record: %v", err)
+		}
+		cancel()
+
+		if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+			t.Errorf("expected Canceled after cancelling, got %v", err)
+		}
+		if err := <-serverErr; status.Code(err) != codes.Canceled {
+			t.Errorf("expected the server to stop with Canceled, got %v", err)
+		}
+		if got := sends.Load(); got != 2 {
+			t.Errorf("expected the server to stop after 2 sends, got %d", got)
+		}
+	})
+}
\ No newline at end of file
@@ -67,8 +67,8 @@ func TestServiceRegistry(t *testing.T) {
 
 	// Test service info
 	info := registry.GetServiceInfo()
-	if len(info) != 4 {
-		t.Errorf("Expected 4 services in info, got %d", len(info))
+	if len(info) != 5 {
+		t.Errorf("Expected 5 services in info, got %d", len(info))
 	}
 
 	// Test health check
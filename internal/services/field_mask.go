@@ -0,0 +1,31 @@
+package services
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// DefaultMaxFieldMaskPaths is the default maximum number of paths accepted in an update mask
+const DefaultMaxFieldMaskPaths = 16
+
+// validateFieldMask checks that the mask has at most maxPaths paths and that
+// every path names one of the allowed fields
+func validateFieldMask(mask *fieldmaskpb.FieldMask, allowed map[string]bool, maxPaths int) error {
+	paths := mask.GetPaths()
+
+	if maxPaths > 0 && len(paths) > maxPaths {
+		return status.Error(codes.InvalidArgument,
+			fmt.Sprintf("update mask has %d paths, maximum is %d", len(paths), maxPaths))
+	}
+
+	for _, path := range paths {
+		if !allowed[path] {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("unknown update mask path: %q", path))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// IdempotencyKeyMetadataKey is the gRPC metadata key carrying the client's
+// idempotency key for create calls
+const IdempotencyKeyMetadataKey = "idempotency-key"
+
+// DefaultIdempotencyTTL is how long a create response is remembered per key
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// idempotencyStore remembers the response to each keyed create request so
+// a retry with the same key and request replays it instead of creating a
+// duplicate. It is not safe for concurrent use: callers hold the owning
+// service's lock across lookup, the create itself and save.
+type idempotencyStore struct {
+	ttl     time.Duration
+	entries map[string]idempotencyEntry
+	now     func() time.Time
+}
+
+type idempotencyEntry struct {
+	fingerprint []byte
+	response    proto.Message
+	expires     time.Time
+}
+
+func newIdempotencyStore(ttl time.Duration) *idempotencyStore {
+	return &idempotencyStore{
+		ttl:     ttl,
+		entries: make(map[string]idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+// idempotencyKeyFromContext returns the idempotency key sent with the call, if any
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(IdempotencyKeyMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// lookup returns a copy of the response saved for key, or nil if there is
+// none. Reusing key with a different request is an AlreadyExists error.
+func (s *idempotencyStore) lookup(key string, req proto.Message) (proto.Message, error) {
+	if key == "" {
+		return nil, nil
+	}
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	if !s.now().Before(entry.expires) {
+		delete(s.entries, key)
+		return nil, nil
+	}
+	if !bytes.Equal(entry.fingerprint, requestFingerprint(req)) {
+		return nil, status.Error(codes.AlreadyExists, "idempotency key was already used with a different request")
+	}
+	return proto.Clone(entry.response), nil
+}
+
+// save remembers a copy of resp as the response to req under key, dropping
+// expired entries
+func (s *idempotencyStore) save(key string, req, resp proto.Message) {
+	if key == "" {
+		return
+	}
+
+	now := s.now()
+	for k, entry := range s.entries {
+		if !now.Before(entry.expires) {
+			delete(s.entries, k)
+		}
+	}
+
+	s.entries[key] = idempotencyEntry{
+		fingerprint: requestFingerprint(req),
+		response:    proto.Clone(resp),
+		expires:     now.Add(s.ttl),
+	}
+}
+
+// requestFingerprint hashes the deterministic encoding of req
+func requestFingerprint(req proto.Message) []byte {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
\ No newline at end of file
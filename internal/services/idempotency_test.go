@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func withIdempotencyKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyMetadataKey, key))
+}
+
+func TestCreatePaymentIdempotencyKey(t *testing.T) {
+	service := NewPaymentService()
+	defer service.Close()
+	before := service.GetPaymentCount()
+
+	req := &pb.CreatePaymentRequest{
+		UserId:        "user-001",
+		TotalAmount:   1500,
+		PaymentMethod: pb.PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER,
+	}
+
+	first, err := service.CreatePayment(withIdempotencyKey("key-1"), req)
+	if err != nil {
+		t.Fatalf("CreatePayment failed: %v", err)
+	}
+	repeat, err := service.CreatePayment(withIdempotencyKey("key-1"), req)
+	if err != nil {
+		t.Fatalf("repeated CreatePayment failed: %v", err)
+	}
+	if repeat.Id != first.Id {
+		t.Errorf("expected repeated key to return payment %s, got %s", first.Id, repeat.Id)
+	}
+	if got := service.GetPaymentCount(); got != before+1 {
+		t.Errorf("expected one payment to be created, got %d", got-before)
+	}
+
+	// Same key with a different body is a conflict
+	_, err = service.CreatePayment(withIdempotencyKey("key-1"), &pb.CreatePaymentRequest{
+		UserId:        "user-001",
+		TotalAmount:   2500,
+		PaymentMethod: pb.PaymentMethod_PAYMENT_METHOD_BANK_TRANSFER,
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists for a reused key with a different body, got %v", err)
+	}
+
+	// Requests without a key are never deduplicated
+	if _, err := service.CreatePayment(context.Background(), req); err != nil {
+		t.Fatalf("CreatePayment without key failed: %v", err)
+	}
+	if got := service.GetPaymentCount(); got != before+2 {
+		t.Errorf("expected a second payment without a key, got %d new", got-before)
+	}
+}
+
+func TestCreateUserIdempotencyKeyExpires(t *testing.T) {
+	service := NewUserService()
+	now := time.Now()
+	service.idempotency.now = func() time.Time { return now }
+
+	req := &pb.CreateUserRequest{Email: "retry@example.com", Name: "Retry"}
+	first, err := service.CreateUser(withIdempotencyKey("user-key"), req)
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	repeat, err := service.CreateUser(withIdempotencyKey("user-key"), req)
+	if err != nil {
+		t.Fatalf("repeated CreateUser failed: %v", err)
+	}
+	if repeat.Id != first.Id {
+		t.Errorf("expected repeated key to return user %s, got %s", first.Id, repeat.Id)
+	}
+
+	// Once the key expires the request is handled afresh
+	now = now.Add(DefaultIdempotencyTTL)
+	_, err = service.CreateUser(withIdempotencyKey("user-key"), req)
+	if status.Code(err) != codes.AlreadyExists || !strings.Contains(err.Error(), "email") {
+		t.Errorf("expected duplicate email error after the key expired, got %v", err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultListPageSize is used when a list request doesn't specify a page size
+	DefaultListPageSize = 10
+	// MaxListPageSize caps the page size of a list request unless the service
+	// sets its own maximum
+	MaxListPageSize = 100
+)
+
+// pageLimit is embedded by services with list methods to hold their maximum
+// page size
+type pageLimit struct {
+	max int32
+}
+
+// SetMaxPageSize caps the page size of the service's list methods; larger
+// requests are clamped to max. Zero or a negative value restores
+// MaxListPageSize. Set it before serving requests.
+func (l *pageLimit) SetMaxPageSize(max int32) {
+	l.max = max
+}
+
+// maxPageSize returns the configured maximum page size
+func (l *pageLimit) maxPageSize() int32 {
+	if l.max <= 0 {
+		return MaxListPageSize
+	}
+	return l.max
+}
+
+// clampPageSize applies the default to an unset page size and caps it at the
+// service's maximum
+func (l *pageLimit) clampPageSize(pageSize int32) int32 {
+	max := l.maxPageSize()
+	if pageSize <= 0 {
+		pageSize = DefaultListPageSize
+	}
+	if pageSize > max {
+		pageSize = max
+	}
+	return pageSize
+}
+
+// pageTokenPrefix prefixes the offset encoded in list page tokens
+const pageTokenPrefix = "next_"
+
+// page describes the slice of a filtered, sorted list returned by a list request
+type page struct {
+	start, end    int
+	size          int32
+	nextPageToken string
+}
+
+// pageOf clamps pageSize and decodes pageToken into the bounds of the
+// requested page of total items. Unrecognized tokens start from the beginning.
+func (l *pageLimit) pageOf(total int, pageSize int32, pageToken string) page {
+	pageSize = l.clampPageSize(pageSize)
+
+	start := 0
+	if offset, err := strconv.Atoi(strings.TrimPrefix(pageToken, pageTokenPrefix)); err == nil && offset > 0 {
+		start = offset
+	}
+	if start > total {
+		start = total
+	}
+	end := start + int(pageSize)
+	if end > total {
+		end = total
+	}
+
+	p := page{start: start, end: end, size: pageSize}
+	if end < total {
+		p.nextPageToken = fmt.Sprintf("%s%d", pageTokenPrefix, end)
+	}
+	return p
+}
+
+// newerFirst orders items by time, newest first, breaking ties by ID
+func newerFirst(a, b time.Time, aID, bID string) bool {
+	if !a.Equal(b) {
+		return a.After(b)
+	}
+	return aID < bID
+}
\ No newline at end of file
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+)
+
+// listPage fetches one page and returns its item IDs, total count, page size
+// and next page token
+type listPage func(pageToken string) (ids []string, total, pageSize int32, next string, err error)
+
+// collectPages walks every page, checking the total and page size stay the same
+// and that each item is returned exactly once
+func collectPages(t *testing.T, name string, pageSize int32, fetch listPage) int {
+	t.Helper()
+
+	seen := make(map[string]bool)
+	var total int32 = -1
+	token := ""
+	for pages := 0; ; pages++ {
+		if pages > 100 {
+			t.Fatalf("%s: pagination did not terminate", name)
+		}
+		ids, pageTotal, size, next, err := fetch(token)
+		if err != nil {
+			t.Fatalf("%s: list failed: %v", name, err)
+		}
+		if total == -1 {
+			total = pageTotal
+		} else if pageTotal != total {
+			t.Errorf("%s: total changed from %d to %d on page %d", name, total, pageTotal, pages+1)
+		}
+		if size != pageSize {
+			t.Errorf("%s: page size = %d, expected %d", name, size, pageSize)
+		}
+		if int32(len(ids)) > pageSize {
+			t.Errorf("%s: page %d has %d items, more than the page size", name, pages+1, len(ids))
+		}
+		for _, id := range ids {
+			if seen[id] {
+				t.Errorf("%s: item %s returned twice", name, id)
+			}
+			seen[id] = true
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	if int32(len(seen)) != total {
+		t.Errorf("%s: walked %d items, total_count is %d", name, len(seen), total)
+	}
+	return len(seen)
+}
+
+func TestListTotalCountStableAcrossPages(t *testing.T) {
+	ctx := context.Background()
+	const pageSize = 3
+
+	t.Run("users", func(t *testing.T) {
+		service := NewUserService()
+		for i := 0; i < 7; i++ {
+			if _, err := service.CreateUser(ctx, &pb.CreateUserRequest{
+				Email: fmt.Sprintf("page-%d@example.com", i),
+				Name:  fmt.Sprintf("Page %d", i),
+			}); err != nil {
+				t.Fatalf("CreateUser failed: %v", err)
+			}
+		}
+
+		walked := collectPages(t, "users", pageSize, func(token string) ([]string, int32, int32, string, error) {
+			resp, err := service.ListUsers(ctx, &pb.ListUsersRequest{PageSize: pageSize, PageToken: token})
+			if err != nil {
+				return nil, 0, 0, "", err
+			}
+			var ids []string
+			for _, user := range resp.Users {
+				ids = append(ids, user.Id)
+			}
+			return ids, resp.TotalCount, resp.PageSize, resp.NextPageToken, nil
+		})
+		if walked != service.GetUserCount() {
+			t.Errorf("walked %d users, expected %d", walked, service.GetUserCount())
+		}
+	})
+
+	t.Run("cards", func(t *testing.T) {
+		service := NewCardService()
+		defer service.Close()
+		for i := 0; i < 7; i++ {
+			if _, err := service.CreateCard(ctx, &pb.CreateCardRequest{
+				UserId:      "page-user",
+				VehicleType: pb.VehicleType_VEHICLE_TYPE_REGULAR,
+			}); err != nil {
+				t.Fatalf("CreateCard failed: %v", err)
+			}
+		}
+
+		walked := collectPages(t, "cards", pageSize, func(token string) ([]string, int32, int32, string, error) {
+			resp, err := service.ListCards(ctx, &pb.ListCardsRequest{UserId: "page-user", PageSize: pageSize, PageToken: token})
+			if err != nil {
+				return nil, 0, 0, "", err
+			}
+			var ids []string
+			for _, card := range resp.Cards {
+				ids = append(ids, card.Id)
+			}
+			return ids, resp.TotalCount, resp.PageSize, resp.NextPageToken, nil
+		})
+		if walked != 7 {
+			t.Errorf("walked %d cards, expected 7", walked)
+		}
+	})
+
+	t.Run("payments", func(t *testing.T) {
+		service := NewPaymentService()
+		defer service.Close()
+		for i := 0; i < 7; i++ {
+			if _, err := service.CreatePayment(ctx, &pb.CreatePaymentRequest{
+				UserId:        "page-user",
+				TotalAmount:   int64(1000 + i),
+				PaymentMethod: pb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD,
+			}); err != nil {
+				t.Fatalf("CreatePayment failed: %v", err)
+			}
+		}
+
+		walked := collectPages(t, "payments", pageSize, func(token string) ([]string, int32, int32, string, error) {
+			resp, err := service.ListPayments(ctx, &pb.ListPaymentsRequest{UserId: "page-user", PageSize: pageSize, PageToken: token})
+			if err != nil {
+				return nil, 0, 0, "", err
+			}
+			var ids []string
+			for _, payment := range resp.Payments {
+				ids = append(ids, payment.Id)
+			}
+			return ids, resp.TotalCount, resp.PageSize, resp.NextPageToken, nil
+		})
+		if walked != 7 {
+			t.Errorf("walked %d payments, expected 7", walked)
+		}
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		service := NewTransactionService()
+		exit := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		for i := 0; i < 7; i++ {
+			// Several transactions share a timestamp so ordering falls back to ID
+			at := exit.Add(time.Duration(i/2) * time.Hour)
+			if _, err := service.CreateTransaction(ctx, "page-card", "Tokyo IC", "Osaka IC", at.Add(-time.Hour), at, 50, 1000); err != nil {
+				t.Fatalf("CreateTransaction failed: %v", err)
+			}
+		}
+
+		walked := collectPages(t, "transactions", pageSize, func(token string) ([]string, int32, int32, string, error) {
+			resp, err := service.GetTransactionHistory(ctx, &pb.GetTransactionHistoryRequest{CardId: "page-card", PageSize: pageSize, PageToken: token})
+			if err != nil {
+				return nil, 0, 0, "", err
+			}
+			var ids []string
+			for _, transaction := range resp.Transactions {
+				ids = append(ids, transaction.Id)
+			}
+			return ids, resp.TotalCount, resp.PageSize, resp.NextPageToken, nil
+		})
+		if walked != 7 {
+			t.Errorf("walked %d transactions, expected 7", walked)
+		}
+	})
+}
+
+func TestPageOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		pageSize  int32
+		pageToken string
+		want      page
+	}{
+		{"first page", 25, 10, "", page{start: 0, end: 10, size: 10, nextPageToken: "next_10"}},
+		{"last page", 25, 10, "next_20", page{start: 20, end: 25, size: 10}},
+		{"default size", 25, 0, "", page{start: 0, end: 10, size: DefaultListPageSize, nextPageToken: "next_10"}},
+		{"oversized page", 250, 500, "", page{start: 0, end: MaxListPageSize, size: MaxListPageSize, nextPageToken: "next_100"}},
+		{"token past the end", 5, 10, "next_50", page{start: 5, end: 5, size: 10}},
+		{"unrecognized token", 25, 10, "garbage", page{start: 0, end: 10, size: 10, nextPageToken: "next_10"}},
+		{"empty list", 0, 10, "", page{start: 0, end: 0, size: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var limit pageLimit
+			if got := limit.pageOf(tt.total, tt.pageSize, tt.pageToken); got != tt.want {
+				t.Errorf("pageOf(%d, %d, %q) = %+v, expected %+v", tt.total, tt.pageSize, tt.pageToken, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPageSizeClampedToConfiguredMax(t *testing.T) {
+	ctx := context.Background()
+	registry := NewServiceRegistry()
+	defer registry.CardService.Close()
+	defer registry.PaymentService.Close()
+	WithMaxPageSize(20, map[string]int{"etc": 5})(registry)
+
+	users, err := registry.UserService.ListUsers(ctx, &pb.ListUsersRequest{PageSize: 1000})
+	if err != nil {
+		t.Fatalf("ListUsers failed: %v", err)
+	}
+	cards, err := registry.CardService.ListCards(ctx, &pb.ListCardsRequest{UserId: "user-001", PageSize: 1000})
+	if err != nil {
+		t.Fatalf("ListCards failed: %v", err)
+	}
+	payments, err := registry.PaymentService.ListPayments(ctx, &pb.ListPaymentsRequest{UserId: "user-001", PageSize: 1000})
+	if err != nil {
+		t.Fatalf("ListPayments failed: %v", err)
+	}
+	transactions, err := registry.TransactionService.GetTransactionHistory(ctx, &pb.GetTransactionHistoryRequest{CardId: "card-1", PageSize: 1000})
+	if err != nil {
+		t.Fatalf("GetTransactionHistory failed: %v", err)
+	}
+	etc, err := registry.ETCService.ListETCMeisai(ctx, &pb.ListETCMeisaiRequest{PageSize: 1000})
+	if err != nil {
+		t.Fatalf("ListETCMeisai failed: %v", err)
+	}
+	etcRange, err := registry.ETCService.GetETCMeisaiByDateRange(ctx, &pb.GetETCMeisaiByDateRangeRequest{PageSize: 1000})
+	if err != nil {
+		t.Fatalf("GetETCMeisaiByDateRange failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		pageSize int32
+		items    int
+		want     int32
+	}{
+		{"users", users.PageSize, len(users.Users), 20},
+		{"cards", cards.PageSize, len(cards.Cards), 20},
+		{"payments", payments.PageSize, len(payments.Payments), 20},
+		{"transactions", transactions.PageSize, len(transactions.Transactions), 20},
+		{"etc", etc.PageSize, len(etc.EtcMeisaiList), 5},
+		{"etc by date range", etcRange.PageSize, len(etcRange.EtcMeisaiList), 5},
+	}
+	for _, tt := range tests {
+		if tt.pageSize != tt.want {
+			t.Errorf("%s: page_size = %d, expected %d", tt.name, tt.pageSize, tt.want)
+		}
+		if int32(tt.items) > tt.want {
+			t.Errorf("%s: returned %d items, more than the page size %d", tt.name, tt.items, tt.want)
+		}
+	}
+}
\ No newline at end of file
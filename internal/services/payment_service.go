@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -17,14 +18,32 @@ import (
 // PaymentService implements the PaymentServiceServer interface
 type PaymentService struct {
 	pb.UnimplementedPaymentServiceServer
+	auditReporter
+	pageLimit
 	mu       sync.RWMutex
 	payments map[string]*pb.Payment
+	// idempotency replays CreatePayment responses for repeated idempotency keys
+	idempotency *idempotencyStore
+	// statements supplies the transactions summed into monthly statements
+	statements StatementSource
+	// statementLocation is the time zone whose calendar months statements
+	// cover; nil uses time.Local
+	statementLocation *time.Location
+
+	// ctx is cancelled by Close to stop in-flight payment processing
+	ctx        context.Context
+	cancel     context.CancelFunc
+	processing sync.WaitGroup
 }
 
 // NewPaymentService creates a new PaymentService instance with mock data
 func NewPaymentService() *PaymentService {
+	ctx, cancel := context.WithCancel(context.Background())
 	service := &PaymentService{
-		payments: make(map[string]*pb.Payment),
+		payments:    make(map[string]*pb.Payment),
+		idempotency: newIdempotencyStore(DefaultIdempotencyTTL),
+		ctx:         ctx,
+		cancel:      cancel,
 	}
 
 	// Add mock data
@@ -120,6 +139,15 @@ func (s *PaymentService) CreatePayment(ctx context.Context, req *pb.CreatePaymen
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// Replay the original response to a retried request instead of charging twice
+	key := idempotencyKeyFromContext(ctx)
+	if replay, err := s.idempotency.lookup(key, req); err != nil || replay != nil {
+		if err != nil {
+			return nil, err
+		}
+		return replay.(*pb.Payment), nil
+	}
+
 	// Create new payment
 	now := timestamppb.New(time.Now())
 	payment := &pb.Payment{
@@ -134,9 +162,15 @@ func (s *PaymentService) CreatePayment(ctx context.Context, req *pb.CreatePaymen
 	}
 
 	s.payments[payment.Id] = payment
-
-	// Simulate payment processing (in real implementation, this would be async)
-	go s.simulatePaymentProcessing(payment.Id)
+	s.idempotency.save(key, req, payment)
+	s.recordAudit(ctx, "CreatePayment", AuditEntityPayment, payment.Id)
+
+	// Simulate payment processing (in real implementation, this would be async).
+	// After Close the payment is left pending.
+	if s.ctx.Err() == nil {
+		s.processing.Add(1)
+		go s.simulatePaymentProcessing(payment.Id)
+	}
 
 	return payment, nil
 }
@@ -150,19 +184,6 @@ func (s *PaymentService) ListPayments(ctx context.Context, req *pb.ListPaymentsR
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	// Default pagination values
-	pageSize := req.PageSize
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	// For simplicity, ignore page token for now in mock implementation
-	skip := 0
-	if req.PageToken != "" {
-		// In real implementation, decode page token to get skip value
-		skip = 0
-	}
-
 	// Filter payments for the specified user
 	var userPayments []*pb.Payment
 	for _, payment := range s.payments {
@@ -177,37 +198,20 @@ func (s *PaymentService) ListPayments(ctx context.Context, req *pb.ListPaymentsR
 		}
 	}
 
-	// Sort payments by date (newest first)
-	for i := 0; i < len(userPayments)-1; i++ {
-		for j := i + 1; j < len(userPayments); j++ {
-			if userPayments[i].PaymentDate.AsTime().Before(userPayments[j].PaymentDate.AsTime()) {
-				userPayments[i], userPayments[j] = userPayments[j], userPayments[i]
-			}
-		}
-	}
-
-	start := skip
-	end := start + int(pageSize)
-
-	var payments []*pb.Payment
-	var nextPageToken string
+	// Sort payments by date (newest first), then ID so pages are stable
+	sort.Slice(userPayments, func(i, j int) bool {
+		return newerFirst(userPayments[i].PaymentDate.AsTime(), userPayments[j].PaymentDate.AsTime(), userPayments[i].Id, userPayments[j].Id)
+	})
 
-	if start < len(userPayments) {
-		if end > len(userPayments) {
-			end = len(userPayments)
-		}
-		payments = userPayments[start:end]
-		// Generate next page token if there are more payments
-		if end < len(userPayments) {
-			nextPageToken = fmt.Sprintf("next_%d", end)
-		}
-	} else {
-		payments = []*pb.Payment{}
-	}
+	p := s.pageOf(len(userPayments), req.PageSize, req.PageToken)
+	payments := make([]*pb.Payment, 0, p.end-p.start)
+	payments = append(payments, userPayments[p.start:p.end]...)
 
 	return &pb.ListPaymentsResponse{
 		Payments:      payments,
-		NextPageToken: nextPageToken,
+		NextPageToken: p.nextPageToken,
+		TotalCount:    int32(len(userPayments)),
+		PageSize:      p.size,
 	}, nil
 }
 
@@ -224,59 +228,114 @@ func (s *PaymentService) GetMonthlyStatement(ctx context.Context, req *pb.GetMon
 	}
 
 	s.mu.RLock()
-	defer s.mu.RUnlock()
+	source, location := s.statements, s.statementLocation
+	s.mu.RUnlock()
+	if source == nil {
+		return nil, status.Error(codes.FailedPrecondition, "statement source is not configured")
+	}
+	if location == nil {
+		location = time.Local
+	}
 
 	// Create date range for the requested month
-	startOfMonth := time.Date(int(req.Year), time.Month(req.Month), 1, 0, 0, 0, 0, time.UTC)
-	endOfMonth := startOfMonth.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	startOfMonth := time.Date(int(req.Year), time.Month(req.Month), 1, 0, 0, 0, 0, location)
+	startOfNextMonth := startOfMonth.AddDate(0, 1, 0)
+
+	transactions, err := source.UserTransactions(ctx, req.UserId, startOfMonth, startOfNextMonth)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load transactions: %v", err)
+	}
 
-	// For demo purposes, create a mock monthly statement
-	// In a real implementation, this would aggregate transaction data
 	statement := &pb.MonthlyStatement{
 		Id:             uuid.New().String(),
 		UserId:         req.UserId,
-		CardId:         "mock-card-id", // In real implementation, would come from context or parameter
 		Year:           req.Year,
 		Month:          req.Month,
-		TotalTrips:     int32(rand.Intn(50) + 10), // 10-60 trips
-		TotalDistance:  float64(rand.Intn(1000) + 100), // 100-1100 km
-		TotalAmount:    int64(rand.Intn(30000) + 5000), // 5,000-35,000 yen
-		DiscountAmount: int64(rand.Intn(5000)), // 0-5,000 yen discount
-		FinalAmount:    0, // Will be calculated below
 		GeneratedAt:    timestamppb.New(time.Now()),
-		PaymentDueDate: timestamppb.New(endOfMonth.AddDate(0, 1, 15)), // 15th of next month
+		PaymentDueDate: timestamppb.New(startOfNextMonth.AddDate(0, 0, 14)), // 15th of next month
 	}
-
-	statement.FinalAmount = statement.TotalAmount - statement.DiscountAmount
+	aggregateStatement(statement, transactions)
 
 	return statement, nil
 }
 
-// simulatePaymentProcessing simulates async payment processing
-func (s *PaymentService) simulatePaymentProcessing(paymentId string) {
-	// Simulate processing time (1-5 seconds)
-	time.Sleep(time.Duration(1+rand.Intn(4)) * time.Second)
+// SetStatementLocation sets the time zone whose calendar months monthly
+// statements cover; nil uses time.Local
+func (s *PaymentService) SetStatementLocation(location *time.Location) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statementLocation = location
+}
 
+// SetStatementSource sets where GetMonthlyStatement finds the transactions it sums
+func (s *PaymentService) SetStatementSource(source StatementSource) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.statements = source
+}
 
-	payment, exists := s.payments[paymentId]
-	if !exists {
+// simulatePaymentProcessing simulates async payment processing, giving up
+// when the service is closed
+func (s *PaymentService) simulatePaymentProcessing(paymentId string) {
+	defer s.processing.Done()
+
+	// Simulate processing time (1-5 seconds)
+	if !s.wait(time.Duration(1+rand.Intn(4)) * time.Second) {
 		return
 	}
 
 	// Update to processing
-	payment.Status = pb.PaymentProcessingStatus_PAYMENT_PROCESSING_STATUS_PROCESSING
+	if !s.setPaymentStatus(paymentId, pb.PaymentProcessingStatus_PAYMENT_PROCESSING_STATUS_PROCESSING) {
+		return
+	}
 
 	// Simulate additional processing time
-	time.Sleep(time.Duration(1+rand.Intn(3)) * time.Second)
+	if !s.wait(time.Duration(1+rand.Intn(3)) * time.Second) {
+		return
+	}
 
 	// 95% success rate, 5% failure rate
 	if rand.Float32() < 0.95 {
-		payment.Status = pb.PaymentProcessingStatus_PAYMENT_PROCESSING_STATUS_COMPLETED
+		s.setPaymentStatus(paymentId, pb.PaymentProcessingStatus_PAYMENT_PROCESSING_STATUS_COMPLETED)
 	} else {
-		payment.Status = pb.PaymentProcessingStatus_PAYMENT_PROCESSING_STATUS_FAILED
+		s.setPaymentStatus(paymentId, pb.PaymentProcessingStatus_PAYMENT_PROCESSING_STATUS_FAILED)
+	}
+}
+
+// wait sleeps for d, returning false if the service is closed first
+func (s *PaymentService) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+// setPaymentStatus updates a payment's status, returning false if it no longer exists
+func (s *PaymentService) setPaymentStatus(paymentId string, st pb.PaymentProcessingStatus) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payment, exists := s.payments[paymentId]
+	if !exists {
+		return false
 	}
+	payment.Status = st
+	return true
+}
+
+// Close stops in-flight payment processing and waits for it to exit.
+// Payments still being processed are left in their current status.
+func (s *PaymentService) Close() {
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+
+	s.processing.Wait()
 }
 
 // GetPaymentCount returns the current number of payments (helper method for testing)
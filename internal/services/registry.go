@@ -1,17 +1,24 @@
 package services
 
 import (
-	"database/sql"
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"regexp"
+	"sync"
+	"time"
 
-	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/yhonda-ohishi/db-handler-server/internal/audit"
 	"github.com/yhonda-ohishi/db-handler-server/internal/client"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
 	dbproto "github.com/yhonda-ohishi/db_service/src/proto"
 	etcpb "github.com/yhonda-ohishi/etc_meisai_scraper/src/pb"
 	etcservices "github.com/yhonda-ohishi/etc_meisai_scraper/src/services"
-	_ "github.com/go-sql-driver/mysql"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // ServiceRegistry holds all gRPC service implementations
@@ -28,82 +35,114 @@ type ServiceRegistry struct {
 	DTakoFerryRowsService   dbproto.DTakoFerryRowsServiceServer
 	ETCMeisaiMappingService dbproto.ETCMeisaiMappingServiceServer
 	// etc_meisai_scraper services
-	DownloadService         etcpb.DownloadServiceServer
-	IsSingleMode            bool
+	DownloadService etcpb.DownloadServiceServer
+	IsSingleMode    bool
+	// AuditLog records mutating operations
+	AuditLog *audit.Log
+	// EnabledServices limits RegisterAll to the named core services
+	// (see ServiceNames); empty registers all of them
+	EnabledServices []string
+	// Health serves grpc.health.v1 for the servers passed to RegisterAll,
+	// reporting each registered service as SERVING until MarkNotServing
+	Health *grpchealth.Server
 }
 
+// ServiceNames are the core service names accepted by RegisterSeparately
+// and EnabledServices
+var ServiceNames = []string{"user", "transaction", "card", "payment", "etc"}
+
 // NewServiceRegistry creates a new service registry with all services initialized
 func NewServiceRegistry() *ServiceRegistry {
-	return &ServiceRegistry{
+	registry := &ServiceRegistry{
 		UserService:        NewUserService(),
 		TransactionService: NewTransactionService(),
 		CardService:        NewCardService(),
 		PaymentService:     NewPaymentService(),
 		ETCService:         NewETCServiceServer(),
+		AuditLog:           audit.NewLogWithDefaults(),
 	}
+	registry.connectServices()
+	return registry
 }
 
 // NewServiceRegistryForSingleMode creates a service registry with DB services for single mode
-func NewServiceRegistryForSingleMode() *ServiceRegistry {
-	reg := NewServiceRegistryWithRealDB()
-	if reg != nil {
-		return reg
-	}
+func NewServiceRegistryForSingleMode() (*ServiceRegistry, error) {
+	return NewServiceRegistryForSingleModeWithPool(DefaultDBPoolConfig())
+}
 
-	// Fallback to basic registry without db_service
-	log.Printf("Warning: Failed to initialize db_service, running without database services")
-	return &ServiceRegistry{
-		UserService:        NewUserService(),
-		TransactionService: NewTransactionService(),
-		CardService:        NewCardService(),
-		PaymentService:     NewPaymentService(),
-		ETCService:         NewETCServiceServer(),
-		IsSingleMode:       true,
-	}
+// NewServiceRegistryForSingleModeWithPool creates a single mode service
+// registry whose download service database uses the given pool settings
+func NewServiceRegistryForSingleModeWithPool(pool DBPoolConfig) (*ServiceRegistry, error) {
+	return NewServiceRegistryWithRealDB(pool)
 }
 
-// NewServiceRegistryWithRealDB creates a service registry with db_service client
-func NewServiceRegistryWithRealDB() *ServiceRegistry {
+// NewServiceRegistryWithRealDB creates a service registry with db_service client.
+// It fails when DATABASE_URL is set but the database can't be reached, so a
+// misconfigured deployment doesn't start without downloads.
+func NewServiceRegistryWithRealDB(pool DBPoolConfig) (*ServiceRegistry, error) {
 	// db_service is accessed via gRPC/bufconn, not direct database connection
 	// The db_service handles all database operations
 
 	// Initialize download service for etc_meisai_scraper
-	// For now, using a dummy DB connection - in production this would be configured properly
 	var downloadServiceServer etcpb.DownloadServiceServer
-
-	// Try to create the download service
 	if dbDSN := os.Getenv("DATABASE_URL"); dbDSN != "" {
-		if db, err := sql.Open("mysql", dbDSN); err == nil {
-			logger := log.New(os.Stdout, "[DownloadService] ", log.LstdFlags)
-			downloadServiceServer = etcservices.NewDownloadServiceGRPC(db, logger)
+		db, err := openDownloadDB(dbDSN, pool)
+		if err != nil {
+			return nil, fmt.Errorf("download service database (DATABASE_URL): %w", err)
 		}
+		logger := log.New(os.Stdout, "[DownloadService] ", log.LstdFlags)
+		downloadServiceServer = etcservices.NewDownloadServiceGRPC(db, logger)
+	} else {
+		// Without a database, register a stub explaining why downloads are disabled
+		log.Printf("Warning: DownloadService disabled (%s)", DownloadDisabledNoDatabaseURL)
+		downloadServiceServer = newDisabledDownloadService(DownloadDisabledNoDatabaseURL)
 	}
 
-	// If no DB configured, create without download service
-	if downloadServiceServer == nil {
-		log.Println("Warning: DownloadService not initialized (no DATABASE_URL)")
+	registry := &ServiceRegistry{
+		UserService:        NewUserService(),
+		TransactionService: NewTransactionService(),
+		CardService:        NewCardService(),
+		PaymentService:     NewPaymentService(),
+		ETCService:         NewETCServiceServer(),
+		DownloadService:    downloadServiceServer,
+		IsSingleMode:       true,
+		AuditLog:           audit.NewLogWithDefaults(),
+	}
+	registry.connectServices()
+	return registry, nil
+}
+
+// connectServices wires the dependencies between services, such as monthly
+// statements summing the transactions on a user's cards, and has the services
+// report their mutating operations to the audit log
+func (r *ServiceRegistry) connectServices() {
+	if r.PaymentService != nil && r.CardService != nil && r.TransactionService != nil {
+		r.PaymentService.SetStatementSource(NewCardTransactionSource(r.CardService, r.TransactionService))
 	}
 
-	return &ServiceRegistry{
-		UserService:             NewUserService(),
-		TransactionService:      NewTransactionService(),
-		CardService:             NewCardService(),
-		PaymentService:          NewPaymentService(),
-		ETCService:              NewETCServiceServer(),
-		DownloadService:         downloadServiceServer,
-		IsSingleMode:            true,
+	if r.AuditLog != nil {
+		for _, service := range []interface{ SetAuditRecorder(audit.Recorder) }{
+			r.UserService, r.CardService, r.PaymentService, r.TransactionService, r.ETCService,
+		} {
+			service.SetAuditRecorder(r.AuditLog)
+		}
 	}
 }
 
 // RegisterAll registers all services to a gRPC server
 // This method supports both single mode (register directly to server) and separate mode
 func (r *ServiceRegistry) RegisterAll(server *grpc.Server) {
-	// Register all services with the gRPC server
-	pb.RegisterUserServiceServer(server, r.UserService)
-	pb.RegisterTransactionServiceServer(server, r.TransactionService)
-	pb.RegisterCardServiceServer(server, r.CardService)
-	pb.RegisterPaymentServiceServer(server, r.PaymentService)
-	pb.RegisterETCServiceServer(server, r.ETCService)
+	// Register the enabled core services with the gRPC server
+	if len(r.EnabledServices) == 0 {
+		r.RegisterSeparately(server, ServiceNames...)
+	} else {
+		r.RegisterSeparately(server, r.EnabledServices...)
+		for _, name := range ServiceNames {
+			if !r.isEnabled(name) {
+				log.Printf("Service %s disabled by configuration, not registering", name)
+			}
+		}
+	}
 
 	// In single mode, also register db_service services directly
 	// These are accessed via bufconn in-memory, not external connection
@@ -126,12 +165,54 @@ func (r *ServiceRegistry) RegisterAll(server *grpc.Server) {
 			etcpb.RegisterDownloadServiceServer(server, r.DownloadService)
 		}
 	}
+
+	// Report every registered service, and the server as a whole, over grpc.health.v1
+	if r.Health == nil {
+		r.Health = grpchealth.NewServer()
+	}
+	for name := range server.GetServiceInfo() {
+		r.Health.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_SERVING)
+	}
+	r.Health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(server, r.Health)
+}
+
+// MarkNotServing reports every service as NOT_SERVING from now on, so health
+// probes fail while in-flight requests drain during shutdown
+func (r *ServiceRegistry) MarkNotServing() {
+	if r.Health != nil {
+		r.Health.Shutdown()
+	}
 }
 
 // RegisterSeparately registers services individually to a gRPC server
 // This provides more granular control over which services to register
 func (r *ServiceRegistry) RegisterSeparately(server *grpc.Server, serviceNames ...string) {
-	serviceMap := map[string]func(){
+	serviceMap := r.registrars(server)
+
+	// Register specified services
+	for _, serviceName := range serviceNames {
+		if registerFunc, exists := serviceMap[serviceName]; exists {
+			registerFunc()
+		} else {
+			log.Printf("Warning: unknown service %q, not registering", serviceName)
+		}
+	}
+}
+
+// isEnabled reports whether name is listed in EnabledServices
+func (r *ServiceRegistry) isEnabled(name string) bool {
+	for _, enabled := range r.EnabledServices {
+		if enabled == name {
+			return true
+		}
+	}
+	return false
+}
+
+// registrars maps each core service name to a function registering it with server
+func (r *ServiceRegistry) registrars(server *grpc.Server) map[string]func() {
+	return map[string]func(){
 		"user": func() {
 			pb.RegisterUserServiceServer(server, r.UserService)
 		},
@@ -148,13 +229,6 @@ func (r *ServiceRegistry) RegisterSeparately(server *grpc.Server, serviceNames .
 			pb.RegisterETCServiceServer(server, r.ETCService)
 		},
 	}
-
-	// Register specified services
-	for _, serviceName := range serviceNames {
-		if registerFunc, exists := serviceMap[serviceName]; exists {
-			registerFunc()
-		}
-	}
 }
 
 // GetServiceInfo returns information about all registered services
@@ -169,13 +243,13 @@ func (r *ServiceRegistry) GetServiceInfo() map[string]interface{} {
 		"transaction_service": map[string]interface{}{
 			"name":              "TransactionService",
 			"description":       "Handles ETC transaction history",
-			"methods":           []string{"GetTransaction", "GetTransactionHistory"},
+			"methods":           []string{"GetTransaction", "GetTransactionHistory", "ListTransactions"},
 			"transaction_count": r.TransactionService.GetTransactionCount(),
 		},
 		"card_service": map[string]interface{}{
 			"name":        "CardService",
 			"description": "Manages ETC cards",
-			"methods":     []string{"GetCard", "CreateCard", "UpdateCard", "DeleteCard", "ListCards"},
+			"methods":     []string{"GetCard", "CreateCard", "UpdateCard", "DeleteCard", "ListCards", "BulkUpdateCardStatus"},
 			"card_count":  r.CardService.GetCardCount(),
 		},
 		"payment_service": map[string]interface{}{
@@ -184,6 +258,18 @@ func (r *ServiceRegistry) GetServiceInfo() map[string]interface{} {
 			"methods":       []string{"GetPayment", "CreatePayment", "ListPayments", "GetMonthlyStatement"},
 			"payment_count": r.PaymentService.GetPaymentCount(),
 		},
+		"etc_service": map[string]interface{}{
+			"name":        "ETCService",
+			"description": "Stores ETC明細 toll records and summaries",
+			"methods": []string{
+				"CreateETCMeisai", "GetETCMeisai", "UpdateETCMeisai", "DeleteETCMeisai", "ListETCMeisai", "StreamETCMeisai",
+				"BulkCreateETCMeisai", "BulkUpdateETCMeisai", "BulkDeleteETCMeisai",
+				"GetETCMeisaiByDateRange", "GetETCMeisaiByHash", "GetUnmappedETCMeisai",
+				"CheckDuplicatesByHash", "DeduplicateETCMeisai", "GenerateHash", "BatchGenerateHash",
+				"GetETCSummary", "GetMonthlyStats", "GetDailyStats",
+			},
+			"record_count": r.ETCService.GetRecordCount(),
+		},
 	}
 }
 
@@ -194,6 +280,7 @@ func (r *ServiceRegistry) IsHealthy() map[string]bool {
 		"transaction_service": r.TransactionService != nil,
 		"card_service":        r.CardService != nil,
 		"payment_service":     r.PaymentService != nil,
+		"etc_service":         r.ETCService != nil,
 	}
 }
 
@@ -232,6 +319,112 @@ func WithMockData() ServiceOption {
 	}
 }
 
+// WithMaxFieldMaskPaths is an option to limit the number of paths accepted in update masks
+func WithMaxFieldMaskPaths(max int) ServiceOption {
+	return func(r *ServiceRegistry) {
+		if r.UserService != nil {
+			r.UserService.SetMaxFieldMaskPaths(max)
+		}
+	}
+}
+
+// WithStatementLocation is an option to set the time zone whose calendar
+// months monthly statements cover
+func WithStatementLocation(location *time.Location) ServiceOption {
+	return func(r *ServiceRegistry) {
+		if r.PaymentService != nil {
+			r.PaymentService.SetStatementLocation(location)
+		}
+	}
+}
+
+// WithAuditMaxPageSize is an option to cap the page size of audit log
+// queries. Zero or a negative value keeps the audit package default.
+func WithAuditMaxPageSize(max int) ServiceOption {
+	return func(r *ServiceRegistry) {
+		if r.AuditLog != nil {
+			r.AuditLog.SetMaxPageSize(max)
+		}
+	}
+}
+
+// WithMaxBatchSize is an option to limit the records accepted by ETC明細 bulk
+// operations. Zero or a negative value keeps DefaultMaxBulkBatchSize.
+func WithMaxBatchSize(max int) ServiceOption {
+	return func(r *ServiceRegistry) {
+		if r.ETCService != nil {
+			r.ETCService.SetMaxBatchSize(max)
+		}
+	}
+}
+
+// WithMaxPageSize is an option to cap the page size of every list method at
+// max, with overrides keyed by service name (see ServiceNames). Zero or a
+// negative value keeps MaxListPageSize.
+func WithMaxPageSize(max int, overrides map[string]int) ServiceOption {
+	return func(r *ServiceRegistry) {
+		limits := make(map[string]interface{ SetMaxPageSize(int32) })
+		if r.UserService != nil {
+			limits["user"] = r.UserService
+		}
+		if r.TransactionService != nil {
+			limits["transaction"] = r.TransactionService
+		}
+		if r.CardService != nil {
+			limits["card"] = r.CardService
+		}
+		if r.PaymentService != nil {
+			limits["payment"] = r.PaymentService
+		}
+		if r.ETCService != nil {
+			limits["etc"] = r.ETCService
+		}
+
+		for name, service := range limits {
+			limit := max
+			if override, ok := overrides[name]; ok {
+				limit = override
+			}
+			service.SetMaxPageSize(int32(limit))
+		}
+	}
+}
+
+// WithPhoneValidation is an option to require user phone numbers to match pattern,
+// such as E164PhonePattern
+func WithPhoneValidation(pattern *regexp.Regexp) ServiceOption {
+	return func(r *ServiceRegistry) {
+		if r.UserService != nil {
+			r.UserService.SetPhonePattern(pattern)
+		}
+	}
+}
+
+// WithEnabledServices is an option to register only the named core services
+func WithEnabledServices(names ...string) ServiceOption {
+	return func(r *ServiceRegistry) {
+		r.EnabledServices = names
+	}
+}
+
+// WithCardNumberExposure is an option to control whether card responses include the raw card number
+func WithCardNumberExposure(expose bool) ServiceOption {
+	return func(r *ServiceRegistry) {
+		if r.CardService != nil {
+			r.CardService.SetExposeCardNumber(expose)
+		}
+	}
+}
+
+// WithETCIDSequence sets the sequence used to allocate ETC明細 record IDs
+func WithETCIDSequence(seq IDSequence) ServiceOption {
+	return func(r *ServiceRegistry) {
+		if r.ETCService != nil {
+			r.ETCService.SetIDSequence(seq)
+		}
+	}
+}
+
 // NewServiceRegistryWithOptions creates a service registry with custom options
 func NewServiceRegistryWithOptions(opts ...ServiceOption) *ServiceRegistry {
 	registry := NewServiceRegistry()
@@ -243,6 +436,42 @@ func NewServiceRegistryWithOptions(opts ...ServiceOption) *ServiceRegistry {
 	return registry
 }
 
+// Shutdown signals every service to stop its background work and waits for
+// them to finish, returning an error if ctx expires first
+func (r *ServiceRegistry) Shutdown(ctx context.Context) error {
+	r.MarkNotServing()
+
+	var closers []func()
+	if r.CardService != nil {
+		closers = append(closers, r.CardService.Close)
+	}
+	if r.PaymentService != nil {
+		closers = append(closers, r.PaymentService.Close)
+	}
+
+	var wg sync.WaitGroup
+	for _, closeService := range closers {
+		wg.Add(1)
+		go func(closeService func()) {
+			defer wg.Done()
+			closeService()
+		}(closeService)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for services to stop: %w", ctx.Err())
+	}
+}
+
 // GetUserServiceInstance returns the user service instance for direct access
 func (r *ServiceRegistry) GetUserServiceInstance() *UserService {
 	return r.UserService
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yhonda-ohishi/db-handler-server/internal/client"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	etcpb "github.com/yhonda-ohishi/etc_meisai_scraper/src/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegisterAllEnabledServices(t *testing.T) {
+	bufconnClient := client.NewBufconnClient()
+	grpcServer := grpc.NewServer()
+	NewServiceRegistryWithOptions(WithEnabledServices("user", "transaction")).RegisterAll(grpcServer)
+	go func() {
+		_ = grpcServer.Serve(bufconnClient.GetListener())
+	}()
+	defer grpcServer.Stop()
+	defer bufconnClient.Close()
+
+	ctx := context.Background()
+	conn, err := bufconnClient.GetConnection(ctx)
+	if err != nil {
+		t.Fatalf("failed to get bufconn connection: %v", err)
+	}
+
+	// Disabled services are not registered at all
+	_, err = pb.NewPaymentServiceClient(conn).GetPayment(ctx, &pb.GetPaymentRequest{Id: "payment-1"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected Unimplemented from disabled PaymentService, got %v", err)
+	}
+
+	// Enabled services keep working
+	created, err := pb.NewUserServiceClient(conn).CreateUser(ctx, &pb.CreateUserRequest{
+		Email: "enabled@example.com",
+		Name:  "Enabled",
+	})
+	if err != nil {
+		t.Fatalf("expected enabled UserService to work, got %v", err)
+	}
+	if created.Id == "" {
+		t.Error("expected created user to have an ID")
+	}
+}
+
+func TestServiceRegistryShutdownStopsGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	registry := NewServiceRegistryWithOptions()
+	grpcServer := grpc.NewServer()
+	registry.RegisterAll(grpcServer)
+	registry.CardService.StartExpirySweeper(time.Hour)
+
+	// Leave a payment processing in the background
+	ctx := context.Background()
+	_, err := registry.PaymentService.CreatePayment(ctx, &pb.CreatePaymentRequest{
+		UserId:        "user-001",
+		TotalAmount:   1000,
+		PaymentMethod: pb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD,
+	})
+	if err != nil {
+		t.Fatalf("CreatePayment failed: %v", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := registry.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	grpcServer.Stop()
+
+	// Allow exiting goroutines to be reaped
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected no leaked goroutines, had %d before and %d after shutdown", before, after)
+	}
+
+	// Payments created after shutdown are left pending
+	payment, err := registry.PaymentService.CreatePayment(ctx, &pb.CreatePaymentRequest{
+		UserId:        "user-001",
+		TotalAmount:   1000,
+		PaymentMethod: pb.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD,
+	})
+	if err != nil {
+		t.Fatalf("CreatePayment after shutdown failed: %v", err)
+	}
+	if payment.Status != pb.PaymentProcessingStatus_PAYMENT_PROCESSING_STATUS_PENDING {
+		t.Errorf("expected pending payment after shutdown, got %v", payment.Status)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected no processing goroutine after shutdown, had %d before and %d after", before, after)
+	}
+}
+
+func TestRegisterAllServesHealth(t *testing.T) {
+	bufconnClient := client.NewBufconnClient()
+	grpcServer := grpc.NewServer()
+	registry := NewServiceRegistryWithOptions(WithEnabledServices("user"))
+	registry.RegisterAll(grpcServer)
+	go func() {
+		_ = grpcServer.Serve(bufconnClient.GetListener())
+	}()
+	defer grpcServer.Stop()
+	defer bufconnClient.Close()
+
+	ctx := context.Background()
+	conn, err := bufconnClient.GetConnection(ctx)
+	if err != nil {
+		t.Fatalf("failed to get bufconn connection: %v", err)
+	}
+	healthClient := grpc_health_v1.NewHealthClient(conn)
+
+	check := func(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		resp, err := healthClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("health check of %q failed: %v", service, err)
+		}
+		return resp.Status
+	}
+
+	userService := pb.UserService_ServiceDesc.ServiceName
+	if got := check(userService); got != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("expected %s to be SERVING, got %v", userService, got)
+	}
+	if got := check(""); got != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("expected the server to be SERVING, got %v", got)
+	}
+
+	// Disabled services are unknown to the health server
+	_, err = healthClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: pb.PaymentService_ServiceDesc.ServiceName})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for the disabled PaymentService, got %v", err)
+	}
+
+	registry.MarkNotServing()
+	if got := check(userService); got != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected %s to be NOT_SERVING once shutdown begins, got %v", userService, got)
+	}
+}
+
+func TestDownloadServiceDisabledWithoutDatabaseURL(t *testing.T) {
+	t.Setenv("DATABASE_URL", "")
+	registry, err := NewServiceRegistryWithRealDB(DefaultDBPoolConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	download, ok := registry.DownloadService.(*disabledDownloadService)
+	if !ok {
+		t.Fatalf("expected the disabled DownloadService stub, got %T", registry.DownloadService)
+	}
+
+	ctx := context.Background()
+	calls := map[string]func() error{
+		"DownloadSync": func() error {
+			_, err := download.DownloadSync(ctx, &etcpb.DownloadRequest{})
+			return err
+		},
+		"DownloadAsync": func() error {
+			_, err := download.DownloadAsync(ctx, &etcpb.DownloadRequest{})
+			return err
+		},
+		"GetJobStatus": func() error {
+			_, err := download.GetJobStatus(ctx, &etcpb.GetJobStatusRequest{JobId: "job-1"})
+			return err
+		},
+		"GetAllAccountIDs": func() error {
+			_, err := download.GetAllAccountIDs(ctx, &etcpb.GetAllAccountIDsRequest{})
+			return err
+		},
+	}
+	for name, call := range calls {
+		err := call()
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("%s: expected FailedPrecondition rather than %v", name, err)
+			continue
+		}
+		const want = "download service disabled: DATABASE_URL not configured"
+		if msg := status.Convert(err).Message(); !strings.Contains(msg, want) {
+			t.Errorf("%s: expected message %q, got %q", name, want, msg)
+		}
+	}
+}
\ No newline at end of file
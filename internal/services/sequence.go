@@ -0,0 +1,38 @@
+package services
+
+import "sync/atomic"
+
+// IDSequence allocates unique, monotonically increasing record IDs
+type IDSequence interface {
+	// Next returns the next unused ID
+	Next() int64
+	// Observe records an ID assigned elsewhere so Next never returns it
+	Observe(id int64)
+}
+
+// AtomicSequence is a lock-free in-memory IDSequence
+type AtomicSequence struct {
+	last atomic.Int64
+}
+
+// NewAtomicSequence creates a sequence whose first ID is start
+func NewAtomicSequence(start int64) *AtomicSequence {
+	seq := &AtomicSequence{}
+	seq.last.Store(start - 1)
+	return seq
+}
+
+// Next returns the next unused ID
+func (s *AtomicSequence) Next() int64 {
+	return s.last.Add(1)
+}
+
+// Observe advances the sequence past id if it is not already beyond it
+func (s *AtomicSequence) Observe(id int64) {
+	for {
+		last := s.last.Load()
+		if id <= last || s.last.CompareAndSwap(last, id) {
+			return
+		}
+	}
+}
\ No newline at end of file
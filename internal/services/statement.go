@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+)
+
+// StatementSource supplies the transactions aggregated into monthly statements
+type StatementSource interface {
+	// UserTransactions returns the user's transactions dated within [start, end)
+	UserTransactions(ctx context.Context, userId string, start, end time.Time) ([]*pb.Transaction, error)
+}
+
+// CardTransactionSource finds a user's transactions through the cards they own
+type CardTransactionSource struct {
+	cards        *CardService
+	transactions *TransactionService
+}
+
+// NewCardTransactionSource creates a statement source backed by the card and
+// transaction services
+func NewCardTransactionSource(cards *CardService, transactions *TransactionService) *CardTransactionSource {
+	return &CardTransactionSource{
+		cards:        cards,
+		transactions: transactions,
+	}
+}
+
+// UserTransactions returns the transactions on the user's cards dated within [start, end)
+func (s *CardTransactionSource) UserTransactions(ctx context.Context, userId string, start, end time.Time) ([]*pb.Transaction, error) {
+	var result []*pb.Transaction
+	for _, card := range s.cards.GetCardsByUserId(userId) {
+		for _, transaction := range s.transactions.GetTransactionsByCardId(card.Id) {
+			date := transactionDate(transaction)
+			if !date.Before(start) && date.Before(end) {
+				result = append(result, transaction)
+			}
+		}
+	}
+	return result, nil
+}
+
+// transactionDate is when a transaction is billed, falling back to its exit time
+func transactionDate(transaction *pb.Transaction) time.Time {
+	if transaction.TransactionDate != nil {
+		return transaction.TransactionDate.AsTime()
+	}
+	return transaction.ExitTime.AsTime()
+}
+
+// aggregateStatement sums the trips, distance and amounts of transactions into
+// statement. The card is set only when every transaction used the same card.
+func aggregateStatement(statement *pb.MonthlyStatement, transactions []*pb.Transaction) {
+	for i, transaction := range transactions {
+		statement.TotalTrips++
+		statement.TotalDistance += transaction.Distance
+		statement.TotalAmount += transaction.TollAmount
+		statement.DiscountAmount += transaction.DiscountAmount
+		statement.FinalAmount += transaction.FinalAmount
+
+		if i == 0 {
+			statement.CardId = transaction.CardId
+		} else if statement.CardId != transaction.CardId {
+			statement.CardId = ""
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetMonthlyStatementAggregatesTransactions(t *testing.T) {
+	ctx := context.Background()
+	cards := NewCardService()
+	defer cards.Close()
+	transactions := NewTransactionService()
+	payments := NewPaymentService()
+	defer payments.Close()
+	payments.SetStatementSource(NewCardTransactionSource(cards, transactions))
+	payments.SetStatementLocation(time.UTC)
+
+	card, err := cards.CreateCard(ctx, &pb.CreateCardRequest{
+		UserId:      "statement-user",
+		VehicleType: pb.VehicleType_VEHICLE_TYPE_REGULAR,
+	})
+	if err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+
+	// Two trips in March 2024, one on each side of the month boundary
+	seed := []struct {
+		exit     time.Time
+		distance float64
+		toll     int64
+	}{
+		{time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC), 42.5, 1200},
+		{time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC), 18.25, 640},
+		{time.Date(2024, 2, 29, 23, 59, 0, 0, time.UTC), 100, 3000},
+		{time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), 100, 3000},
+	}
+	var want pb.MonthlyStatement
+	for i, trip := range seed {
+		transaction, err := transactions.CreateTransaction(ctx, card.Id, "Tokyo IC", "Osaka IC", trip.exit.Add(-time.Hour), trip.exit, trip.distance, trip.toll)
+		if err != nil {
+			t.Fatalf("CreateTransaction failed: %v", err)
+		}
+		if i < 2 {
+			want.TotalTrips++
+			want.TotalDistance += transaction.Distance
+			want.TotalAmount += transaction.TollAmount
+			want.DiscountAmount += transaction.DiscountAmount
+			want.FinalAmount += transaction.FinalAmount
+		}
+	}
+
+	statement, err := payments.GetMonthlyStatement(ctx, &pb.GetMonthlyStatementRequest{
+		UserId: "statement-user",
+		Year:   2024,
+		Month:  3,
+	})
+	if err != nil {
+		t.Fatalf("GetMonthlyStatement failed: %v", err)
+	}
+
+	if statement.TotalTrips != want.TotalTrips {
+		t.Errorf("TotalTrips = %d, expected %d", statement.TotalTrips, want.TotalTrips)
+	}
+	if statement.TotalDistance != want.TotalDistance {
+		t.Errorf("TotalDistance = %v, expected %v", statement.TotalDistance, want.TotalDistance)
+	}
+	if statement.TotalAmount != want.TotalAmount {
+		t.Errorf("TotalAmount = %d, expected %d", statement.TotalAmount, want.TotalAmount)
+	}
+	if statement.DiscountAmount != want.DiscountAmount {
+		t.Errorf("DiscountAmount = %d, expected %d", statement.DiscountAmount, want.DiscountAmount)
+	}
+	if statement.FinalAmount != want.FinalAmount {
+		t.Errorf("FinalAmount = %d, expected %d", statement.FinalAmount, want.FinalAmount)
+	}
+	if statement.CardId != card.Id {
+		t.Errorf("CardId = %q, expected %q", statement.CardId, card.Id)
+	}
+	if due := statement.PaymentDueDate.AsTime(); !due.Equal(time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("PaymentDueDate = %v, expected 2024-04-15", due)
+	}
+}
+
+func TestGetMonthlyStatementWithoutTransactions(t *testing.T) {
+	payments := NewPaymentService()
+	defer payments.Close()
+
+	req := &pb.GetMonthlyStatementRequest{UserId: "nobody", Year: 2024, Month: 1}
+	if _, err := payments.GetMonthlyStatement(context.Background(), req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition without a statement source, got %v", err)
+	}
+
+	cards := NewCardService()
+	defer cards.Close()
+	payments.SetStatementSource(NewCardTransactionSource(cards, NewTransactionService()))
+
+	statement, err := payments.GetMonthlyStatement(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetMonthlyStatement failed: %v", err)
+	}
+	if statement.TotalTrips != 0 || statement.FinalAmount != 0 || statement.CardId != "" {
+		t.Errorf("expected an empty statement, got %v", statement)
+	}
+}
+
+func TestGetMonthlyStatementUsesLocationMonthBoundaries(t *testing.T) {
+	ctx := context.Background()
+	cards := NewCardService()
+	defer cards.Close()
+	transactions := NewTransactionService()
+	payments := NewPaymentService()
+	defer payments.Close()
+	payments.SetStatementSource(NewCardTransactionSource(cards, transactions))
+
+	jst := time.FixedZone("JST", 9*60*60)
+	payments.SetStatementLocation(jst)
+
+	card, err := cards.CreateCard(ctx, &pb.CreateCardRequest{
+		UserId:      "statement-jst",
+		VehicleType: pb.VehicleType_VEHICLE_TYPE_REGULAR,
+	})
+	if err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+
+	// 2024-03-01 00:30 in Tokyo is still February in UTC
+	exit := time.Date(2024, 3, 1, 0, 30, 0, 0, jst)
+	if _, err := transactions.CreateTransaction(ctx, card.Id, "Tokyo IC", "Osaka IC", exit.Add(-time.Hour), exit, 10, 500); err != nil {
+		t.Fatalf("CreateTransaction failed: %v", err)
+	}
+
+	for month, trips := range map[int32]int32{2: 0, 3: 1} {
+		statement, err := payments.GetMonthlyStatement(ctx, &pb.GetMonthlyStatementRequest{
+			UserId: "statement-jst",
+			Year:   2024,
+			Month:  month,
+		})
+		if err != nil {
+			t.Fatalf("GetMonthlyStatement failed: %v", err)
+		}
+		if statement.TotalTrips != trips {
+			t.Errorf("month %d: TotalTrips = %d, expected %d", month, statement.TotalTrips, trips)
+		}
+	}
+
+	statement, err := payments.GetMonthlyStatement(ctx, &pb.GetMonthlyStatementRequest{UserId: "statement-jst", Year: 2024, Month: 3})
+	if err != nil {
+		t.Fatalf("GetMonthlyStatement failed: %v", err)
+	}
+	if due := statement.PaymentDueDate.AsTime(); !due.Equal(time.Date(2024, 4, 15, 0, 0, 0, 0, jst)) {
+		t.Errorf("PaymentDueDate = %v, expected 2024-04-15 in JST", due)
+	}
+}
\ No newline at end of file
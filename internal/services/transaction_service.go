@@ -1,9 +1,9 @@
 package services
 
 import (
-	"fmt"
 	"context"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -17,14 +17,24 @@ import (
 // TransactionService implements the TransactionServiceServer interface
 type TransactionService struct {
 	pb.UnimplementedTransactionServiceServer
+	auditReporter
+	pageLimit
 	mu           sync.RWMutex
 	transactions map[string]*pb.Transaction
+
+	subMu       sync.Mutex
+	subscribers map[chan *pb.Transaction]struct{}
 }
 
+// DefaultSubscriptionBuffer is the number of transactions buffered per
+// subscriber before the oldest are dropped
+const DefaultSubscriptionBuffer = 64
+
 // NewTransactionService creates a new TransactionService instance with mock data
 func NewTransactionService() *TransactionService {
 	service := &TransactionService{
 		transactions: make(map[string]*pb.Transaction),
+		subscribers:  make(map[chan *pb.Transaction]struct{}),
 	}
 
 	// Add mock data
@@ -147,19 +157,6 @@ func (s *TransactionService) GetTransactionHistory(ctx context.Context, req *pb.
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	// Default pagination values
-	pageSize := req.PageSize
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	// For simplicity, ignore page token for now in mock implementation
-	skip := 0
-	if req.PageToken != "" {
-		// In real implementation, decode page token to get skip value
-		skip = 0
-	}
-
 	// Filter transactions for the specified card
 	var cardTransactions []*pb.Transaction
 	for _, transaction := range s.transactions {
@@ -176,49 +173,88 @@ func (s *TransactionService) GetTransactionHistory(ctx context.Context, req *pb.
 		}
 	}
 
-	// Sort transactions by date (newest first)
-	for i := 0; i < len(cardTransactions)-1; i++ {
-		for j := i + 1; j < len(cardTransactions); j++ {
-			if cardTransactions[i].TransactionDate.AsTime().Before(cardTransactions[j].TransactionDate.AsTime()) {
-				cardTransactions[i], cardTransactions[j] = cardTransactions[j], cardTransactions[i]
-			}
-		}
+	// Sort transactions by date (newest first), then ID so pages are stable
+	sort.Slice(cardTransactions, func(i, j int) bool {
+		return newerFirst(cardTransactions[i].TransactionDate.AsTime(), cardTransactions[j].TransactionDate.AsTime(), cardTransactions[i].Id, cardTransactions[j].Id)
+	})
+
+	p := s.pageOf(len(cardTransactions), req.PageSize, req.PageToken)
+	transactions := make([]*pb.Transaction, 0, p.end-p.start)
+	transactions = append(transactions, cardTransactions[p.start:p.end]...)
+
+	// Calculate total amount
+	var totalAmount int64
+	for _, tx := range cardTransactions {
+		totalAmount += tx.FinalAmount
 	}
 
-	start := skip
-	end := start + int(pageSize)
+	return &pb.TransactionList{
+		Transactions:  transactions,
+		NextPageToken: p.nextPageToken,
+		TotalAmount:   totalAmount,
+		TotalCount:    int32(len(cardTransactions)),
+		PageSize:      p.size,
+	}, nil
+}
 
-	var transactions []*pb.Transaction
-	var nextPageToken string
+// ListTransactions lists transactions matching the payment status, date
+// range and card filters, newest first
+func (s *TransactionService) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.TransactionList, error) {
+	if req.StartDate != nil && req.EndDate != nil && req.EndDate.AsTime().Before(req.StartDate.AsTime()) {
+		return nil, status.Error(codes.InvalidArgument, "end_date must not be before start_date")
+	}
+	statuses := make(map[pb.PaymentStatus]bool, len(req.PaymentStatus))
+	for _, paymentStatus := range req.PaymentStatus {
+		if _, ok := pb.PaymentStatus_name[int32(paymentStatus)]; !ok || paymentStatus == pb.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid payment status %d", paymentStatus)
+		}
+		statuses[paymentStatus] = true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	if start < len(cardTransactions) {
-		if end > len(cardTransactions) {
-			end = len(cardTransactions)
+	var matched []*pb.Transaction
+	for _, transaction := range s.transactions {
+		if len(statuses) > 0 && !statuses[transaction.PaymentStatus] {
+			continue
+		}
+		if req.CardId != "" && transaction.CardId != req.CardId {
+			continue
 		}
-		transactions = cardTransactions[start:end]
-		// Generate next page token if there are more transactions
-		if end < len(cardTransactions) {
-			nextPageToken = fmt.Sprintf("next_%d", end)
+		if req.StartDate != nil && transaction.TransactionDate.AsTime().Before(req.StartDate.AsTime()) {
+			continue
 		}
-	} else {
-		transactions = []*pb.Transaction{}
+		if req.EndDate != nil && transaction.TransactionDate.AsTime().After(req.EndDate.AsTime()) {
+			continue
+		}
+		matched = append(matched, transaction)
 	}
 
-	// Calculate total amount
+	sort.Slice(matched, func(i, j int) bool {
+		return newerFirst(matched[i].TransactionDate.AsTime(), matched[j].TransactionDate.AsTime(), matched[i].Id, matched[j].Id)
+	})
+
+	p := s.pageOf(len(matched), req.PageSize, req.PageToken)
+	transactions := make([]*pb.Transaction, 0, p.end-p.start)
+	transactions = append(transactions, matched[p.start:p.end]...)
+
 	var totalAmount int64
-	for _, tx := range cardTransactions {
+	for _, tx := range matched {
 		totalAmount += tx.FinalAmount
 	}
 
 	return &pb.TransactionList{
 		Transactions:  transactions,
-		NextPageToken: nextPageToken,
+		NextPageToken: p.nextPageToken,
 		TotalAmount:   totalAmount,
+		TotalCount:    int32(len(matched)),
+		PageSize:      p.size,
 	}, nil
 }
 
 // CreateTransaction creates a new transaction (helper method for testing)
-func (s *TransactionService) CreateTransaction(cardId, entryGateId, exitGateId string, entryTime, exitTime time.Time, distance float64, tollAmount int64) (*pb.Transaction, error) {
+func (s *TransactionService) CreateTransaction(ctx context.Context, cardId, entryGateId, exitGateId string, entryTime, exitTime time.Time, distance float64, tollAmount int64) (*pb.Transaction, error) {
 	if cardId == "" {
 		return nil, status.Error(codes.InvalidArgument, "card ID is required")
 	}
@@ -255,9 +291,58 @@ func (s *TransactionService) CreateTransaction(cardId, entryGateId, exitGateId s
 	}
 
 	s.transactions[transaction.Id] = transaction
+	s.broadcast(transaction)
+	s.recordAudit(ctx, "CreateTransaction", AuditEntityTransaction, transaction.Id)
 	return transaction, nil
 }
 
+// Subscribe returns a channel receiving each transaction created after the
+// call, and a function that unsubscribes and closes the channel. When the
+// subscriber falls more than buffer transactions behind, the oldest are dropped.
+func (s *TransactionService) Subscribe(buffer int) (<-chan *pb.Transaction, func()) {
+	if buffer <= 0 {
+		buffer = DefaultSubscriptionBuffer
+	}
+	ch := make(chan *pb.Transaction, buffer)
+
+	s.subMu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subMu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.subMu.Lock()
+			delete(s.subscribers, ch)
+			close(ch)
+			s.subMu.Unlock()
+		})
+	}
+	return ch, unsubscribe
+}
+
+// broadcast delivers transaction to every subscriber without blocking,
+// dropping a subscriber's oldest buffered transaction when it is full
+func (s *TransactionService) broadcast(transaction *pb.Transaction) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	for ch := range s.subscribers {
+		for {
+			select {
+			case ch <- transaction:
+			default:
+				select {
+				case <-ch:
+				default:
+				}
+				continue
+			}
+			break
+		}
+	}
+}
+
 // GetTransactionCount returns the current number of transactions (helper method for testing)
 func (s *TransactionService) GetTransactionCount() int {
 	s.mu.RLock()
@@ -280,7 +365,7 @@ func (s *TransactionService) GetTransactionsByCardId(cardId string) []*pb.Transa
 }
 
 // UpdateTransactionPaymentStatus updates the payment status of a transaction (helper method)
-func (s *TransactionService) UpdateTransactionPaymentStatus(transactionId string, paymentStatus pb.PaymentStatus) error {
+func (s *TransactionService) UpdateTransactionPaymentStatus(ctx context.Context, transactionId string, paymentStatus pb.PaymentStatus) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -290,5 +375,6 @@ func (s *TransactionService) UpdateTransactionPaymentStatus(transactionId string
 	}
 
 	transaction.PaymentStatus = paymentStatus
+	s.recordAudit(ctx, "UpdateTransactionPaymentStatus", AuditEntityTransaction, transactionId)
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yhonda-ohishi/db-handler-server/internal/audit"
+	"github.com/yhonda-ohishi/db-handler-server/internal/logger"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTransactionSubscriptionDropsOldest(t *testing.T) {
+	ctx := context.Background()
+	service := NewTransactionService()
+	updates, unsubscribe := service.Subscribe(2)
+
+	now := time.Now()
+	var ids []string
+	for i := 0; i < 3; i++ {
+		tx, err := service.CreateTransaction(ctx, "card-1", "gate-001", "gate-002", now.Add(-time.Hour), now, 10, 1000)
+		if err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+		ids = append(ids, tx.Id)
+	}
+
+	// The buffer holds the two newest transactions
+	for _, want := range ids[1:] {
+		got := <-updates
+		if got.Id != want {
+			t.Errorf("expected transaction %s, got %s", want, got.Id)
+		}
+	}
+
+	unsubscribe()
+	if _, ok := <-updates; ok {
+		t.Error("expected channel to be closed after unsubscribe")
+	}
+
+	// Creating after unsubscribe must not panic on the closed channel
+	if _, err := service.CreateTransaction(ctx, "card-1", "gate-001", "gate-002", now.Add(-time.Hour), now, 10, 1000); err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+}
+
+func TestListTransactionsFiltersByPaymentStatus(t *testing.T) {
+	ctx := context.Background()
+	service := NewTransactionService()
+
+	// Seed a card with one transaction per status
+	now := time.Now()
+	seeded := map[pb.PaymentStatus]string{}
+	for _, paymentStatus := range []pb.PaymentStatus{
+		pb.PaymentStatus_PAYMENT_STATUS_PENDING,
+		pb.PaymentStatus_PAYMENT_STATUS_COMPLETED,
+		pb.PaymentStatus_PAYMENT_STATUS_FAILED,
+	} {
+		tx, err := service.CreateTransaction(ctx, "card-recon", "gate-001", "gate-002", now.Add(-time.Hour), now, 10, 1000)
+		if err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+		if err := service.UpdateTransactionPaymentStatus(ctx, tx.Id, paymentStatus); err != nil {
+			t.Fatalf("failed to set payment status: %v", err)
+		}
+		seeded[paymentStatus] = tx.Id
+	}
+
+	t.Run("status and card", func(t *testing.T) {
+		resp, err := service.ListTransactions(ctx, &pb.ListTransactionsRequest{
+			CardId:        "card-recon",
+			PaymentStatus: []pb.PaymentStatus{pb.PaymentStatus_PAYMENT_STATUS_FAILED, pb.PaymentStatus_PAYMENT_STATUS_PENDING},
+		})
+		if err != nil {
+			t.Fatalf("ListTransactions failed: %v", err)
+		}
+		got := map[string]bool{}
+		for _, tx := range resp.Transactions {
+			got[tx.Id] = true
+		}
+		if len(got) != 2 || !got[seeded[pb.PaymentStatus_PAYMENT_STATUS_FAILED]] || !got[seeded[pb.PaymentStatus_PAYMENT_STATUS_PENDING]] {
+			t.Errorf("expected the failed and pending transactions, got %v", got)
+		}
+		if resp.TotalCount != 2 {
+			t.Errorf("expected total count 2, got %d", resp.TotalCount)
+		}
+	})
+
+	t.Run("status across cards", func(t *testing.T) {
+		failedCount := 0
+		for _, tx := range service.transactions {
+			if tx.PaymentStatus == pb.PaymentStatus_PAYMENT_STATUS_FAILED {
+				failedCount++
+			}
+		}
+
+		failed, err := service.ListTransactions(ctx, &pb.ListTransactionsRequest{
+			PaymentStatus: []pb.PaymentStatus{pb.PaymentStatus_PAYMENT_STATUS_FAILED},
+		})
+		if err != nil {
+			t.Fatalf("ListTransactions failed: %v", err)
+		}
+		if int(failed.TotalCount) != failedCount {
+			t.Errorf("expected %d failed transactions, got %d", failedCount, failed.TotalCount)
+		}
+		for _, tx := range failed.Transactions {
+			if tx.PaymentStatus != pb.PaymentStatus_PAYMENT_STATUS_FAILED {
+				t.Errorf("transaction %s has status %s", tx.Id, tx.PaymentStatus)
+			}
+		}
+	})
+
+	t.Run("date range and pagination", func(t *testing.T) {
+		resp, err := service.ListTransactions(ctx, &pb.ListTransactionsRequest{
+			CardId:    "card-recon",
+			StartDate: timestamppb.New(now.Add(-time.Minute)),
+			PageSize:  2,
+		})
+		if err != nil {
+			t.Fatalf("ListTransactions failed: %v", err)
+		}
+		if len(resp.Transactions) != 2 || resp.TotalCount != 3 || resp.NextPageToken == "" {
+			t.Errorf("expected a first page of 2 of 3 transactions, got %d of %d (next %q)", len(resp.Transactions), resp.TotalCount, resp.NextPageToken)
+		}
+
+		resp, err = service.ListTransactions(ctx, &pb.ListTransactionsRequest{
+			CardId:  "card-recon",
+			EndDate: timestamppb.New(now.Add(-time.Minute)),
+		})
+		if err != nil {
+			t.Fatalf("ListTransactions failed: %v", err)
+		}
+		if len(resp.Transactions) != 0 {
+			t.Errorf("expected no transactions before the range, got %d", len(resp.Transactions))
+		}
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		_, err := service.ListTransactions(ctx, &pb.ListTransactionsRequest{
+			PaymentStatus: []pb.PaymentStatus{pb.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument for an unspecified status, got %v", err)
+		}
+
+		_, err = service.ListTransactions(ctx, &pb.ListTransactionsRequest{
+			StartDate: timestamppb.New(now),
+			EndDate:   timestamppb.New(now.Add(-time.Hour)),
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument for an inverted date range, got %v", err)
+		}
+	})
+}
+
+func TestTransactionWritesRecordAudit(t *testing.T) {
+	registry := NewServiceRegistry()
+	defer registry.CardService.Close()
+	defer registry.PaymentService.Close()
+	ctx := logger.ContextWithUserID(context.Background(), "gate-operator")
+
+	now := time.Now()
+	tx, err := registry.TransactionService.CreateTransaction(ctx, "card-audit", "gate-001", "gate-002", now.Add(-time.Hour), now, 10, 1000)
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if err := registry.TransactionService.UpdateTransactionPaymentStatus(ctx, tx.Id, pb.PaymentStatus_PAYMENT_STATUS_FAILED); err != nil {
+		t.Fatalf("failed to set payment status: %v", err)
+	}
+
+	result, err := registry.AuditLog.Query(audit.QueryOptions{EntityType: AuditEntityTransaction})
+	if err != nil {
+		t.Fatalf("audit query failed: %v", err)
+	}
+	if len(result.Entries) != 2 {
+		t.Fatalf("expected 2 transaction audit entries, got %+v", result.Entries)
+	}
+	for i, method := range []string{"CreateTransaction", "UpdateTransactionPaymentStatus"} {
+		entry := result.Entries[i]
+		if entry.Method != method || entry.EntityID != tx.Id || entry.UserID != "gate-operator" || entry.UserIDSource != audit.UserIDSourceHeader {
+			t.Errorf("expected %s of %s by gate-operator from the header, got %+v", method, tx.Id, entry)
+		}
+	}
+}
\ No newline at end of file
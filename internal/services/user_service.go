@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/yhonda-ohishi/db-handler-server/internal/validation"
 	pb "github.com/yhonda-ohishi/db-handler-server/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -18,14 +21,40 @@ import (
 // UserService implements the UserServiceServer interface
 type UserService struct {
 	pb.UnimplementedUserServiceServer
+	auditReporter
+	pageLimit
 	mu    sync.RWMutex
 	users map[string]*pb.User
+	// emails indexes user IDs by normalized email
+	emails            map[string]string
+	maxFieldMaskPaths int
+	// phonePattern validates phone numbers when set; nil accepts any value
+	phonePattern *regexp.Regexp
+	// idempotency replays CreateUser responses for repeated idempotency keys
+	idempotency *idempotencyStore
+}
+
+// MaxSearchQueryLength is the longest query accepted by SearchUsers
+const MaxSearchQueryLength = 100
+
+// E164PhonePattern matches phone numbers in E.164 format, e.g. +818012345678
+var E164PhonePattern = validation.E164PhonePattern
+
+// userUpdatableFields are the user fields that can be named in an update mask
+var userUpdatableFields = map[string]bool{
+	"email":        true,
+	"name":         true,
+	"phone_number": true,
+	"address":      true,
 }
 
 // NewUserService creates a new UserService instance with mock data
 func NewUserService() *UserService {
 	service := &UserService{
-		users: make(map[string]*pb.User),
+		users:             make(map[string]*pb.User),
+		emails:            make(map[string]string),
+		maxFieldMaskPaths: DefaultMaxFieldMaskPaths,
+		idempotency:       newIdempotencyStore(DefaultIdempotencyTTL),
 	}
 
 	// Add mock data
@@ -70,9 +99,16 @@ func (s *UserService) addMockData() {
 
 	for _, user := range mockUsers {
 		s.users[user.Id] = user
+		s.emails[normalizeEmail(user.Email)] = user.Id
 	}
 }
 
+// normalizeEmail returns the key used to index email, which matches
+// case-insensitively
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // GetUser retrieves a user by ID
 func (s *UserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
 	if req.Id == "" {
@@ -83,36 +119,39 @@ func (s *UserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.
 	defer s.mu.RUnlock()
 
 	user, exists := s.users[req.Id]
-	if !exists {
+	if !exists || (isDeleted(user) && !req.IncludeDeleted) {
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 
 	return user, nil
 }
 
+// isDeleted reports whether user has been soft-deleted
+func isDeleted(user *pb.User) bool {
+	return user.Status == pb.UserStatus_USER_STATUS_DELETED
+}
+
 // CreateUser creates a new user
 func (s *UserService) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
-	// Validate required fields
-	if req.Email == "" {
-		return nil, status.Error(codes.InvalidArgument, "email is required")
-	}
-	if req.Name == "" {
-		return nil, status.Error(codes.InvalidArgument, "name is required")
-	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	// Basic email validation
-	if !strings.Contains(req.Email, "@") {
-		return nil, status.Error(codes.InvalidArgument, "invalid email format")
+	if err := validation.ValidateCreateUser(req, s.phonePattern); err != nil {
+		return nil, err
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	// Replay the original response to a retried request
+	key := idempotencyKeyFromContext(ctx)
+	if replay, err := s.idempotency.lookup(key, req); err != nil || replay != nil {
+		if err != nil {
+			return nil, err
+		}
+		return replay.(*pb.User), nil
+	}
 
 	// Check if email already exists
-	for _, user := range s.users {
-		if user.Email == req.Email {
-			return nil, status.Error(codes.AlreadyExists, "user with this email already exists")
-		}
+	if _, exists := s.emails[normalizeEmail(req.Email)]; exists {
+		return nil, status.Error(codes.AlreadyExists, "user with this email already exists")
 	}
 
 	// Create new user
@@ -129,9 +168,29 @@ func (s *UserService) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	}
 
 	s.users[user.Id] = user
+	s.emails[normalizeEmail(user.Email)] = user.Id
+	s.idempotency.save(key, req, user)
+	s.recordAudit(ctx, "CreateUser", AuditEntityUser, user.Id)
 	return user, nil
 }
 
+// GetUserByEmail retrieves a user by email, matched case-insensitively
+func (s *UserService) GetUserByEmail(ctx context.Context, req *pb.GetUserByEmailRequest) (*pb.User, error) {
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, exists := s.emails[normalizeEmail(req.Email)]
+	if !exists || isDeleted(s.users[id]) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	return s.users[id], nil
+}
+
 // UpdateUser updates an existing user
 func (s *UserService) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
 	if req.Id == "" {
@@ -141,42 +200,65 @@ func (s *UserService) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := validateFieldMask(req.UpdateMask, userUpdatableFields, s.maxFieldMaskPaths); err != nil {
+		return nil, err
+	}
+
 	user, exists := s.users[req.Id]
-	if !exists {
+	if !exists || isDeleted(user) {
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 
-	// Update fields if provided
-	if req.Email != "" {
-		// Check if new email already exists (but not for the same user)
-		for id, existingUser := range s.users {
-			if id != req.Id && existingUser.Email == req.Email {
-				return nil, status.Error(codes.AlreadyExists, "user with this email already exists")
-			}
-		}
+	if err := validation.ValidateUpdateUser(req, s.phonePattern); err != nil {
+		return nil, err
+	}
+	update := validation.UserUpdateFields(req)
 
-		// Basic email validation
-		if !strings.Contains(req.Email, "@") {
-			return nil, status.Error(codes.InvalidArgument, "invalid email format")
+	// Check if new email already exists (but not for the same user)
+	if update["email"] {
+		if id, exists := s.emails[normalizeEmail(req.Email)]; exists && id != req.Id {
+			return nil, status.Error(codes.AlreadyExists, "user with this email already exists")
 		}
+	}
 
+	if update["email"] {
+		delete(s.emails, normalizeEmail(user.Email))
 		user.Email = req.Email
+		s.emails[normalizeEmail(user.Email)] = user.Id
 	}
-	if req.Name != "" {
+	if update["name"] {
 		user.Name = req.Name
 	}
-	if req.PhoneNumber != "" {
+	if update["phone_number"] {
 		user.PhoneNumber = req.PhoneNumber
 	}
-	if req.Address != "" {
+	if update["address"] {
 		user.Address = req.Address
 	}
 
 	user.UpdatedAt = timestamppb.New(time.Now())
+	s.recordAudit(ctx, "UpdateUser", AuditEntityUser, user.Id)
 	return user, nil
 }
 
-// DeleteUser deletes a user by ID
+// SetMaxFieldMaskPaths sets the maximum number of update mask paths accepted
+// by UpdateUser. Zero or a negative value disables the limit.
+func (s *UserService) SetMaxFieldMaskPaths(max int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxFieldMaskPaths = max
+}
+
+// SetPhonePattern sets the pattern phone numbers must match in CreateUser and
+// UpdateUser. Empty phone numbers are always accepted; nil disables validation.
+func (s *UserService) SetPhonePattern(pattern *regexp.Regexp) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.phonePattern = pattern
+}
+
+// DeleteUser soft-deletes a user by ID, keeping the record (and reserving its
+// email) for audit history. HardDelete erases the user entirely.
 func (s *UserService) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
 	if req.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "user ID is required")
@@ -185,12 +267,23 @@ func (s *UserService) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	_, exists := s.users[req.Id]
-	if !exists {
+	user, exists := s.users[req.Id]
+	if !exists || (isDeleted(user) && !req.HardDelete) {
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 
-	delete(s.users, req.Id)
+	if req.HardDelete {
+		delete(s.users, req.Id)
+		delete(s.emails, normalizeEmail(user.Email))
+		s.recordAudit(ctx, "DeleteUser", AuditEntityUser, req.Id)
+		return &emptypb.Empty{}, nil
+	}
+
+	now := timestamppb.New(time.Now())
+	user.Status = pb.UserStatus_USER_STATUS_DELETED
+	user.DeletedAt = now
+	user.UpdatedAt = now
+	s.recordAudit(ctx, "DeleteUser", AuditEntityUser, req.Id)
 	return &emptypb.Empty{}, nil
 }
 
@@ -199,57 +292,63 @@ func (s *UserService) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	// Default pagination values
-	pageSize := req.PageSize
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 10
-	}
-
-	// For simplicity, ignore page token for now in mock implementation
-	skip := 0
-	if req.PageToken != "" {
-		// In real implementation, decode page token to get skip value
-		skip = 0
-	}
-
 	// Convert map to slice for pagination
 	allUsers := make([]*pb.User, 0, len(s.users))
 	for _, user := range s.users {
+		if isDeleted(user) && !req.IncludeDeleted {
+			continue
+		}
 		allUsers = append(allUsers, user)
 	}
 
-	// Sort by creation date (newest first)
-	for i := 0; i < len(allUsers)-1; i++ {
-		for j := i + 1; j < len(allUsers); j++ {
-			if allUsers[i].CreatedAt.AsTime().Before(allUsers[j].CreatedAt.AsTime()) {
-				allUsers[i], allUsers[j] = allUsers[j], allUsers[i]
-			}
-		}
-	}
+	return s.paginateUsers(allUsers, req.PageSize, req.PageToken), nil
+}
 
-	start := skip
-	end := start + int(pageSize)
+// SearchUsers lists users whose name or email contains the query,
+// case-insensitively, with the same pagination as ListUsers
+func (s *UserService) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.ListUsersResponse, error) {
+	query := strings.ToLower(strings.TrimSpace(req.Query))
+	if query == "" {
+		return nil, status.Error(codes.InvalidArgument, "search query is required")
+	}
+	if utf8.RuneCountInString(query) > MaxSearchQueryLength {
+		return nil, status.Errorf(codes.InvalidArgument, "search query exceeds %d characters", MaxSearchQueryLength)
+	}
 
-	var users []*pb.User
-	var nextPageToken string
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	if start < len(allUsers) {
-		if end > len(allUsers) {
-			end = len(allUsers)
+	var matches []*pb.User
+	for _, user := range s.users {
+		if isDeleted(user) {
+			continue
 		}
-		users = allUsers[start:end]
-		// Generate next page token if there are more users
-		if end < len(allUsers) {
-			nextPageToken = fmt.Sprintf("next_%d", end)
+		if strings.Contains(strings.ToLower(user.Name), query) || strings.Contains(strings.ToLower(user.Email), query) {
+			matches = append(matches, user)
 		}
-	} else {
-		users = []*pb.User{}
 	}
 
+	return s.paginateUsers(matches, req.PageSize, req.PageToken), nil
+}
+
+// paginateUsers sorts users by creation date (newest first) and returns the
+// requested page
+func (s *UserService) paginateUsers(allUsers []*pb.User, pageSize int32, pageToken string) *pb.ListUsersResponse {
+	// Sort by creation date (newest first), then ID so pages are stable
+	sort.Slice(allUsers, func(i, j int) bool {
+		return newerFirst(allUsers[i].CreatedAt.AsTime(), allUsers[j].CreatedAt.AsTime(), allUsers[i].Id, allUsers[j].Id)
+	})
+
+	p := s.pageOf(len(allUsers), pageSize, pageToken)
+	users := make([]*pb.User, 0, p.end-p.start)
+	users = append(users, allUsers[p.start:p.end]...)
+
 	return &pb.ListUsersResponse{
 		Users:         users,
-		NextPageToken: nextPageToken,
-	}, nil
+		NextPageToken: p.nextPageToken,
+		TotalCount:    int32(len(allUsers)),
+		PageSize:      p.size,
+	}
 }
 
 // GetUserCount returns the current number of users (helper method for testing)
@@ -257,17 +356,4 @@ func (s *UserService) GetUserCount() int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	return len(s.users)
-}
-
-// GetUserByEmail retrieves a user by email (helper method for testing)
-func (s *UserService) GetUserByEmail(email string) (*pb.User, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	for _, user := range s.users {
-		if user.Email == email {
-			return user, nil
-		}
-	}
-	return nil, fmt.Errorf("user with email %s not found", email)
 }
\ No newline at end of file
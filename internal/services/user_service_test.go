@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/yhonda-ohishi/db-handler-server/internal/audit"
+	"github.com/yhonda-ohishi/db-handler-server/internal/logger"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestUpdateUserFieldMask(t *testing.T) {
+	ctx := context.Background()
+	service := NewUserService()
+
+	user, err := service.CreateUser(ctx, &pb.CreateUserRequest{
+		Email:       "mask@example.com",
+		Name:        "Mask User",
+		PhoneNumber: "090-1234-5678",
+		Address:     "Tokyo, Japan",
+	})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	t.Run("masked fields only", func(t *testing.T) {
+		updated, err := service.UpdateUser(ctx, &pb.UpdateUserRequest{
+			Id:         user.Id,
+			Name:       "Renamed User",
+			Address:    "",
+			Email:      "ignored@example.com",
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "address"}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.Name != "Renamed User" {
+			t.Errorf("expected name 'Renamed User', got %q", updated.Name)
+		}
+		if updated.Address != "" {
+			t.Errorf("expected address to be cleared, got %q", updated.Address)
+		}
+		if updated.Email != "mask@example.com" {
+			t.Errorf("expected email to be unchanged, got %q", updated.Email)
+		}
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		_, err := service.UpdateUser(ctx, &pb.UpdateUserRequest{
+			Id:         user.Id,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "password"}},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("over limit", func(t *testing.T) {
+		service.SetMaxFieldMaskPaths(2)
+		defer service.SetMaxFieldMaskPaths(DefaultMaxFieldMaskPaths)
+
+		_, err := service.UpdateUser(ctx, &pb.UpdateUserRequest{
+			Id:         user.Id,
+			Name:       "Over Limit",
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "address", "phone_number"}},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestUserPhoneValidation(t *testing.T) {
+	ctx := context.Background()
+	service := NewUserService()
+	service.SetPhonePattern(E164PhonePattern)
+
+	tests := []struct {
+		name     string
+		phone    string
+		wantCode codes.Code
+	}{
+		{"valid E.164", "+818012345678", codes.OK},
+		{"invalid string", "abc", codes.InvalidArgument},
+		{"empty allowed", "", codes.OK},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := service.CreateUser(ctx, &pb.CreateUserRequest{
+				Email:       fmt.Sprintf("phone%d@example.com", i),
+				Name:        "Phone User",
+				PhoneNumber: tt.phone,
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("CreateUser: expected %v, got %v", tt.wantCode, err)
+			}
+
+			if user == nil {
+				user, err = service.CreateUser(ctx, &pb.CreateUserRequest{
+					Email: fmt.Sprintf("phone-update%d@example.com", i),
+					Name:  "Phone User",
+				})
+				if err != nil {
+					t.Fatalf("failed to create user: %v", err)
+				}
+			}
+
+			_, err = service.UpdateUser(ctx, &pb.UpdateUserRequest{
+				Id:          user.Id,
+				PhoneNumber: tt.phone,
+				UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"phone_number"}},
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("UpdateUser: expected %v, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestUserEmailIndex(t *testing.T) {
+	ctx := context.Background()
+	service := NewUserService()
+
+	user, err := service.CreateUser(ctx, &pb.CreateUserRequest{Email: "Index@Example.com", Name: "Index User"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	t.Run("duplicate differing in case", func(t *testing.T) {
+		_, err := service.CreateUser(ctx, &pb.CreateUserRequest{Email: "index@example.COM", Name: "Duplicate"})
+		if status.Code(err) != codes.AlreadyExists {
+			t.Errorf("expected AlreadyExists, got %v", err)
+		}
+	})
+
+	t.Run("lookup is case-insensitive", func(t *testing.T) {
+		found, err := service.GetUserByEmail(ctx, &pb.GetUserByEmailRequest{Email: "INDEX@example.com"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if found.Id != user.Id {
+			t.Errorf("expected user %s, got %s", user.Id, found.Id)
+		}
+	})
+
+	t.Run("update moves the index", func(t *testing.T) {
+		_, err := service.UpdateUser(ctx, &pb.UpdateUserRequest{Id: user.Id, Email: "moved@example.com"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := service.GetUserByEmail(ctx, &pb.GetUserByEmailRequest{Email: "index@example.com"}); status.Code(err) != codes.NotFound {
+			t.Errorf("expected old email to be NotFound, got %v", err)
+		}
+		if _, err := service.CreateUser(ctx, &pb.CreateUserRequest{Email: "index@example.com", Name: "Reuser"}); err != nil {
+			t.Errorf("expected old email to be reusable, got %v", err)
+		}
+	})
+
+	t.Run("delete removes the index", func(t *testing.T) {
+		if _, err := service.DeleteUser(ctx, &pb.DeleteUserRequest{Id: user.Id}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := service.GetUserByEmail(ctx, &pb.GetUserByEmailRequest{Email: "moved@example.com"}); status.Code(err) != codes.NotFound {
+			t.Errorf("expected NotFound after delete, got %v", err)
+		}
+	})
+
+	t.Run("empty email", func(t *testing.T) {
+		if _, err := service.GetUserByEmail(ctx, &pb.GetUserByEmailRequest{}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestUserSoftDelete(t *testing.T) {
+	ctx := context.Background()
+	service := NewUserService()
+
+	user, err := service.CreateUser(ctx, &pb.CreateUserRequest{Email: "soft@example.com", Name: "Soft User"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if _, err := service.DeleteUser(ctx, &pb.DeleteUserRequest{Id: user.Id}); err != nil {
+		t.Fatalf("failed to delete user: %v", err)
+	}
+
+	if _, err := service.GetUser(ctx, &pb.GetUserRequest{Id: user.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for soft-deleted user, got %v", err)
+	}
+
+	deleted, err := service.GetUser(ctx, &pb.GetUserRequest{Id: user.Id, IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("expected soft-deleted user with include_deleted, got %v", err)
+	}
+	if deleted.Status != pb.UserStatus_USER_STATUS_DELETED || deleted.DeletedAt == nil {
+		t.Errorf("expected deleted status and deleted_at, got %v and %v", deleted.Status, deleted.DeletedAt)
+	}
+
+	listed := func(includeDeleted bool) bool {
+		resp, err := service.ListUsers(ctx, &pb.ListUsersRequest{PageSize: 100, IncludeDeleted: includeDeleted})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, u := range resp.Users {
+			if u.Id == user.Id {
+				return true
+			}
+		}
+		return false
+	}
+	if listed(false) {
+		t.Error("expected soft-deleted user to be excluded from ListUsers")
+	}
+	if !listed(true) {
+		t.Error("expected soft-deleted user in ListUsers with include_deleted")
+	}
+
+	// The email stays reserved until the user is erased
+	if _, err := service.CreateUser(ctx, &pb.CreateUserRequest{Email: "soft@example.com", Name: "Reuser"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists for soft-deleted email, got %v", err)
+	}
+
+	if _, err := service.DeleteUser(ctx, &pb.DeleteUserRequest{Id: user.Id, HardDelete: true}); err != nil {
+		t.Fatalf("failed to hard delete user: %v", err)
+	}
+	if _, err := service.GetUser(ctx, &pb.GetUserRequest{Id: user.Id, IncludeDeleted: true}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound after hard delete, got %v", err)
+	}
+	if _, err := service.CreateUser(ctx, &pb.CreateUserRequest{Email: "soft@example.com", Name: "Reuser"}); err != nil {
+		t.Errorf("expected email to be reusable after hard delete, got %v", err)
+	}
+}
+
+func TestSearchUsers(t *testing.T) {
+	ctx := context.Background()
+	service := NewUserService()
+
+	seed := []*pb.CreateUserRequest{
+		{Email: "taro.yamada@example.com", Name: "Taro Yamada"},
+		{Email: "hanako@example.com", Name: "Hanako Yamada"},
+		{Email: "ichiro@yamada-corp.jp", Name: "Ichiro Suzuki"},
+		{Email: "jiro@example.com", Name: "Jiro Tanaka"},
+	}
+	for _, req := range seed {
+		if _, err := service.CreateUser(ctx, req); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+
+	resp, err := service.SearchUsers(ctx, &pb.SearchUsersRequest{Query: "YAMADA"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, user := range resp.Users {
+		got[user.Email] = true
+	}
+	for _, want := range []string{"taro.yamada@example.com", "hanako@example.com", "ichiro@yamada-corp.jp"} {
+		if !got[want] {
+			t.Errorf("expected %s in results", want)
+		}
+	}
+	if len(resp.Users) != 3 {
+		t.Errorf("expected 3 matches, got %d", len(resp.Users))
+	}
+
+	paged, err := service.SearchUsers(ctx, &pb.SearchUsersRequest{Query: "yamada", PageSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paged.Users) != 2 || paged.NextPageToken == "" {
+		t.Errorf("expected 2 users and a next page token, got %d and %q", len(paged.Users), paged.NextPageToken)
+	}
+
+	if _, err := service.SearchUsers(ctx, &pb.SearchUsersRequest{Query: "  "}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for empty query, got %v", err)
+	}
+	long := strings.Repeat("a", MaxSearchQueryLength+1)
+	if _, err := service.SearchUsers(ctx, &pb.SearchUsersRequest{Query: long}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for long query, got %v", err)
+	}
+}
+
+func TestCreateUserRecordsAudit(t *testing.T) {
+	registry := NewServiceRegistry()
+	ctx := logger.ContextWithUserID(context.Background(), "admin-42")
+
+	user, err := registry.UserService.CreateUser(ctx, &pb.CreateUserRequest{Email: "audited@example.com", Name: "Audited"})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	// Failed operations aren't recorded
+	if _, err := registry.UserService.CreateUser(ctx, &pb.CreateUserRequest{Email: "audited@example.com", Name: "Again"}); err == nil {
+		t.Fatal("expected duplicate email to fail")
+	}
+	if _, err := registry.CardService.CreateCard(ctx, &pb.CreateCardRequest{UserId: user.Id, VehicleType: pb.VehicleType_VEHICLE_TYPE_REGULAR}); err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+
+	result, err := registry.AuditLog.Query(audit.QueryOptions{EntityType: AuditEntityUser})
+	if err != nil {
+		t.Fatalf("audit query failed: %v", err)
+	}
+	if len(result.Entries) != 1 {
+		t.Fatalf("expected 1 user audit entry, got %+v", result.Entries)
+	}
+	entry := result.Entries[0]
+	if entry.Method != "CreateUser" || entry.EntityID != user.Id || entry.UserID != "admin-42" {
+		t.Errorf("expected CreateUser of %s by admin-42, got %+v", user.Id, entry)
+	}
+	if entry.Timestamp.IsZero() {
+		t.Error("expected the entry to be timestamped")
+	}
+
+	if registry.AuditLog.Len() != 2 {
+		t.Errorf("expected user and card entries, got %d entries", registry.AuditLog.Len())
+	}
+}
\ No newline at end of file
@@ -0,0 +1,15 @@
+package services
+
+import "github.com/yhonda-ohishi/db-handler-server/internal/validation"
+
+// Field violation reasons reported in BadRequest error details
+const (
+	ViolationRequired      = validation.ViolationRequired
+	ViolationInvalidFormat = validation.ViolationInvalidFormat
+	ViolationOutOfRange    = validation.ViolationOutOfRange
+	ViolationInconsistent  = validation.ViolationInconsistent
+)
+
+// fieldViolations collects field-level validation failures so they can be
+// reported together as an InvalidArgument status with BadRequest details
+type fieldViolations = validation.Violations
\ No newline at end of file
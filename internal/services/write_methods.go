@@ -0,0 +1,43 @@
+package services
+
+import (
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+)
+
+// WriteMethods lists the full gRPC method names that modify data, which
+// read-only mode rejects. Add every new mutating RPC here; the services'
+// tests fail for an RPC that is neither listed here nor known to be a read.
+var WriteMethods = []string{
+	pb.UserService_CreateUser_FullMethodName,
+	pb.UserService_UpdateUser_FullMethodName,
+	pb.UserService_DeleteUser_FullMethodName,
+
+	pb.CardService_CreateCard_FullMethodName,
+	pb.CardService_UpdateCard_FullMethodName,
+	pb.CardService_DeleteCard_FullMethodName,
+	pb.CardService_BulkUpdateCardStatus_FullMethodName,
+
+	pb.PaymentService_CreatePayment_FullMethodName,
+
+	pb.ETCService_CreateETCMeisai_FullMethodName,
+	pb.ETCService_UpdateETCMeisai_FullMethodName,
+	pb.ETCService_DeleteETCMeisai_FullMethodName,
+	pb.ETCService_BulkCreateETCMeisai_FullMethodName,
+	pb.ETCService_BulkUpdateETCMeisai_FullMethodName,
+	pb.ETCService_BulkDeleteETCMeisai_FullMethodName,
+	pb.ETCService_DeduplicateETCMeisai_FullMethodName,
+
+	// db_service services registered in single mode
+	"/db_service.ETCMeisaiService/Create",
+	"/db_service.ETCMeisaiService/Update",
+	"/db_service.ETCMeisaiService/Delete",
+	"/db_service.DTakoUriageKeihiService/Create",
+	"/db_service.DTakoUriageKeihiService/Update",
+	"/db_service.DTakoUriageKeihiService/Delete",
+	"/db_service.DTakoFerryRowsService/Create",
+	"/db_service.DTakoFerryRowsService/Update",
+	"/db_service.DTakoFerryRowsService/Delete",
+	"/db_service.ETCMeisaiMappingService/Create",
+	"/db_service.ETCMeisaiMappingService/Update",
+	"/db_service.ETCMeisaiMappingService/Delete",
+}
\ No newline at end of file
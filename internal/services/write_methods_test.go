@@ -0,0 +1,68 @@
+package services
+
+import (
+	"testing"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc"
+)
+
+// readMethods are the RPCs that only read data
+var readMethods = map[string]bool{
+	pb.UserService_GetUser_FullMethodName:                      true,
+	pb.UserService_GetUserByEmail_FullMethodName:               true,
+	pb.UserService_ListUsers_FullMethodName:                    true,
+	pb.UserService_SearchUsers_FullMethodName:                  true,
+	pb.CardService_GetCard_FullMethodName:                      true,
+	pb.CardService_ListCards_FullMethodName:                    true,
+	pb.PaymentService_GetPayment_FullMethodName:                true,
+	pb.PaymentService_ListPayments_FullMethodName:              true,
+	pb.PaymentService_GetMonthlyStatement_FullMethodName:       true,
+	pb.TransactionService_GetTransaction_FullMethodName:        true,
+	pb.TransactionService_GetTransactionHistory_FullMethodName: true,
+	pb.TransactionService_ListTransactions_FullMethodName:      true,
+	pb.ETCService_GetETCMeisai_FullMethodName:                  true,
+	pb.ETCService_ListETCMeisai_FullMethodName:                 true,
+	pb.ETCService_StreamETCMeisai_FullMethodName:               true,
+	pb.ETCService_GetETCMeisaiByDateRange_FullMethodName:       true,
+	pb.ETCService_GetETCMeisaiByHash_FullMethodName:            true,
+	pb.ETCService_GetUnmappedETCMeisai_FullMethodName:          true,
+	pb.ETCService_CheckDuplicatesByHash_FullMethodName:         true,
+	pb.ETCService_GenerateHash_FullMethodName:                  true,
+	pb.ETCService_BatchGenerateHash_FullMethodName:             true,
+	pb.ETCService_GetETCSummary_FullMethodName:                 true,
+	pb.ETCService_GetMonthlyStats_FullMethodName:               true,
+	pb.ETCService_GetDailyStats_FullMethodName:                 true,
+}
+
+func TestEveryRPCIsClassifiedAsReadOrWrite(t *testing.T) {
+	writes := map[string]bool{}
+	for _, method := range WriteMethods {
+		writes[method] = true
+	}
+
+	for _, desc := range []grpc.ServiceDesc{
+		pb.UserService_ServiceDesc,
+		pb.CardService_ServiceDesc,
+		pb.PaymentService_ServiceDesc,
+		pb.TransactionService_ServiceDesc,
+		pb.ETCService_ServiceDesc,
+	} {
+		var names []string
+		for _, method := range desc.Methods {
+			names = append(names, method.MethodName)
+		}
+		for _, stream := range desc.Streams {
+			names = append(names, stream.StreamName)
+		}
+		for _, name := range names {
+			fullMethod := "/" + desc.ServiceName + "/" + name
+			switch {
+			case writes[fullMethod] && readMethods[fullMethod]:
+				t.Errorf("%s is listed as both a read and a write", fullMethod)
+			case !writes[fullMethod] && !readMethods[fullMethod]:
+				t.Errorf("%s is not classified; add it to WriteMethods if it modifies data", fullMethod)
+			}
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Phone formats accepted by PhonePattern
+const (
+	PhoneFormatAny      = "any"
+	PhoneFormatE164     = "e164"
+	PhoneFormatJapan    = "jp"
+	PhoneFormatE164OrJP = "e164_or_jp"
+	DefaultPhoneFormat  = PhoneFormatE164OrJP
+)
+
+// E164PhonePattern matches phone numbers in E.164 format, e.g. +818012345678
+var E164PhonePattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// JapanesePhonePattern matches domestic Japanese numbers with or without
+// hyphens, e.g. 090-1234-5678 or 0312345678, and +81 numbers written with
+// hyphens, e.g. +81-90-1234-5678
+var JapanesePhonePattern = regexp.MustCompile(`^(0[0-9]{9,10}|0[0-9]{1,4}-[0-9]{1,4}-[0-9]{4}|\+81-[1-9][0-9]{0,3}-[0-9]{1,4}-[0-9]{4})$`)
+
+// E164OrJapanesePhonePattern matches either E164PhonePattern or JapanesePhonePattern
+var E164OrJapanesePhonePattern = regexp.MustCompile(E164PhonePattern.String() + `|` + JapanesePhonePattern.String())
+
+// PhonePattern returns the pattern for a phone format, or nil for
+// PhoneFormatAny. An empty format uses DefaultPhoneFormat.
+func PhonePattern(format string) (*regexp.Regexp, error) {
+	switch format {
+	case "":
+		return PhonePattern(DefaultPhoneFormat)
+	case PhoneFormatAny:
+		return nil, nil
+	case PhoneFormatE164:
+		return E164PhonePattern, nil
+	case PhoneFormatJapan:
+		return JapanesePhonePattern, nil
+	case PhoneFormatE164OrJP:
+		return E164OrJapanesePhonePattern, nil
+	default:
+		return nil, fmt.Errorf("invalid phone format: %q", format)
+	}
+}
+
+// ValidatePhoneNumber checks phone against pattern. Empty phone numbers are
+// always accepted; a nil pattern accepts any value.
+func ValidatePhoneNumber(v *Violations, phone string, pattern *regexp.Regexp) {
+	if phone == "" || pattern == nil {
+		return
+	}
+	if !pattern.MatchString(phone) {
+		v.Add("phone_number", ViolationInvalidFormat, "invalid phone number format")
+	}
+}
\ No newline at end of file
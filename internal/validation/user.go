@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"net/mail"
+	"regexp"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ValidateCreateUser checks the fields of a CreateUser request. Phone numbers
+// must match phonePattern; see PhonePattern.
+func ValidateCreateUser(req *pb.CreateUserRequest, phonePattern *regexp.Regexp) error {
+	var v Violations
+	ValidateEmail(&v, req.Email)
+	if req.Name == "" {
+		v.Add("name", ViolationRequired, "name is required")
+	}
+	ValidatePhoneNumber(&v, req.PhoneNumber, phonePattern)
+	return v.Err()
+}
+
+// ValidateUpdateUser checks the fields an UpdateUser request would change.
+// Phone numbers must match phonePattern; see PhonePattern.
+func ValidateUpdateUser(req *pb.UpdateUserRequest, phonePattern *regexp.Regexp) error {
+	if req.Id == "" {
+		return status.Error(codes.InvalidArgument, "user ID is required")
+	}
+
+	update := UserUpdateFields(req)
+	var v Violations
+	if update["email"] {
+		ValidateEmail(&v, req.Email)
+	}
+	if update["name"] && req.Name == "" {
+		v.Add("name", ViolationRequired, "name is required")
+	}
+	if update["phone_number"] {
+		ValidatePhoneNumber(&v, req.PhoneNumber, phonePattern)
+	}
+	return v.Err()
+}
+
+// UserUpdateFields returns the fields an UpdateUser request changes. With an
+// update mask only the listed fields are changed; otherwise each field is
+// changed if provided.
+func UserUpdateFields(req *pb.UpdateUserRequest) map[string]bool {
+	update := make(map[string]bool)
+	if len(req.UpdateMask.GetPaths()) > 0 {
+		for _, path := range req.UpdateMask.GetPaths() {
+			update[path] = true
+		}
+		return update
+	}
+
+	update["email"] = req.Email != ""
+	update["name"] = req.Name != ""
+	update["phone_number"] = req.PhoneNumber != ""
+	update["address"] = req.Address != ""
+	return update
+}
+
+// ValidateEmail checks that email is present and is a bare RFC 5322 address,
+// so "a@" and "Name <a@example.com>" are both rejected
+func ValidateEmail(v *Violations, email string) {
+	if email == "" {
+		v.Add("email", ViolationRequired, "email is required")
+		return
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || addr.Address != email {
+		v.Add("email", ViolationInvalidFormat, "invalid email format")
+	}
+}
\ No newline at end of file
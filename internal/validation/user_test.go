@@ -0,0 +1,215 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// violationsOf returns the field -> reason pairs in err's BadRequest details
+func violationsOf(t *testing.T, err error) map[string]string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", st.Code())
+	}
+
+	fields := make(map[string]string)
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range badRequest.FieldViolations {
+				fields[v.Field] = v.Description
+			}
+		}
+	}
+	return fields
+}
+
+func TestValidateCreateUser(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.CreateUserRequest
+		want map[string]string
+	}{
+		{
+			name: "valid",
+			req:  &pb.CreateUserRequest{Email: "user@example.com", Name: "User"},
+		},
+		{
+			name: "missing email and name",
+			req:  &pb.CreateUserRequest{},
+			want: map[string]string{"email": ViolationRequired, "name": ViolationRequired},
+		},
+		{
+			name: "malformed email",
+			req:  &pb.CreateUserRequest{Email: "not-an-email", Name: "User"},
+			want: map[string]string{"email": ViolationInvalidFormat},
+		},
+		{
+			name: "bogus phone number",
+			req:  &pb.CreateUserRequest{Email: "user@example.com", Name: "User", PhoneNumber: "12-34"},
+			want: map[string]string{"phone_number": ViolationInvalidFormat},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := violationsOf(t, ValidateCreateUser(tt.req, E164OrJapanesePhonePattern))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateCreateUser() violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateUser(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.UpdateUserRequest
+		want map[string]string
+	}{
+		{
+			name: "omitted fields are left alone",
+			req:  &pb.UpdateUserRequest{Id: "user-1", Address: "Tokyo"},
+		},
+		{
+			name: "malformed email",
+			req:  &pb.UpdateUserRequest{Id: "user-1", Email: "not-an-email"},
+			want: map[string]string{"email": ViolationInvalidFormat},
+		},
+		{
+			name: "masked phone number",
+			req: &pb.UpdateUserRequest{
+				Id:          "user-1",
+				PhoneNumber: "abc",
+				UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"phone_number"}},
+			},
+			want: map[string]string{"phone_number": ViolationInvalidFormat},
+		},
+		{
+			name: "masked name cleared",
+			req: &pb.UpdateUserRequest{
+				Id:         "user-1",
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+			},
+			want: map[string]string{"name": ViolationRequired},
+		},
+		{
+			name: "unmasked fields are not checked",
+			req: &pb.UpdateUserRequest{
+				Id:         "user-1",
+				Email:      "not-an-email",
+				Address:    "Tokyo",
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"address"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := violationsOf(t, ValidateUpdateUser(tt.req, E164OrJapanesePhonePattern))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateUpdateUser() violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("missing ID", func(t *testing.T) {
+		err := ValidateUpdateUser(&pb.UpdateUserRequest{Email: "user@example.com"}, nil)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestViolationsErr(t *testing.T) {
+	var v Violations
+	if err := v.Err(); err != nil {
+		t.Fatalf("expected no error without violations, got %v", err)
+	}
+
+	v.Add("email", ViolationRequired, "email is required")
+	v.Add("name", ViolationRequired, "name is required")
+	st := status.Convert(v.Err())
+	if want := "email is required; name is required"; st.Message() != want {
+		t.Errorf("message = %q, want %q", st.Message(), want)
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	tests := map[string]bool{
+		"user@example.com":         true,
+		"first.last+tag@mail.jp":   true,
+		"a@":                       false,
+		"@example.com":             false,
+		"not-an-email":             false,
+		"user@@example.com":        false,
+		"User <user@example.com>":  false,
+		" user@example.com":        false,
+		"user@example.com, b@c.jp": false,
+	}
+
+	for email, valid := range tests {
+		var v Violations
+		ValidateEmail(&v, email)
+		if got := v.Err() == nil; got != valid {
+			t.Errorf("ValidateEmail(%q) valid = %v, want %v", email, got, valid)
+		}
+	}
+}
+
+func TestPhonePattern(t *testing.T) {
+	tests := []struct {
+		format string
+		valid  []string
+		bogus  []string
+	}{
+		{
+			format: PhoneFormatE164,
+			valid:  []string{"+818012345678", "+14155552671"},
+			bogus:  []string{"090-1234-5678", "+0123", "abc"},
+		},
+		{
+			format: PhoneFormatJapan,
+			valid:  []string{"090-1234-5678", "03-1234-5678", "0312345678", "09012345678", "+81-90-1234-5678"},
+			bogus:  []string{"+818012345678", "12-34", "090-1234-567", "090123"},
+		},
+		{
+			format: "",
+			valid:  []string{"+818012345678", "090-1234-5678", "+81-80-1234-5678"},
+			bogus:  []string{"abc", "12345", "090-1234-5678x"},
+		},
+	}
+
+	for _, tt := range tests {
+		pattern, err := PhonePattern(tt.format)
+		if err != nil {
+			t.Fatalf("PhonePattern(%q): %v", tt.format, err)
+		}
+		for _, phone := range tt.valid {
+			if !pattern.MatchString(phone) {
+				t.Errorf("format %q rejected %q", tt.format, phone)
+			}
+		}
+		for _, phone := range tt.bogus {
+			if pattern.MatchString(phone) {
+				t.Errorf("format %q accepted %q", tt.format, phone)
+			}
+		}
+	}
+
+	if pattern, err := PhonePattern(PhoneFormatAny); err != nil || pattern != nil {
+		t.Errorf("PhonePattern(any) = %v, %v; want nil, nil", pattern, err)
+	}
+	if _, err := PhonePattern("us"); err == nil {
+		t.Error("expected an error for an unknown phone format")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,51 @@
+// Package validation holds request validators shared by the gRPC services
+// and the REST and JSON-RPC gateways, so every protocol rejects the same
+// input with the same field errors.
+package validation
+
+import (
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Field violation reasons reported in BadRequest error details
+const (
+	ViolationRequired      = "required"
+	ViolationInvalidFormat = "invalid_format"
+	ViolationOutOfRange    = "out_of_range"
+	ViolationInconsistent  = "inconsistent"
+)
+
+// Violations collects field-level validation failures so they can be
+// reported together as an InvalidArgument status with BadRequest details
+type Violations struct {
+	violations []*errdetails.BadRequest_FieldViolation
+	messages   []string
+}
+
+// Add records that field failed validation for reason, with a human-readable message
+func (v *Violations) Add(field, reason, message string) {
+	v.violations = append(v.violations, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: reason,
+	})
+	v.messages = append(v.messages, message)
+}
+
+// Err returns the collected violations as an InvalidArgument status, or nil
+// if there are none
+func (v *Violations) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(v.messages, "; "))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v.violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
\ No newline at end of file
@@ -141,8 +141,12 @@ type ETCCard struct {
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	ActivatedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=activated_at,json=activatedAt,proto3" json:"activated_at,omitempty"`
 	DeactivatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deactivated_at,json=deactivatedAt,proto3" json:"deactivated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Last four digits of card_number
+	CardLast4 string `protobuf:"bytes,11,opt,name=card_last4,json=cardLast4,proto3" json:"card_last4,omitempty"`
+	// card_number with all but the last four digits masked
+	CardNumberMasked string `protobuf:"bytes,12,opt,name=card_number_masked,json=cardNumberMasked,proto3" json:"card_number_masked,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ETCCard) Reset() {
@@ -245,6 +249,20 @@ func (x *ETCCard) GetDeactivatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *ETCCard) GetCardLast4() string {
+	if x != nil {
+		return x.CardLast4
+	}
+	return ""
+}
+
+func (x *ETCCard) GetCardNumberMasked() string {
+	if x != nil {
+		return x.CardNumberMasked
+	}
+	return ""
+}
+
 // Request messages
 type GetCardRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -478,6 +496,114 @@ func (x *DeleteCardRequest) GetId() string {
 	return ""
 }
 
+// BulkUpdateCardStatusRequest moves every card of a user to status, e.g.
+// suspending them all after a fraud report
+type BulkUpdateCardStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        CardStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=etc_meisai.v1.CardStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateCardStatusRequest) Reset() {
+	*x = BulkUpdateCardStatusRequest{}
+	mi := &file_card_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateCardStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateCardStatusRequest) ProtoMessage() {}
+
+func (x *BulkUpdateCardStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_card_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateCardStatusRequest.ProtoReflect.Descriptor instead.
+func (*BulkUpdateCardStatusRequest) Descriptor() ([]byte, []int) {
+	return file_card_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BulkUpdateCardStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BulkUpdateCardStatusRequest) GetStatus() CardStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CardStatus_CARD_STATUS_UNSPECIFIED
+}
+
+type BulkUpdateCardStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of cards whose status changed; cards already in the target
+	// status are left untouched
+	UpdatedCount  int32      `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`
+	UpdatedCards  []*ETCCard `protobuf:"bytes,2,rep,name=updated_cards,json=updatedCards,proto3" json:"updated_cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateCardStatusResponse) Reset() {
+	*x = BulkUpdateCardStatusResponse{}
+	mi := &file_card_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateCardStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateCardStatusResponse) ProtoMessage() {}
+
+func (x *BulkUpdateCardStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_card_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateCardStatusResponse.ProtoReflect.Descriptor instead.
+func (*BulkUpdateCardStatusResponse) Descriptor() ([]byte, []int) {
+	return file_card_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BulkUpdateCardStatusResponse) GetUpdatedCount() int32 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
+func (x *BulkUpdateCardStatusResponse) GetUpdatedCards() []*ETCCard {
+	if x != nil {
+		return x.UpdatedCards
+	}
+	return nil
+}
+
 type ListCardsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -489,7 +615,7 @@ type ListCardsRequest struct {
 
 func (x *ListCardsRequest) Reset() {
 	*x = ListCardsRequest{}
-	mi := &file_card_proto_msgTypes[5]
+	mi := &file_card_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -501,7 +627,7 @@ func (x *ListCardsRequest) String() string {
 func (*ListCardsRequest) ProtoMessage() {}
 
 func (x *ListCardsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_card_proto_msgTypes[5]
+	mi := &file_card_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,7 +640,7 @@ func (x *ListCardsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCardsRequest.ProtoReflect.Descriptor instead.
 func (*ListCardsRequest) Descriptor() ([]byte, []int) {
-	return file_card_proto_rawDescGZIP(), []int{5}
+	return file_card_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListCardsRequest) GetUserId() string {
@@ -542,13 +668,17 @@ type ListCardsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cards         []*ETCCard             `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
 	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of items matching the request across all pages
+	TotalCount int32 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Page size applied to this response
+	PageSize      int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListCardsResponse) Reset() {
 	*x = ListCardsResponse{}
-	mi := &file_card_proto_msgTypes[6]
+	mi := &file_card_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -560,7 +690,7 @@ func (x *ListCardsResponse) String() string {
 func (*ListCardsResponse) ProtoMessage() {}
 
 func (x *ListCardsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_card_proto_msgTypes[6]
+	mi := &file_card_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -573,7 +703,7 @@ func (x *ListCardsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCardsResponse.ProtoReflect.Descriptor instead.
 func (*ListCardsResponse) Descriptor() ([]byte, []int) {
-	return file_card_proto_rawDescGZIP(), []int{6}
+	return file_card_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListCardsResponse) GetCards() []*ETCCard {
@@ -590,12 +720,26 @@ func (x *ListCardsResponse) GetNextPageToken() string {
 	return ""
 }
 
+func (x *ListCardsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListCardsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
 var File_card_proto protoreflect.FileDescriptor
 
 const file_card_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"card.proto\x12\retc_meisai.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xe6\x03\n" +
+	"card.proto\x12\retc_meisai.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xb3\x04\n" +
 	"\aETCCard\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
@@ -610,7 +754,10 @@ const file_card_proto_rawDesc = "" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
 	"\factivated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vactivatedAt\x12A\n" +
 	"\x0edeactivated_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\rdeactivatedAt\" \n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\rdeactivatedAt\x12\x1d\n" +
+	"\n" +
+	"card_last4\x18\v \x01(\tR\tcardLast4\x12,\n" +
+	"\x12card_number_masked\x18\f \x01(\tR\x10cardNumberMasked\" \n" +
 	"\x0eGetCardRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\xf0\x01\n" +
 	"\x11CreateCardRequest\x12\x17\n" +
@@ -627,15 +774,24 @@ const file_card_proto_rawDesc = "" +
 	"\fvehicle_type\x18\x03 \x01(\x0e2\x1a.etc_meisai.v1.VehicleTypeR\vvehicleType\x121\n" +
 	"\x06status\x18\x04 \x01(\x0e2\x19.etc_meisai.v1.CardStatusR\x06status\"#\n" +
 	"\x11DeleteCardRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"g\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"i\n" +
+	"\x1bBulkUpdateCardStatusRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x19.etc_meisai.v1.CardStatusR\x06status\"\x80\x01\n" +
+	"\x1cBulkUpdateCardStatusResponse\x12#\n" +
+	"\rupdated_count\x18\x01 \x01(\x05R\fupdatedCount\x12;\n" +
+	"\rupdated_cards\x18\x02 \x03(\v2\x16.etc_meisai.v1.ETCCardR\fupdatedCards\"g\n" +
 	"\x10ListCardsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x03 \x01(\tR\tpageToken\"i\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xa7\x01\n" +
 	"\x11ListCardsResponse\x12,\n" +
 	"\x05cards\x18\x01 \x03(\v2\x16.etc_meisai.v1.ETCCardR\x05cards\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*u\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize*u\n" +
 	"\n" +
 	"CardStatus\x12\x1b\n" +
 	"\x17CARD_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
@@ -646,7 +802,7 @@ const file_card_proto_rawDesc = "" +
 	"\x18VEHICLE_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14VEHICLE_TYPE_REGULAR\x10\x01\x12\x14\n" +
 	"\x10VEHICLE_TYPE_KEI\x10\x02\x12\x16\n" +
-	"\x12VEHICLE_TYPE_LARGE\x10\x032\xff\x03\n" +
+	"\x12VEHICLE_TYPE_LARGE\x10\x032\xf0\x04\n" +
 	"\vCardService\x12\\\n" +
 	"\aGetCard\x12\x1d.etc_meisai.v1.GetCardRequest\x1a\x16.etc_meisai.v1.ETCCard\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/cards/{id}\x12`\n" +
 	"\n" +
@@ -655,7 +811,8 @@ const file_card_proto_rawDesc = "" +
 	"UpdateCard\x12 .etc_meisai.v1.UpdateCardRequest\x1a\x16.etc_meisai.v1.ETCCard\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/api/v1/cards/{id}\x12b\n" +
 	"\n" +
 	"DeleteCard\x12 .etc_meisai.v1.DeleteCardRequest\x1a\x16.google.protobuf.Empty\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/cards/{id}\x12e\n" +
-	"\tListCards\x12\x1f.etc_meisai.v1.ListCardsRequest\x1a .etc_meisai.v1.ListCardsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/cardsB\xa8\x01\n" +
+	"\tListCards\x12\x1f.etc_meisai.v1.ListCardsRequest\x1a .etc_meisai.v1.ListCardsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/cards\x12o\n" +
+	"\x14BulkUpdateCardStatus\x12*.etc_meisai.v1.BulkUpdateCardStatusRequest\x1a+.etc_meisai.v1.BulkUpdateCardStatusResponseB\xa8\x01\n" +
 	"\x11com.etc_meisai.v1B\tCardProtoP\x01Z7github.com/yhonda-ohishi/db-handler-server;etc_meisaiv1\xa2\x02\x03EXX\xaa\x02\fEtcMeisai.V1\xca\x02\fEtcMeisai\\V1\xe2\x02\x18EtcMeisai\\V1\\GPBMetadata\xea\x02\rEtcMeisai::V1b\x06proto3"
 
 var (
@@ -671,47 +828,53 @@ func file_card_proto_rawDescGZIP() []byte {
 }
 
 var file_card_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_card_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_card_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_card_proto_goTypes = []any{
-	(CardStatus)(0),               // 0: etc_meisai.v1.CardStatus
-	(VehicleType)(0),              // 1: etc_meisai.v1.VehicleType
-	(*ETCCard)(nil),               // 2: etc_meisai.v1.ETCCard
-	(*GetCardRequest)(nil),        // 3: etc_meisai.v1.GetCardRequest
-	(*CreateCardRequest)(nil),     // 4: etc_meisai.v1.CreateCardRequest
-	(*UpdateCardRequest)(nil),     // 5: etc_meisai.v1.UpdateCardRequest
-	(*DeleteCardRequest)(nil),     // 6: etc_meisai.v1.DeleteCardRequest
-	(*ListCardsRequest)(nil),      // 7: etc_meisai.v1.ListCardsRequest
-	(*ListCardsResponse)(nil),     // 8: etc_meisai.v1.ListCardsResponse
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 10: google.protobuf.Empty
+	(CardStatus)(0),                      // 0: etc_meisai.v1.CardStatus
+	(VehicleType)(0),                     // 1: etc_meisai.v1.VehicleType
+	(*ETCCard)(nil),                      // 2: etc_meisai.v1.ETCCard
+	(*GetCardRequest)(nil),               // 3: etc_meisai.v1.GetCardRequest
+	(*CreateCardRequest)(nil),            // 4: etc_meisai.v1.CreateCardRequest
+	(*UpdateCardRequest)(nil),            // 5: etc_meisai.v1.UpdateCardRequest
+	(*DeleteCardRequest)(nil),            // 6: etc_meisai.v1.DeleteCardRequest
+	(*BulkUpdateCardStatusRequest)(nil),  // 7: etc_meisai.v1.BulkUpdateCardStatusRequest
+	(*BulkUpdateCardStatusResponse)(nil), // 8: etc_meisai.v1.BulkUpdateCardStatusResponse
+	(*ListCardsRequest)(nil),             // 9: etc_meisai.v1.ListCardsRequest
+	(*ListCardsResponse)(nil),            // 10: etc_meisai.v1.ListCardsResponse
+	(*timestamppb.Timestamp)(nil),        // 11: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                // 12: google.protobuf.Empty
 }
 var file_card_proto_depIdxs = []int32{
-	9,  // 0: etc_meisai.v1.ETCCard.expiry_date:type_name -> google.protobuf.Timestamp
+	11, // 0: etc_meisai.v1.ETCCard.expiry_date:type_name -> google.protobuf.Timestamp
 	0,  // 1: etc_meisai.v1.ETCCard.status:type_name -> etc_meisai.v1.CardStatus
 	1,  // 2: etc_meisai.v1.ETCCard.vehicle_type:type_name -> etc_meisai.v1.VehicleType
-	9,  // 3: etc_meisai.v1.ETCCard.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 4: etc_meisai.v1.ETCCard.activated_at:type_name -> google.protobuf.Timestamp
-	9,  // 5: etc_meisai.v1.ETCCard.deactivated_at:type_name -> google.protobuf.Timestamp
-	9,  // 6: etc_meisai.v1.CreateCardRequest.expiry_date:type_name -> google.protobuf.Timestamp
+	11, // 3: etc_meisai.v1.ETCCard.created_at:type_name -> google.protobuf.Timestamp
+	11, // 4: etc_meisai.v1.ETCCard.activated_at:type_name -> google.protobuf.Timestamp
+	11, // 5: etc_meisai.v1.ETCCard.deactivated_at:type_name -> google.protobuf.Timestamp
+	11, // 6: etc_meisai.v1.CreateCardRequest.expiry_date:type_name -> google.protobuf.Timestamp
 	1,  // 7: etc_meisai.v1.CreateCardRequest.vehicle_type:type_name -> etc_meisai.v1.VehicleType
 	1,  // 8: etc_meisai.v1.UpdateCardRequest.vehicle_type:type_name -> etc_meisai.v1.VehicleType
 	0,  // 9: etc_meisai.v1.UpdateCardRequest.status:type_name -> etc_meisai.v1.CardStatus
-	2,  // 10: etc_meisai.v1.ListCardsResponse.cards:type_name -> etc_meisai.v1.ETCCard
-	3,  // 11: etc_meisai.v1.CardService.GetCard:input_type -> etc_meisai.v1.GetCardRequest
-	4,  // 12: etc_meisai.v1.CardService.CreateCard:input_type -> etc_meisai.v1.CreateCardRequest
-	5,  // 13: etc_meisai.v1.CardService.UpdateCard:input_type -> etc_meisai.v1.UpdateCardRequest
-	6,  // 14: etc_meisai.v1.CardService.DeleteCard:input_type -> etc_meisai.v1.DeleteCardRequest
-	7,  // 15: etc_meisai.v1.CardService.ListCards:input_type -> etc_meisai.v1.ListCardsRequest
-	2,  // 16: etc_meisai.v1.CardService.GetCard:output_type -> etc_meisai.v1.ETCCard
-	2,  // 17: etc_meisai.v1.CardService.CreateCard:output_type -> etc_meisai.v1.ETCCard
-	2,  // 18: etc_meisai.v1.CardService.UpdateCard:output_type -> etc_meisai.v1.ETCCard
-	10, // 19: etc_meisai.v1.CardService.DeleteCard:output_type -> google.protobuf.Empty
-	8,  // 20: etc_meisai.v1.CardService.ListCards:output_type -> etc_meisai.v1.ListCardsResponse
-	16, // [16:21] is the sub-list for method output_type
-	11, // [11:16] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	0,  // 10: etc_meisai.v1.BulkUpdateCardStatusRequest.status:type_name -> etc_meisai.v1.CardStatus
+	2,  // 11: etc_meisai.v1.BulkUpdateCardStatusResponse.updated_cards:type_name -> etc_meisai.v1.ETCCard
+	2,  // 12: etc_meisai.v1.ListCardsResponse.cards:type_name -> etc_meisai.v1.ETCCard
+	3,  // 13: etc_meisai.v1.CardService.GetCard:input_type -> etc_meisai.v1.GetCardRequest
+	4,  // 14: etc_meisai.v1.CardService.CreateCard:input_type -> etc_meisai.v1.CreateCardRequest
+	5,  // 15: etc_meisai.v1.CardService.UpdateCard:input_type -> etc_meisai.v1.UpdateCardRequest
+	6,  // 16: etc_meisai.v1.CardService.DeleteCard:input_type -> etc_meisai.v1.DeleteCardRequest
+	9,  // 17: etc_meisai.v1.CardService.ListCards:input_type -> etc_meisai.v1.ListCardsRequest
+	7,  // 18: etc_meisai.v1.CardService.BulkUpdateCardStatus:input_type -> etc_meisai.v1.BulkUpdateCardStatusRequest
+	2,  // 19: etc_meisai.v1.CardService.GetCard:output_type -> etc_meisai.v1.ETCCard
+	2,  // 20: etc_meisai.v1.CardService.CreateCard:output_type -> etc_meisai.v1.ETCCard
+	2,  // 21: etc_meisai.v1.CardService.UpdateCard:output_type -> etc_meisai.v1.ETCCard
+	12, // 22: etc_meisai.v1.CardService.DeleteCard:output_type -> google.protobuf.Empty
+	10, // 23: etc_meisai.v1.CardService.ListCards:output_type -> etc_meisai.v1.ListCardsResponse
+	8,  // 24: etc_meisai.v1.CardService.BulkUpdateCardStatus:output_type -> etc_meisai.v1.BulkUpdateCardStatusResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_card_proto_init() }
@@ -725,7 +888,7 @@ func file_card_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_card_proto_rawDesc), len(file_card_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
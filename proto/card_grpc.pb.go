@@ -20,11 +20,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CardService_GetCard_FullMethodName    = "/etc_meisai.v1.CardService/GetCard"
-	CardService_CreateCard_FullMethodName = "/etc_meisai.v1.CardService/CreateCard"
-	CardService_UpdateCard_FullMethodName = "/etc_meisai.v1.CardService/UpdateCard"
-	CardService_DeleteCard_FullMethodName = "/etc_meisai.v1.CardService/DeleteCard"
-	CardService_ListCards_FullMethodName  = "/etc_meisai.v1.CardService/ListCards"
+	CardService_GetCard_FullMethodName              = "/etc_meisai.v1.CardService/GetCard"
+	CardService_CreateCard_FullMethodName           = "/etc_meisai.v1.CardService/CreateCard"
+	CardService_UpdateCard_FullMethodName           = "/etc_meisai.v1.CardService/UpdateCard"
+	CardService_DeleteCard_FullMethodName           = "/etc_meisai.v1.CardService/DeleteCard"
+	CardService_ListCards_FullMethodName            = "/etc_meisai.v1.CardService/ListCards"
+	CardService_BulkUpdateCardStatus_FullMethodName = "/etc_meisai.v1.CardService/BulkUpdateCardStatus"
 )
 
 // CardServiceClient is the client API for CardService service.
@@ -43,6 +44,8 @@ type CardServiceClient interface {
 	DeleteCard(ctx context.Context, in *DeleteCardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// List cards for a user
 	ListCards(ctx context.Context, in *ListCardsRequest, opts ...grpc.CallOption) (*ListCardsResponse, error)
+	// Set the status of all of a user's cards
+	BulkUpdateCardStatus(ctx context.Context, in *BulkUpdateCardStatusRequest, opts ...grpc.CallOption) (*BulkUpdateCardStatusResponse, error)
 }
 
 type cardServiceClient struct {
@@ -103,6 +106,16 @@ func (c *cardServiceClient) ListCards(ctx context.Context, in *ListCardsRequest,
 	return out, nil
 }
 
+func (c *cardServiceClient) BulkUpdateCardStatus(ctx context.Context, in *BulkUpdateCardStatusRequest, opts ...grpc.CallOption) (*BulkUpdateCardStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkUpdateCardStatusResponse)
+	err := c.cc.Invoke(ctx, CardService_BulkUpdateCardStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CardServiceServer is the server API for CardService service.
 // All implementations must embed UnimplementedCardServiceServer
 // for forward compatibility.
@@ -119,6 +132,8 @@ type CardServiceServer interface {
 	DeleteCard(context.Context, *DeleteCardRequest) (*emptypb.Empty, error)
 	// List cards for a user
 	ListCards(context.Context, *ListCardsRequest) (*ListCardsResponse, error)
+	// Set the status of all of a user's cards
+	BulkUpdateCardStatus(context.Context, *BulkUpdateCardStatusRequest) (*BulkUpdateCardStatusResponse, error)
 	mustEmbedUnimplementedCardServiceServer()
 }
 
@@ -144,6 +159,9 @@ func (UnimplementedCardServiceServer) DeleteCard(context.Context, *DeleteCardReq
 func (UnimplementedCardServiceServer) ListCards(context.Context, *ListCardsRequest) (*ListCardsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCards not implemented")
 }
+func (UnimplementedCardServiceServer) BulkUpdateCardStatus(context.Context, *BulkUpdateCardStatusRequest) (*BulkUpdateCardStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkUpdateCardStatus not implemented")
+}
 func (UnimplementedCardServiceServer) mustEmbedUnimplementedCardServiceServer() {}
 func (UnimplementedCardServiceServer) testEmbeddedByValue()                     {}
 
@@ -255,6 +273,24 @@ func _CardService_ListCards_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CardService_BulkUpdateCardStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateCardStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).BulkUpdateCardStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_BulkUpdateCardStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).BulkUpdateCardStatus(ctx, req.(*BulkUpdateCardStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CardService_ServiceDesc is the grpc.ServiceDesc for CardService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -282,6 +318,10 @@ var CardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListCards",
 			Handler:    _CardService_ListCards_Handler,
 		},
+		{
+			MethodName: "BulkUpdateCardStatus",
+			Handler:    _CardService_BulkUpdateCardStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "card.proto",
@@ -383,10 +383,16 @@ func (x *DeleteETCMeisaiRequest) GetId() int64 {
 }
 
 type ListETCMeisaiRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	Filter        string                 `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter    string                 `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Case-insensitive substring of the entrance IC, exit IC or car number
+	Search string `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
+	// Optional inclusive date range (YYYY-MM-DD) and owner
+	StartDate     string `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	UserId        string `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -442,6 +448,105 @@ func (x *ListETCMeisaiRequest) GetFilter() string {
 	return ""
 }
 
+func (x *ListETCMeisaiRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListETCMeisaiRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ListETCMeisaiRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ListETCMeisaiRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// StreamETCMeisaiRequest filters the streamed records like GetETCSummary: the
+// optional dates (YYYY-MM-DD) are inclusive
+type StreamETCMeisaiRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartDate string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	UserId    string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Records per message; defaults to 100 and is capped at 1000
+	BatchSize     int32 `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamETCMeisaiRequest) Reset() {
+	*x = StreamETCMeisaiRequest{}
+	mi := &file_etc_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamETCMeisaiRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamETCMeisaiRequest) ProtoMessage() {}
+
+func (x *StreamETCMeisaiRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamETCMeisaiRequest.ProtoReflect.Descriptor instead.
+func (*StreamETCMeisaiRequest) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StreamETCMeisaiRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *StreamETCMeisaiRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *StreamETCMeisaiRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StreamETCMeisaiRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
 type BulkCreateETCMeisaiRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	EtcMeisaiList []*ETCMeisai           `protobuf:"bytes,1,rep,name=etc_meisai_list,json=etcMeisaiList,proto3" json:"etc_meisai_list,omitempty"`
@@ -451,7 +556,7 @@ type BulkCreateETCMeisaiRequest struct {
 
 func (x *BulkCreateETCMeisaiRequest) Reset() {
 	*x = BulkCreateETCMeisaiRequest{}
-	mi := &file_etc_service_proto_msgTypes[6]
+	mi := &file_etc_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -463,7 +568,7 @@ func (x *BulkCreateETCMeisaiRequest) String() string {
 func (*BulkCreateETCMeisaiRequest) ProtoMessage() {}
 
 func (x *BulkCreateETCMeisaiRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[6]
+	mi := &file_etc_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -476,7 +581,7 @@ func (x *BulkCreateETCMeisaiRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkCreateETCMeisaiRequest.ProtoReflect.Descriptor instead.
 func (*BulkCreateETCMeisaiRequest) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{6}
+	return file_etc_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *BulkCreateETCMeisaiRequest) GetEtcMeisaiList() []*ETCMeisai {
@@ -495,7 +600,7 @@ type BulkUpdateETCMeisaiRequest struct {
 
 func (x *BulkUpdateETCMeisaiRequest) Reset() {
 	*x = BulkUpdateETCMeisaiRequest{}
-	mi := &file_etc_service_proto_msgTypes[7]
+	mi := &file_etc_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -507,7 +612,7 @@ func (x *BulkUpdateETCMeisaiRequest) String() string {
 func (*BulkUpdateETCMeisaiRequest) ProtoMessage() {}
 
 func (x *BulkUpdateETCMeisaiRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[7]
+	mi := &file_etc_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,7 +625,7 @@ func (x *BulkUpdateETCMeisaiRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkUpdateETCMeisaiRequest.ProtoReflect.Descriptor instead.
 func (*BulkUpdateETCMeisaiRequest) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{7}
+	return file_etc_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *BulkUpdateETCMeisaiRequest) GetEtcMeisaiList() []*ETCMeisai {
@@ -530,6 +635,50 @@ func (x *BulkUpdateETCMeisaiRequest) GetEtcMeisaiList() []*ETCMeisai {
 	return nil
 }
 
+type BulkDeleteETCMeisaiRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkDeleteETCMeisaiRequest) Reset() {
+	*x = BulkDeleteETCMeisaiRequest{}
+	mi := &file_etc_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDeleteETCMeisaiRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeleteETCMeisaiRequest) ProtoMessage() {}
+
+func (x *BulkDeleteETCMeisaiRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeleteETCMeisaiRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteETCMeisaiRequest) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BulkDeleteETCMeisaiRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
 type GetETCMeisaiByDateRangeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
@@ -542,7 +691,7 @@ type GetETCMeisaiByDateRangeRequest struct {
 
 func (x *GetETCMeisaiByDateRangeRequest) Reset() {
 	*x = GetETCMeisaiByDateRangeRequest{}
-	mi := &file_etc_service_proto_msgTypes[8]
+	mi := &file_etc_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -554,7 +703,7 @@ func (x *GetETCMeisaiByDateRangeRequest) String() string {
 func (*GetETCMeisaiByDateRangeRequest) ProtoMessage() {}
 
 func (x *GetETCMeisaiByDateRangeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[8]
+	mi := &file_etc_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,7 +716,7 @@ func (x *GetETCMeisaiByDateRangeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetETCMeisaiByDateRangeRequest.ProtoReflect.Descriptor instead.
 func (*GetETCMeisaiByDateRangeRequest) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{8}
+	return file_etc_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetETCMeisaiByDateRangeRequest) GetStartDate() string {
@@ -607,7 +756,7 @@ type GetETCMeisaiByHashRequest struct {
 
 func (x *GetETCMeisaiByHashRequest) Reset() {
 	*x = GetETCMeisaiByHashRequest{}
-	mi := &file_etc_service_proto_msgTypes[9]
+	mi := &file_etc_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -619,7 +768,7 @@ func (x *GetETCMeisaiByHashRequest) String() string {
 func (*GetETCMeisaiByHashRequest) ProtoMessage() {}
 
 func (x *GetETCMeisaiByHashRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[9]
+	mi := &file_etc_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -632,7 +781,7 @@ func (x *GetETCMeisaiByHashRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetETCMeisaiByHashRequest.ProtoReflect.Descriptor instead.
 func (*GetETCMeisaiByHashRequest) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{9}
+	return file_etc_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetETCMeisaiByHashRequest) GetHash() string {
@@ -652,7 +801,7 @@ type GetUnmappedETCMeisaiRequest struct {
 
 func (x *GetUnmappedETCMeisaiRequest) Reset() {
 	*x = GetUnmappedETCMeisaiRequest{}
-	mi := &file_etc_service_proto_msgTypes[10]
+	mi := &file_etc_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -664,7 +813,7 @@ func (x *GetUnmappedETCMeisaiRequest) String() string {
 func (*GetUnmappedETCMeisaiRequest) ProtoMessage() {}
 
 func (x *GetUnmappedETCMeisaiRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[10]
+	mi := &file_etc_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -677,7 +826,7 @@ func (x *GetUnmappedETCMeisaiRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUnmappedETCMeisaiRequest.ProtoReflect.Descriptor instead.
 func (*GetUnmappedETCMeisaiRequest) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{10}
+	return file_etc_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetUnmappedETCMeisaiRequest) GetPageSize() int32 {
@@ -703,7 +852,7 @@ type CheckDuplicatesByHashRequest struct {
 
 func (x *CheckDuplicatesByHashRequest) Reset() {
 	*x = CheckDuplicatesByHashRequest{}
-	mi := &file_etc_service_proto_msgTypes[11]
+	mi := &file_etc_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -715,7 +864,7 @@ func (x *CheckDuplicatesByHashRequest) String() string {
 func (*CheckDuplicatesByHashRequest) ProtoMessage() {}
 
 func (x *CheckDuplicatesByHashRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[11]
+	mi := &file_etc_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,7 +877,7 @@ func (x *CheckDuplicatesByHashRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckDuplicatesByHashRequest.ProtoReflect.Descriptor instead.
 func (*CheckDuplicatesByHashRequest) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{11}
+	return file_etc_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CheckDuplicatesByHashRequest) GetHashes() []string {
@@ -738,6 +887,60 @@ func (x *CheckDuplicatesByHashRequest) GetHashes() []string {
 	return nil
 }
 
+// DeduplicateRequest removes records sharing a hash, keeping the lowest ID.
+// The optional dates (YYYY-MM-DD, inclusive) limit which records are considered.
+type DeduplicateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeduplicateRequest) Reset() {
+	*x = DeduplicateRequest{}
+	mi := &file_etc_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeduplicateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeduplicateRequest) ProtoMessage() {}
+
+func (x *DeduplicateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeduplicateRequest.ProtoReflect.Descriptor instead.
+func (*DeduplicateRequest) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeduplicateRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *DeduplicateRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
 type GenerateHashRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	EtcMeisai     *ETCMeisai             `protobuf:"bytes,1,opt,name=etc_meisai,json=etcMeisai,proto3" json:"etc_meisai,omitempty"`
@@ -747,7 +950,7 @@ type GenerateHashRequest struct {
 
 func (x *GenerateHashRequest) Reset() {
 	*x = GenerateHashRequest{}
-	mi := &file_etc_service_proto_msgTypes[12]
+	mi := &file_etc_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -759,7 +962,7 @@ func (x *GenerateHashRequest) String() string {
 func (*GenerateHashRequest) ProtoMessage() {}
 
 func (x *GenerateHashRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[12]
+	mi := &file_etc_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -772,7 +975,7 @@ func (x *GenerateHashRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateHashRequest.ProtoReflect.Descriptor instead.
 func (*GenerateHashRequest) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{12}
+	return file_etc_service_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GenerateHashRequest) GetEtcMeisai() *ETCMeisai {
@@ -782,6 +985,52 @@ func (x *GenerateHashRequest) GetEtcMeisai() *ETCMeisai {
 	return nil
 }
 
+// BatchGenerateHashRequest hashes records without storing them, so callers
+// can dedup against existing hashes before inserting.
+type BatchGenerateHashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EtcMeisaiList []*ETCMeisai           `protobuf:"bytes,1,rep,name=etc_meisai_list,json=etcMeisaiList,proto3" json:"etc_meisai_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGenerateHashRequest) Reset() {
+	*x = BatchGenerateHashRequest{}
+	mi := &file_etc_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGenerateHashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGenerateHashRequest) ProtoMessage() {}
+
+func (x *BatchGenerateHashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGenerateHashRequest.ProtoReflect.Descriptor instead.
+func (*BatchGenerateHashRequest) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchGenerateHashRequest) GetEtcMeisaiList() []*ETCMeisai {
+	if x != nil {
+		return x.EtcMeisaiList
+	}
+	return nil
+}
+
 type GetETCSummaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
@@ -793,7 +1042,7 @@ type GetETCSummaryRequest struct {
 
 func (x *GetETCSummaryRequest) Reset() {
 	*x = GetETCSummaryRequest{}
-	mi := &file_etc_service_proto_msgTypes[13]
+	mi := &file_etc_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -805,7 +1054,7 @@ func (x *GetETCSummaryRequest) String() string {
 func (*GetETCSummaryRequest) ProtoMessage() {}
 
 func (x *GetETCSummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[13]
+	mi := &file_etc_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -818,7 +1067,7 @@ func (x *GetETCSummaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetETCSummaryRequest.ProtoReflect.Descriptor instead.
 func (*GetETCSummaryRequest) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{13}
+	return file_etc_service_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetETCSummaryRequest) GetStartDate() string {
@@ -853,7 +1102,7 @@ type GetMonthlyStatsRequest struct {
 
 func (x *GetMonthlyStatsRequest) Reset() {
 	*x = GetMonthlyStatsRequest{}
-	mi := &file_etc_service_proto_msgTypes[14]
+	mi := &file_etc_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -865,7 +1114,7 @@ func (x *GetMonthlyStatsRequest) String() string {
 func (*GetMonthlyStatsRequest) ProtoMessage() {}
 
 func (x *GetMonthlyStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[14]
+	mi := &file_etc_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -878,7 +1127,7 @@ func (x *GetMonthlyStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMonthlyStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetMonthlyStatsRequest) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{14}
+	return file_etc_service_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetMonthlyStatsRequest) GetYear() int32 {
@@ -902,6 +1151,76 @@ func (x *GetMonthlyStatsRequest) GetUserId() string {
 	return ""
 }
 
+type GetDailyStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Inclusive range, as YYYY-MM-DD
+	StartDate string `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   string `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	UserId    string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Include days without trips as zero entries
+	FillGaps      bool `protobuf:"varint,4,opt,name=fill_gaps,json=fillGaps,proto3" json:"fill_gaps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyStatsRequest) Reset() {
+	*x = GetDailyStatsRequest{}
+	mi := &file_etc_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyStatsRequest) ProtoMessage() {}
+
+func (x *GetDailyStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetDailyStatsRequest) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetDailyStatsRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetDailyStatsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetDailyStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetDailyStatsRequest) GetFillGaps() bool {
+	if x != nil {
+		return x.FillGaps
+	}
+	return false
+}
+
 // Response messages
 type ETCMeisaiResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -912,7 +1231,7 @@ type ETCMeisaiResponse struct {
 
 func (x *ETCMeisaiResponse) Reset() {
 	*x = ETCMeisaiResponse{}
-	mi := &file_etc_service_proto_msgTypes[15]
+	mi := &file_etc_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -924,7 +1243,7 @@ func (x *ETCMeisaiResponse) String() string {
 func (*ETCMeisaiResponse) ProtoMessage() {}
 
 func (x *ETCMeisaiResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[15]
+	mi := &file_etc_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -937,7 +1256,7 @@ func (x *ETCMeisaiResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ETCMeisaiResponse.ProtoReflect.Descriptor instead.
 func (*ETCMeisaiResponse) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{15}
+	return file_etc_service_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ETCMeisaiResponse) GetEtcMeisai() *ETCMeisai {
@@ -952,13 +1271,20 @@ type ListETCMeisaiResponse struct {
 	EtcMeisaiList []*ETCMeisai           `protobuf:"bytes,1,rep,name=etc_meisai_list,json=etcMeisaiList,proto3" json:"etc_meisai_list,omitempty"`
 	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Sums over all matching records, not just this page
+	// (set by GetETCMeisaiByDateRange)
+	TotalAmount   int64 `protobuf:"varint,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	TotalToll     int64 `protobuf:"varint,5,opt,name=total_toll,json=totalToll,proto3" json:"total_toll,omitempty"`
+	TotalDiscount int64 `protobuf:"varint,6,opt,name=total_discount,json=totalDiscount,proto3" json:"total_discount,omitempty"`
+	// Page size applied to this response
+	PageSize      int32 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListETCMeisaiResponse) Reset() {
 	*x = ListETCMeisaiResponse{}
-	mi := &file_etc_service_proto_msgTypes[16]
+	mi := &file_etc_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -970,7 +1296,7 @@ func (x *ListETCMeisaiResponse) String() string {
 func (*ListETCMeisaiResponse) ProtoMessage() {}
 
 func (x *ListETCMeisaiResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[16]
+	mi := &file_etc_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -983,7 +1309,7 @@ func (x *ListETCMeisaiResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListETCMeisaiResponse.ProtoReflect.Descriptor instead.
 func (*ListETCMeisaiResponse) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{16}
+	return file_etc_service_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ListETCMeisaiResponse) GetEtcMeisaiList() []*ETCMeisai {
@@ -1007,6 +1333,34 @@ func (x *ListETCMeisaiResponse) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *ListETCMeisaiResponse) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *ListETCMeisaiResponse) GetTotalToll() int64 {
+	if x != nil {
+		return x.TotalToll
+	}
+	return 0
+}
+
+func (x *ListETCMeisaiResponse) GetTotalDiscount() int64 {
+	if x != nil {
+		return x.TotalDiscount
+	}
+	return 0
+}
+
+func (x *ListETCMeisaiResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
 type BulkCreateETCMeisaiResponse struct {
 	state                protoimpl.MessageState `protogen:"open.v1"`
 	CreatedEtcMeisaiList []*ETCMeisai           `protobuf:"bytes,1,rep,name=created_etc_meisai_list,json=createdEtcMeisaiList,proto3" json:"created_etc_meisai_list,omitempty"`
@@ -1019,7 +1373,7 @@ type BulkCreateETCMeisaiResponse struct {
 
 func (x *BulkCreateETCMeisaiResponse) Reset() {
 	*x = BulkCreateETCMeisaiResponse{}
-	mi := &file_etc_service_proto_msgTypes[17]
+	mi := &file_etc_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1031,7 +1385,7 @@ func (x *BulkCreateETCMeisaiResponse) String() string {
 func (*BulkCreateETCMeisaiResponse) ProtoMessage() {}
 
 func (x *BulkCreateETCMeisaiResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[17]
+	mi := &file_etc_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1044,7 +1398,7 @@ func (x *BulkCreateETCMeisaiResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkCreateETCMeisaiResponse.ProtoReflect.Descriptor instead.
 func (*BulkCreateETCMeisaiResponse) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{17}
+	return file_etc_service_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *BulkCreateETCMeisaiResponse) GetCreatedEtcMeisaiList() []*ETCMeisai {
@@ -1085,21 +1439,89 @@ type BulkUpdateETCMeisaiResponse struct {
 	sizeCache            protoimpl.SizeCache
 }
 
-func (x *BulkUpdateETCMeisaiResponse) Reset() {
-	*x = BulkUpdateETCMeisaiResponse{}
-	mi := &file_etc_service_proto_msgTypes[18]
+func (x *BulkUpdateETCMeisaiResponse) Reset() {
+	*x = BulkUpdateETCMeisaiResponse{}
+	mi := &file_etc_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateETCMeisaiResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateETCMeisaiResponse) ProtoMessage() {}
+
+func (x *BulkUpdateETCMeisaiResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateETCMeisaiResponse.ProtoReflect.Descriptor instead.
+func (*BulkUpdateETCMeisaiResponse) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BulkUpdateETCMeisaiResponse) GetUpdatedEtcMeisaiList() []*ETCMeisai {
+	if x != nil {
+		return x.UpdatedEtcMeisaiList
+	}
+	return nil
+}
+
+func (x *BulkUpdateETCMeisaiResponse) GetSuccessCount() int32 {
+	if x != nil {
+		return x.SuccessCount
+	}
+	return 0
+}
+
+func (x *BulkUpdateETCMeisaiResponse) GetErrorCount() int32 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *BulkUpdateETCMeisaiResponse) GetErrorMessages() []string {
+	if x != nil {
+		return x.ErrorMessages
+	}
+	return nil
+}
+
+type BulkDeleteETCMeisaiResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SuccessCount int32                  `protobuf:"varint,1,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	ErrorCount   int32                  `protobuf:"varint,2,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	// IDs in the request that did not exist
+	NotFoundIds   []int64 `protobuf:"varint,3,rep,packed,name=not_found_ids,json=notFoundIds,proto3" json:"not_found_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkDeleteETCMeisaiResponse) Reset() {
+	*x = BulkDeleteETCMeisaiResponse{}
+	mi := &file_etc_service_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BulkUpdateETCMeisaiResponse) String() string {
+func (x *BulkDeleteETCMeisaiResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BulkUpdateETCMeisaiResponse) ProtoMessage() {}
+func (*BulkDeleteETCMeisaiResponse) ProtoMessage() {}
 
-func (x *BulkUpdateETCMeisaiResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[18]
+func (x *BulkDeleteETCMeisaiResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1110,35 +1532,28 @@ func (x *BulkUpdateETCMeisaiResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BulkUpdateETCMeisaiResponse.ProtoReflect.Descriptor instead.
-func (*BulkUpdateETCMeisaiResponse) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{18}
-}
-
-func (x *BulkUpdateETCMeisaiResponse) GetUpdatedEtcMeisaiList() []*ETCMeisai {
-	if x != nil {
-		return x.UpdatedEtcMeisaiList
-	}
-	return nil
+// Deprecated: Use BulkDeleteETCMeisaiResponse.ProtoReflect.Descriptor instead.
+func (*BulkDeleteETCMeisaiResponse) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *BulkUpdateETCMeisaiResponse) GetSuccessCount() int32 {
+func (x *BulkDeleteETCMeisaiResponse) GetSuccessCount() int32 {
 	if x != nil {
 		return x.SuccessCount
 	}
 	return 0
 }
 
-func (x *BulkUpdateETCMeisaiResponse) GetErrorCount() int32 {
+func (x *BulkDeleteETCMeisaiResponse) GetErrorCount() int32 {
 	if x != nil {
 		return x.ErrorCount
 	}
 	return 0
 }
 
-func (x *BulkUpdateETCMeisaiResponse) GetErrorMessages() []string {
+func (x *BulkDeleteETCMeisaiResponse) GetNotFoundIds() []int64 {
 	if x != nil {
-		return x.ErrorMessages
+		return x.NotFoundIds
 	}
 	return nil
 }
@@ -1153,7 +1568,7 @@ type CheckDuplicatesResponse struct {
 
 func (x *CheckDuplicatesResponse) Reset() {
 	*x = CheckDuplicatesResponse{}
-	mi := &file_etc_service_proto_msgTypes[19]
+	mi := &file_etc_service_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1165,7 +1580,7 @@ func (x *CheckDuplicatesResponse) String() string {
 func (*CheckDuplicatesResponse) ProtoMessage() {}
 
 func (x *CheckDuplicatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[19]
+	mi := &file_etc_service_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1178,7 +1593,7 @@ func (x *CheckDuplicatesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckDuplicatesResponse.ProtoReflect.Descriptor instead.
 func (*CheckDuplicatesResponse) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{19}
+	return file_etc_service_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *CheckDuplicatesResponse) GetDuplicateHashes() []string {
@@ -1195,6 +1610,58 @@ func (x *CheckDuplicatesResponse) GetDuplicateCount() int32 {
 	return 0
 }
 
+type DeduplicateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RemovedCount  int32                  `protobuf:"varint,1,opt,name=removed_count,json=removedCount,proto3" json:"removed_count,omitempty"`
+	RemovedIds    []int64                `protobuf:"varint,2,rep,packed,name=removed_ids,json=removedIds,proto3" json:"removed_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeduplicateResponse) Reset() {
+	*x = DeduplicateResponse{}
+	mi := &file_etc_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeduplicateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeduplicateResponse) ProtoMessage() {}
+
+func (x *DeduplicateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeduplicateResponse.ProtoReflect.Descriptor instead.
+func (*DeduplicateResponse) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeduplicateResponse) GetRemovedCount() int32 {
+	if x != nil {
+		return x.RemovedCount
+	}
+	return 0
+}
+
+func (x *DeduplicateResponse) GetRemovedIds() []int64 {
+	if x != nil {
+		return x.RemovedIds
+	}
+	return nil
+}
+
 type GenerateHashResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
@@ -1204,7 +1671,7 @@ type GenerateHashResponse struct {
 
 func (x *GenerateHashResponse) Reset() {
 	*x = GenerateHashResponse{}
-	mi := &file_etc_service_proto_msgTypes[20]
+	mi := &file_etc_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1216,7 +1683,7 @@ func (x *GenerateHashResponse) String() string {
 func (*GenerateHashResponse) ProtoMessage() {}
 
 func (x *GenerateHashResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[20]
+	mi := &file_etc_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1229,7 +1696,7 @@ func (x *GenerateHashResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateHashResponse.ProtoReflect.Descriptor instead.
 func (*GenerateHashResponse) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{20}
+	return file_etc_service_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GenerateHashResponse) GetHash() string {
@@ -1239,6 +1706,95 @@ func (x *GenerateHashResponse) GetHash() string {
 	return ""
 }
 
+type BatchGenerateHashResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hashes in the same order as the request's etc_meisai_list
+	Hashes        []string `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGenerateHashResponse) Reset() {
+	*x = BatchGenerateHashResponse{}
+	mi := &file_etc_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGenerateHashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGenerateHashResponse) ProtoMessage() {}
+
+func (x *BatchGenerateHashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGenerateHashResponse.ProtoReflect.Descriptor instead.
+func (*BatchGenerateHashResponse) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BatchGenerateHashResponse) GetHashes() []string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type StreamETCMeisaiResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EtcMeisaiList []*ETCMeisai           `protobuf:"bytes,1,rep,name=etc_meisai_list,json=etcMeisaiList,proto3" json:"etc_meisai_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamETCMeisaiResponse) Reset() {
+	*x = StreamETCMeisaiResponse{}
+	mi := &file_etc_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamETCMeisaiResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamETCMeisaiResponse) ProtoMessage() {}
+
+func (x *StreamETCMeisaiResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamETCMeisaiResponse.ProtoReflect.Descriptor instead.
+func (*StreamETCMeisaiResponse) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *StreamETCMeisaiResponse) GetEtcMeisaiList() []*ETCMeisai {
+	if x != nil {
+		return x.EtcMeisaiList
+	}
+	return nil
+}
+
 type GetETCSummaryResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	TotalTransactions int32                  `protobuf:"varint,1,opt,name=total_transactions,json=totalTransactions,proto3" json:"total_transactions,omitempty"`
@@ -1252,7 +1808,7 @@ type GetETCSummaryResponse struct {
 
 func (x *GetETCSummaryResponse) Reset() {
 	*x = GetETCSummaryResponse{}
-	mi := &file_etc_service_proto_msgTypes[21]
+	mi := &file_etc_service_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1264,7 +1820,7 @@ func (x *GetETCSummaryResponse) String() string {
 func (*GetETCSummaryResponse) ProtoMessage() {}
 
 func (x *GetETCSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[21]
+	mi := &file_etc_service_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1277,7 +1833,7 @@ func (x *GetETCSummaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetETCSummaryResponse.ProtoReflect.Descriptor instead.
 func (*GetETCSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{21}
+	return file_etc_service_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *GetETCSummaryResponse) GetTotalTransactions() int32 {
@@ -1329,7 +1885,7 @@ type GetMonthlyStatsResponse struct {
 
 func (x *GetMonthlyStatsResponse) Reset() {
 	*x = GetMonthlyStatsResponse{}
-	mi := &file_etc_service_proto_msgTypes[22]
+	mi := &file_etc_service_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1341,7 +1897,7 @@ func (x *GetMonthlyStatsResponse) String() string {
 func (*GetMonthlyStatsResponse) ProtoMessage() {}
 
 func (x *GetMonthlyStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[22]
+	mi := &file_etc_service_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1354,7 +1910,7 @@ func (x *GetMonthlyStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMonthlyStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetMonthlyStatsResponse) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{22}
+	return file_etc_service_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *GetMonthlyStatsResponse) GetYear() int32 {
@@ -1411,7 +1967,7 @@ type ETCMonthlySummary struct {
 
 func (x *ETCMonthlySummary) Reset() {
 	*x = ETCMonthlySummary{}
-	mi := &file_etc_service_proto_msgTypes[23]
+	mi := &file_etc_service_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1423,7 +1979,7 @@ func (x *ETCMonthlySummary) String() string {
 func (*ETCMonthlySummary) ProtoMessage() {}
 
 func (x *ETCMonthlySummary) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[23]
+	mi := &file_etc_service_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1436,7 +1992,7 @@ func (x *ETCMonthlySummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ETCMonthlySummary.ProtoReflect.Descriptor instead.
 func (*ETCMonthlySummary) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{23}
+	return file_etc_service_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ETCMonthlySummary) GetYear() int32 {
@@ -1478,7 +2034,7 @@ type ETCDailyStat struct {
 
 func (x *ETCDailyStat) Reset() {
 	*x = ETCDailyStat{}
-	mi := &file_etc_service_proto_msgTypes[24]
+	mi := &file_etc_service_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1490,7 +2046,7 @@ func (x *ETCDailyStat) String() string {
 func (*ETCDailyStat) ProtoMessage() {}
 
 func (x *ETCDailyStat) ProtoReflect() protoreflect.Message {
-	mi := &file_etc_service_proto_msgTypes[24]
+	mi := &file_etc_service_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1503,7 +2059,7 @@ func (x *ETCDailyStat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ETCDailyStat.ProtoReflect.Descriptor instead.
 func (*ETCDailyStat) Descriptor() ([]byte, []int) {
-	return file_etc_service_proto_rawDescGZIP(), []int{24}
+	return file_etc_service_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ETCDailyStat) GetDay() int32 {
@@ -1527,6 +2083,143 @@ func (x *ETCDailyStat) GetTotalAmount() int64 {
 	return 0
 }
 
+type GetDailyStatsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	StartDate        string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate          string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	TransactionCount int32                  `protobuf:"varint,3,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	TotalAmount      int64                  `protobuf:"varint,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	// Sorted by date
+	DailyStats    []*ETCDateStat `protobuf:"bytes,5,rep,name=daily_stats,json=dailyStats,proto3" json:"daily_stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyStatsResponse) Reset() {
+	*x = GetDailyStatsResponse{}
+	mi := &file_etc_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyStatsResponse) ProtoMessage() {}
+
+func (x *GetDailyStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetDailyStatsResponse) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetDailyStatsResponse) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetDailyStatsResponse) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetDailyStatsResponse) GetTransactionCount() int32 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *GetDailyStatsResponse) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *GetDailyStatsResponse) GetDailyStats() []*ETCDateStat {
+	if x != nil {
+		return x.DailyStats
+	}
+	return nil
+}
+
+type ETCDateStat struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Date             string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	TransactionCount int32                  `protobuf:"varint,2,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	TotalAmount      int64                  `protobuf:"varint,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ETCDateStat) Reset() {
+	*x = ETCDateStat{}
+	mi := &file_etc_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ETCDateStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ETCDateStat) ProtoMessage() {}
+
+func (x *ETCDateStat) ProtoReflect() protoreflect.Message {
+	mi := &file_etc_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ETCDateStat.ProtoReflect.Descriptor instead.
+func (*ETCDateStat) Descriptor() ([]byte, []int) {
+	return file_etc_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ETCDateStat) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *ETCDateStat) GetTransactionCount() int32 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *ETCDateStat) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
 var File_etc_service_proto protoreflect.FileDescriptor
 
 const file_etc_service_proto_rawDesc = "" +
@@ -1567,16 +2260,30 @@ const file_etc_service_proto_rawDesc = "" +
 	"\n" +
 	"etc_meisai\x18\x02 \x01(\v2\x18.etc_meisai.v1.ETCMeisaiR\tetcMeisai\"(\n" +
 	"\x16DeleteETCMeisaiRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"j\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xd5\x01\n" +
 	"\x14ListETCMeisaiRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
 	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x16\n" +
-	"\x06filter\x18\x03 \x01(\tR\x06filter\"^\n" +
+	"\x06filter\x18\x03 \x01(\tR\x06filter\x12\x16\n" +
+	"\x06search\x18\x04 \x01(\tR\x06search\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x06 \x01(\tR\aendDate\x12\x17\n" +
+	"\auser_id\x18\a \x01(\tR\x06userId\"\x8a\x01\n" +
+	"\x16StreamETCMeisaiRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05R\tbatchSize\"^\n" +
 	"\x1aBulkCreateETCMeisaiRequest\x12@\n" +
 	"\x0fetc_meisai_list\x18\x01 \x03(\v2\x18.etc_meisai.v1.ETCMeisaiR\retcMeisaiList\"^\n" +
 	"\x1aBulkUpdateETCMeisaiRequest\x12@\n" +
-	"\x0fetc_meisai_list\x18\x01 \x03(\v2\x18.etc_meisai.v1.ETCMeisaiR\retcMeisaiList\"\x96\x01\n" +
+	"\x0fetc_meisai_list\x18\x01 \x03(\v2\x18.etc_meisai.v1.ETCMeisaiR\retcMeisaiList\".\n" +
+	"\x1aBulkDeleteETCMeisaiRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"\x96\x01\n" +
 	"\x1eGetETCMeisaiByDateRangeRequest\x12\x1d\n" +
 	"\n" +
 	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
@@ -1592,9 +2299,15 @@ const file_etc_service_proto_rawDesc = "" +
 	"page_token\x18\x02 \x01(\tR\tpageToken\"6\n" +
 	"\x1cCheckDuplicatesByHashRequest\x12\x16\n" +
 	"\x06hashes\x18\x01 \x03(\tR\x06hashes\"N\n" +
+	"\x12DeduplicateRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\"N\n" +
 	"\x13GenerateHashRequest\x127\n" +
 	"\n" +
-	"etc_meisai\x18\x01 \x01(\v2\x18.etc_meisai.v1.ETCMeisaiR\tetcMeisai\"i\n" +
+	"etc_meisai\x18\x01 \x01(\v2\x18.etc_meisai.v1.ETCMeisaiR\tetcMeisai\"\\\n" +
+	"\x18BatchGenerateHashRequest\x12@\n" +
+	"\x0fetc_meisai_list\x18\x01 \x03(\v2\x18.etc_meisai.v1.ETCMeisaiR\retcMeisaiList\"i\n" +
 	"\x14GetETCSummaryRequest\x12\x1d\n" +
 	"\n" +
 	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
@@ -1603,15 +2316,26 @@ const file_etc_service_proto_rawDesc = "" +
 	"\x16GetMonthlyStatsRequest\x12\x12\n" +
 	"\x04year\x18\x01 \x01(\x05R\x04year\x12\x14\n" +
 	"\x05month\x18\x02 \x01(\x05R\x05month\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\"L\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\x86\x01\n" +
+	"\x14GetDailyStatsRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfill_gaps\x18\x04 \x01(\bR\bfillGaps\"L\n" +
 	"\x11ETCMeisaiResponse\x127\n" +
 	"\n" +
-	"etc_meisai\x18\x01 \x01(\v2\x18.etc_meisai.v1.ETCMeisaiR\tetcMeisai\"\xa2\x01\n" +
+	"etc_meisai\x18\x01 \x01(\v2\x18.etc_meisai.v1.ETCMeisaiR\tetcMeisai\"\xa8\x02\n" +
 	"\x15ListETCMeisaiResponse\x12@\n" +
 	"\x0fetc_meisai_list\x18\x01 \x03(\v2\x18.etc_meisai.v1.ETCMeisaiR\retcMeisaiList\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
 	"\vtotal_count\x18\x03 \x01(\x05R\n" +
-	"totalCount\"\xdb\x01\n" +
+	"totalCount\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\x03R\vtotalAmount\x12\x1d\n" +
+	"\n" +
+	"total_toll\x18\x05 \x01(\x03R\ttotalToll\x12%\n" +
+	"\x0etotal_discount\x18\x06 \x01(\x03R\rtotalDiscount\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\"\xdb\x01\n" +
 	"\x1bBulkCreateETCMeisaiResponse\x12O\n" +
 	"\x17created_etc_meisai_list\x18\x01 \x03(\v2\x18.etc_meisai.v1.ETCMeisaiR\x14createdEtcMeisaiList\x12#\n" +
 	"\rsuccess_count\x18\x02 \x01(\x05R\fsuccessCount\x12\x1f\n" +
@@ -1623,12 +2347,25 @@ const file_etc_service_proto_rawDesc = "" +
 	"\rsuccess_count\x18\x02 \x01(\x05R\fsuccessCount\x12\x1f\n" +
 	"\verror_count\x18\x03 \x01(\x05R\n" +
 	"errorCount\x12%\n" +
-	"\x0eerror_messages\x18\x04 \x03(\tR\rerrorMessages\"m\n" +
+	"\x0eerror_messages\x18\x04 \x03(\tR\rerrorMessages\"\x87\x01\n" +
+	"\x1bBulkDeleteETCMeisaiResponse\x12#\n" +
+	"\rsuccess_count\x18\x01 \x01(\x05R\fsuccessCount\x12\x1f\n" +
+	"\verror_count\x18\x02 \x01(\x05R\n" +
+	"errorCount\x12\"\n" +
+	"\rnot_found_ids\x18\x03 \x03(\x03R\vnotFoundIds\"m\n" +
 	"\x17CheckDuplicatesResponse\x12)\n" +
 	"\x10duplicate_hashes\x18\x01 \x03(\tR\x0fduplicateHashes\x12'\n" +
-	"\x0fduplicate_count\x18\x02 \x01(\x05R\x0eduplicateCount\"*\n" +
+	"\x0fduplicate_count\x18\x02 \x01(\x05R\x0eduplicateCount\"[\n" +
+	"\x13DeduplicateResponse\x12#\n" +
+	"\rremoved_count\x18\x01 \x01(\x05R\fremovedCount\x12\x1f\n" +
+	"\vremoved_ids\x18\x02 \x03(\x03R\n" +
+	"removedIds\"*\n" +
 	"\x14GenerateHashResponse\x12\x12\n" +
-	"\x04hash\x18\x01 \x01(\tR\x04hash\"\xfe\x01\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\"3\n" +
+	"\x19BatchGenerateHashResponse\x12\x16\n" +
+	"\x06hashes\x18\x01 \x03(\tR\x06hashes\"[\n" +
+	"\x17StreamETCMeisaiResponse\x12@\n" +
+	"\x0fetc_meisai_list\x18\x01 \x03(\v2\x18.etc_meisai.v1.ETCMeisaiR\retcMeisaiList\"\xfe\x01\n" +
 	"\x15GetETCSummaryResponse\x12-\n" +
 	"\x12total_transactions\x18\x01 \x01(\x05R\x11totalTransactions\x12!\n" +
 	"\ftotal_amount\x18\x02 \x01(\x03R\vtotalAmount\x12\x1d\n" +
@@ -1652,7 +2389,19 @@ const file_etc_service_proto_rawDesc = "" +
 	"\fETCDailyStat\x12\x10\n" +
 	"\x03day\x18\x01 \x01(\x05R\x03day\x12+\n" +
 	"\x11transaction_count\x18\x02 \x01(\x05R\x10transactionCount\x12!\n" +
-	"\ftotal_amount\x18\x03 \x01(\x03R\vtotalAmount2\xd1\f\n" +
+	"\ftotal_amount\x18\x03 \x01(\x03R\vtotalAmount\"\xde\x01\n" +
+	"\x15GetDailyStatsResponse\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12+\n" +
+	"\x11transaction_count\x18\x03 \x01(\x05R\x10transactionCount\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\x03R\vtotalAmount\x12;\n" +
+	"\vdaily_stats\x18\x05 \x03(\v2\x1a.etc_meisai.v1.ETCDateStatR\n" +
+	"dailyStats\"q\n" +
+	"\vETCDateStat\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12+\n" +
+	"\x11transaction_count\x18\x02 \x01(\x05R\x10transactionCount\x12!\n" +
+	"\ftotal_amount\x18\x03 \x01(\x03R\vtotalAmount2\xe7\x10\n" +
 	"\n" +
 	"ETCService\x12y\n" +
 	"\x0fCreateETCMeisai\x12%.etc_meisai.v1.CreateETCMeisaiRequest\x1a .etc_meisai.v1.ETCMeisaiResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/api/v1/etc/meisai\x12u\n" +
@@ -1660,16 +2409,21 @@ const file_etc_service_proto_rawDesc = "" +
 	"\x0fUpdateETCMeisai\x12%.etc_meisai.v1.UpdateETCMeisaiRequest\x1a .etc_meisai.v1.ETCMeisaiResponse\"+\x82\xd3\xe4\x93\x02%:\n" +
 	"etc_meisai\x1a\x17/api/v1/etc/meisai/{id}\x12q\n" +
 	"\x0fDeleteETCMeisai\x12%.etc_meisai.v1.DeleteETCMeisaiRequest\x1a\x16.google.protobuf.Empty\"\x1f\x82\xd3\xe4\x93\x02\x19*\x17/api/v1/etc/meisai/{id}\x12v\n" +
-	"\rListETCMeisai\x12#.etc_meisai.v1.ListETCMeisaiRequest\x1a$.etc_meisai.v1.ListETCMeisaiResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/etc/meisai\x12l\n" +
+	"\rListETCMeisai\x12#.etc_meisai.v1.ListETCMeisaiRequest\x1a$.etc_meisai.v1.ListETCMeisaiResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/etc/meisai\x12b\n" +
+	"\x0fStreamETCMeisai\x12%.etc_meisai.v1.StreamETCMeisaiRequest\x1a&.etc_meisai.v1.StreamETCMeisaiResponse0\x01\x12l\n" +
 	"\x13BulkCreateETCMeisai\x12).etc_meisai.v1.BulkCreateETCMeisaiRequest\x1a*.etc_meisai.v1.BulkCreateETCMeisaiResponse\x12l\n" +
-	"\x13BulkUpdateETCMeisai\x12).etc_meisai.v1.BulkUpdateETCMeisaiRequest\x1a*.etc_meisai.v1.BulkUpdateETCMeisaiResponse\x12n\n" +
+	"\x13BulkUpdateETCMeisai\x12).etc_meisai.v1.BulkUpdateETCMeisaiRequest\x1a*.etc_meisai.v1.BulkUpdateETCMeisaiResponse\x12l\n" +
+	"\x13BulkDeleteETCMeisai\x12).etc_meisai.v1.BulkDeleteETCMeisaiRequest\x1a*.etc_meisai.v1.BulkDeleteETCMeisaiResponse\x12n\n" +
 	"\x17GetETCMeisaiByDateRange\x12-.etc_meisai.v1.GetETCMeisaiByDateRangeRequest\x1a$.etc_meisai.v1.ListETCMeisaiResponse\x12`\n" +
 	"\x12GetETCMeisaiByHash\x12(.etc_meisai.v1.GetETCMeisaiByHashRequest\x1a .etc_meisai.v1.ETCMeisaiResponse\x12h\n" +
 	"\x14GetUnmappedETCMeisai\x12*.etc_meisai.v1.GetUnmappedETCMeisaiRequest\x1a$.etc_meisai.v1.ListETCMeisaiResponse\x12l\n" +
-	"\x15CheckDuplicatesByHash\x12+.etc_meisai.v1.CheckDuplicatesByHashRequest\x1a&.etc_meisai.v1.CheckDuplicatesResponse\x12W\n" +
-	"\fGenerateHash\x12\".etc_meisai.v1.GenerateHashRequest\x1a#.etc_meisai.v1.GenerateHashResponse\x12w\n" +
+	"\x15CheckDuplicatesByHash\x12+.etc_meisai.v1.CheckDuplicatesByHashRequest\x1a&.etc_meisai.v1.CheckDuplicatesResponse\x12]\n" +
+	"\x14DeduplicateETCMeisai\x12!.etc_meisai.v1.DeduplicateRequest\x1a\".etc_meisai.v1.DeduplicateResponse\x12W\n" +
+	"\fGenerateHash\x12\".etc_meisai.v1.GenerateHashRequest\x1a#.etc_meisai.v1.GenerateHashResponse\x12f\n" +
+	"\x11BatchGenerateHash\x12'.etc_meisai.v1.BatchGenerateHashRequest\x1a(.etc_meisai.v1.BatchGenerateHashResponse\x12w\n" +
 	"\rGetETCSummary\x12#.etc_meisai.v1.GetETCSummaryRequest\x1a$.etc_meisai.v1.GetETCSummaryResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/api/v1/etc/summary\x12\x83\x01\n" +
-	"\x0fGetMonthlyStats\x12%.etc_meisai.v1.GetMonthlyStatsRequest\x1a&.etc_meisai.v1.GetMonthlyStatsResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/etc/stats/monthlyB?Z=github.com/yhonda-ohishi/db-handler-server/proto;etc_meisaiv1b\x06proto3"
+	"\x0fGetMonthlyStats\x12%.etc_meisai.v1.GetMonthlyStatsRequest\x1a&.etc_meisai.v1.GetMonthlyStatsResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/etc/stats/monthly\x12{\n" +
+	"\rGetDailyStats\x12#.etc_meisai.v1.GetDailyStatsRequest\x1a$.etc_meisai.v1.GetDailyStatsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/etc/stats/dailyB?Z=github.com/yhonda-ohishi/db-handler-server/proto;etc_meisaiv1b\x06proto3"
 
 var (
 	file_etc_service_proto_rawDescOnce sync.Once
@@ -1683,7 +2437,7 @@ func file_etc_service_proto_rawDescGZIP() []byte {
 	return file_etc_service_proto_rawDescData
 }
 
-var file_etc_service_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_etc_service_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_etc_service_proto_goTypes = []any{
 	(*ETCMeisai)(nil),                      // 0: etc_meisai.v1.ETCMeisai
 	(*CreateETCMeisaiRequest)(nil),         // 1: etc_meisai.v1.CreateETCMeisaiRequest
@@ -1691,75 +2445,99 @@ var file_etc_service_proto_goTypes = []any{
 	(*UpdateETCMeisaiRequest)(nil),         // 3: etc_meisai.v1.UpdateETCMeisaiRequest
 	(*DeleteETCMeisaiRequest)(nil),         // 4: etc_meisai.v1.DeleteETCMeisaiRequest
 	(*ListETCMeisaiRequest)(nil),           // 5: etc_meisai.v1.ListETCMeisaiRequest
-	(*BulkCreateETCMeisaiRequest)(nil),     // 6: etc_meisai.v1.BulkCreateETCMeisaiRequest
-	(*BulkUpdateETCMeisaiRequest)(nil),     // 7: etc_meisai.v1.BulkUpdateETCMeisaiRequest
-	(*GetETCMeisaiByDateRangeRequest)(nil), // 8: etc_meisai.v1.GetETCMeisaiByDateRangeRequest
-	(*GetETCMeisaiByHashRequest)(nil),      // 9: etc_meisai.v1.GetETCMeisaiByHashRequest
-	(*GetUnmappedETCMeisaiRequest)(nil),    // 10: etc_meisai.v1.GetUnmappedETCMeisaiRequest
-	(*CheckDuplicatesByHashRequest)(nil),   // 11: etc_meisai.v1.CheckDuplicatesByHashRequest
-	(*GenerateHashRequest)(nil),            // 12: etc_meisai.v1.GenerateHashRequest
-	(*GetETCSummaryRequest)(nil),           // 13: etc_meisai.v1.GetETCSummaryRequest
-	(*GetMonthlyStatsRequest)(nil),         // 14: etc_meisai.v1.GetMonthlyStatsRequest
-	(*ETCMeisaiResponse)(nil),              // 15: etc_meisai.v1.ETCMeisaiResponse
-	(*ListETCMeisaiResponse)(nil),          // 16: etc_meisai.v1.ListETCMeisaiResponse
-	(*BulkCreateETCMeisaiResponse)(nil),    // 17: etc_meisai.v1.BulkCreateETCMeisaiResponse
-	(*BulkUpdateETCMeisaiResponse)(nil),    // 18: etc_meisai.v1.BulkUpdateETCMeisaiResponse
-	(*CheckDuplicatesResponse)(nil),        // 19: etc_meisai.v1.CheckDuplicatesResponse
-	(*GenerateHashResponse)(nil),           // 20: etc_meisai.v1.GenerateHashResponse
-	(*GetETCSummaryResponse)(nil),          // 21: etc_meisai.v1.GetETCSummaryResponse
-	(*GetMonthlyStatsResponse)(nil),        // 22: etc_meisai.v1.GetMonthlyStatsResponse
-	(*ETCMonthlySummary)(nil),              // 23: etc_meisai.v1.ETCMonthlySummary
-	(*ETCDailyStat)(nil),                   // 24: etc_meisai.v1.ETCDailyStat
-	(*timestamppb.Timestamp)(nil),          // 25: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                  // 26: google.protobuf.Empty
+	(*StreamETCMeisaiRequest)(nil),         // 6: etc_meisai.v1.StreamETCMeisaiRequest
+	(*BulkCreateETCMeisaiRequest)(nil),     // 7: etc_meisai.v1.BulkCreateETCMeisaiRequest
+	(*BulkUpdateETCMeisaiRequest)(nil),     // 8: etc_meisai.v1.BulkUpdateETCMeisaiRequest
+	(*BulkDeleteETCMeisaiRequest)(nil),     // 9: etc_meisai.v1.BulkDeleteETCMeisaiRequest
+	(*GetETCMeisaiByDateRangeRequest)(nil), // 10: etc_meisai.v1.GetETCMeisaiByDateRangeRequest
+	(*GetETCMeisaiByHashRequest)(nil),      // 11: etc_meisai.v1.GetETCMeisaiByHashRequest
+	(*GetUnmappedETCMeisaiRequest)(nil),    // 12: etc_meisai.v1.GetUnmappedETCMeisaiRequest
+	(*CheckDuplicatesByHashRequest)(nil),   // 13: etc_meisai.v1.CheckDuplicatesByHashRequest
+	(*DeduplicateRequest)(nil),             // 14: etc_meisai.v1.DeduplicateRequest
+	(*GenerateHashRequest)(nil),            // 15: etc_meisai.v1.GenerateHashRequest
+	(*BatchGenerateHashRequest)(nil),       // 16: etc_meisai.v1.BatchGenerateHashRequest
+	(*GetETCSummaryRequest)(nil),           // 17: etc_meisai.v1.GetETCSummaryRequest
+	(*GetMonthlyStatsRequest)(nil),         // 18: etc_meisai.v1.GetMonthlyStatsRequest
+	(*GetDailyStatsRequest)(nil),           // 19: etc_meisai.v1.GetDailyStatsRequest
+	(*ETCMeisaiResponse)(nil),              // 20: etc_meisai.v1.ETCMeisaiResponse
+	(*ListETCMeisaiResponse)(nil),          // 21: etc_meisai.v1.ListETCMeisaiResponse
+	(*BulkCreateETCMeisaiResponse)(nil),    // 22: etc_meisai.v1.BulkCreateETCMeisaiResponse
+	(*BulkUpdateETCMeisaiResponse)(nil),    // 23: etc_meisai.v1.BulkUpdateETCMeisaiResponse
+	(*BulkDeleteETCMeisaiResponse)(nil),    // 24: etc_meisai.v1.BulkDeleteETCMeisaiResponse
+	(*CheckDuplicatesResponse)(nil),        // 25: etc_meisai.v1.CheckDuplicatesResponse
+	(*DeduplicateResponse)(nil),            // 26: etc_meisai.v1.DeduplicateResponse
+	(*GenerateHashResponse)(nil),           // 27: etc_meisai.v1.GenerateHashResponse
+	(*BatchGenerateHashResponse)(nil),      // 28: etc_meisai.v1.BatchGenerateHashResponse
+	(*StreamETCMeisaiResponse)(nil),        // 29: etc_meisai.v1.StreamETCMeisaiResponse
+	(*GetETCSummaryResponse)(nil),          // 30: etc_meisai.v1.GetETCSummaryResponse
+	(*GetMonthlyStatsResponse)(nil),        // 31: etc_meisai.v1.GetMonthlyStatsResponse
+	(*ETCMonthlySummary)(nil),              // 32: etc_meisai.v1.ETCMonthlySummary
+	(*ETCDailyStat)(nil),                   // 33: etc_meisai.v1.ETCDailyStat
+	(*GetDailyStatsResponse)(nil),          // 34: etc_meisai.v1.GetDailyStatsResponse
+	(*ETCDateStat)(nil),                    // 35: etc_meisai.v1.ETCDateStat
+	(*timestamppb.Timestamp)(nil),          // 36: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                  // 37: google.protobuf.Empty
 }
 var file_etc_service_proto_depIdxs = []int32{
-	25, // 0: etc_meisai.v1.ETCMeisai.created_at:type_name -> google.protobuf.Timestamp
-	25, // 1: etc_meisai.v1.ETCMeisai.updated_at:type_name -> google.protobuf.Timestamp
+	36, // 0: etc_meisai.v1.ETCMeisai.created_at:type_name -> google.protobuf.Timestamp
+	36, // 1: etc_meisai.v1.ETCMeisai.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: etc_meisai.v1.CreateETCMeisaiRequest.etc_meisai:type_name -> etc_meisai.v1.ETCMeisai
 	0,  // 3: etc_meisai.v1.UpdateETCMeisaiRequest.etc_meisai:type_name -> etc_meisai.v1.ETCMeisai
 	0,  // 4: etc_meisai.v1.BulkCreateETCMeisaiRequest.etc_meisai_list:type_name -> etc_meisai.v1.ETCMeisai
 	0,  // 5: etc_meisai.v1.BulkUpdateETCMeisaiRequest.etc_meisai_list:type_name -> etc_meisai.v1.ETCMeisai
 	0,  // 6: etc_meisai.v1.GenerateHashRequest.etc_meisai:type_name -> etc_meisai.v1.ETCMeisai
-	0,  // 7: etc_meisai.v1.ETCMeisaiResponse.etc_meisai:type_name -> etc_meisai.v1.ETCMeisai
-	0,  // 8: etc_meisai.v1.ListETCMeisaiResponse.etc_meisai_list:type_name -> etc_meisai.v1.ETCMeisai
-	0,  // 9: etc_meisai.v1.BulkCreateETCMeisaiResponse.created_etc_meisai_list:type_name -> etc_meisai.v1.ETCMeisai
-	0,  // 10: etc_meisai.v1.BulkUpdateETCMeisaiResponse.updated_etc_meisai_list:type_name -> etc_meisai.v1.ETCMeisai
-	23, // 11: etc_meisai.v1.GetETCSummaryResponse.monthly_summaries:type_name -> etc_meisai.v1.ETCMonthlySummary
-	24, // 12: etc_meisai.v1.GetMonthlyStatsResponse.daily_stats:type_name -> etc_meisai.v1.ETCDailyStat
-	1,  // 13: etc_meisai.v1.ETCService.CreateETCMeisai:input_type -> etc_meisai.v1.CreateETCMeisaiRequest
-	2,  // 14: etc_meisai.v1.ETCService.GetETCMeisai:input_type -> etc_meisai.v1.GetETCMeisaiRequest
-	3,  // 15: etc_meisai.v1.ETCService.UpdateETCMeisai:input_type -> etc_meisai.v1.UpdateETCMeisaiRequest
-	4,  // 16: etc_meisai.v1.ETCService.DeleteETCMeisai:input_type -> etc_meisai.v1.DeleteETCMeisaiRequest
-	5,  // 17: etc_meisai.v1.ETCService.ListETCMeisai:input_type -> etc_meisai.v1.ListETCMeisaiRequest
-	6,  // 18: etc_meisai.v1.ETCService.BulkCreateETCMeisai:input_type -> etc_meisai.v1.BulkCreateETCMeisaiRequest
-	7,  // 19: etc_meisai.v1.ETCService.BulkUpdateETCMeisai:input_type -> etc_meisai.v1.BulkUpdateETCMeisaiRequest
-	8,  // 20: etc_meisai.v1.ETCService.GetETCMeisaiByDateRange:input_type -> etc_meisai.v1.GetETCMeisaiByDateRangeRequest
-	9,  // 21: etc_meisai.v1.ETCService.GetETCMeisaiByHash:input_type -> etc_meisai.v1.GetETCMeisaiByHashRequest
-	10, // 22: etc_meisai.v1.ETCService.GetUnmappedETCMeisai:input_type -> etc_meisai.v1.GetUnmappedETCMeisaiRequest
-	11, // 23: etc_meisai.v1.ETCService.CheckDuplicatesByHash:input_type -> etc_meisai.v1.CheckDuplicatesByHashRequest
-	12, // 24: etc_meisai.v1.ETCService.GenerateHash:input_type -> etc_meisai.v1.GenerateHashRequest
-	13, // 25: etc_meisai.v1.ETCService.GetETCSummary:input_type -> etc_meisai.v1.GetETCSummaryRequest
-	14, // 26: etc_meisai.v1.ETCService.GetMonthlyStats:input_type -> etc_meisai.v1.GetMonthlyStatsRequest
-	15, // 27: etc_meisai.v1.ETCService.CreateETCMeisai:output_type -> etc_meisai.v1.ETCMeisaiResponse
-	15, // 28: etc_meisai.v1.ETCService.GetETCMeisai:output_type -> etc_meisai.v1.ETCMeisaiResponse
-	15, // 29: etc_meisai.v1.ETCService.UpdateETCMeisai:output_type -> etc_meisai.v1.ETCMeisaiResponse
-	26, // 30: etc_meisai.v1.ETCService.DeleteETCMeisai:output_type -> google.protobuf.Empty
-	16, // 31: etc_meisai.v1.ETCService.ListETCMeisai:output_type -> etc_meisai.v1.ListETCMeisaiResponse
-	17, // 32: etc_meisai.v1.ETCService.BulkCreateETCMeisai:output_type -> etc_meisai.v1.BulkCreateETCMeisaiResponse
-	18, // 33: etc_meisai.v1.ETCService.BulkUpdateETCMeisai:output_type -> etc_meisai.v1.BulkUpdateETCMeisaiResponse
-	16, // 34: etc_meisai.v1.ETCService.GetETCMeisaiByDateRange:output_type -> etc_meisai.v1.ListETCMeisaiResponse
-	15, // 35: etc_meisai.v1.ETCService.GetETCMeisaiByHash:output_type -> etc_meisai.v1.ETCMeisaiResponse
-	16, // 36: etc_meisai.v1.ETCService.GetUnmappedETCMeisai:output_type -> etc_meisai.v1.ListETCMeisaiResponse
-	19, // 37: etc_meisai.v1.ETCService.CheckDuplicatesByHash:output_type -> etc_meisai.v1.CheckDuplicatesResponse
-	20, // 38: etc_meisai.v1.ETCService.GenerateHash:output_type -> etc_meisai.v1.GenerateHashResponse
-	21, // 39: etc_meisai.v1.ETCService.GetETCSummary:output_type -> etc_meisai.v1.GetETCSummaryResponse
-	22, // 40: etc_meisai.v1.ETCService.GetMonthlyStats:output_type -> etc_meisai.v1.GetMonthlyStatsResponse
-	27, // [27:41] is the sub-list for method output_type
-	13, // [13:27] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	0,  // 7: etc_meisai.v1.BatchGenerateHashRequest.etc_meisai_list:type_name -> etc_meisai.v1.ETCMeisai
+	0,  // 8: etc_meisai.v1.ETCMeisaiResponse.etc_meisai:type_name -> etc_meisai.v1.ETCMeisai
+	0,  // 9: etc_meisai.v1.ListETCMeisaiResponse.etc_meisai_list:type_name -> etc_meisai.v1.ETCMeisai
+	0,  // 10: etc_meisai.v1.BulkCreateETCMeisaiResponse.created_etc_meisai_list:type_name -> etc_meisai.v1.ETCMeisai
+	0,  // 11: etc_meisai.v1.BulkUpdateETCMeisaiResponse.updated_etc_meisai_list:type_name -> etc_meisai.v1.ETCMeisai
+	0,  // 12: etc_meisai.v1.StreamETCMeisaiResponse.etc_meisai_list:type_name -> etc_meisai.v1.ETCMeisai
+	32, // 13: etc_meisai.v1.GetETCSummaryResponse.monthly_summaries:type_name -> etc_meisai.v1.ETCMonthlySummary
+	33, // 14: etc_meisai.v1.GetMonthlyStatsResponse.daily_stats:type_name -> etc_meisai.v1.ETCDailyStat
+	35, // 15: etc_meisai.v1.GetDailyStatsResponse.daily_stats:type_name -> etc_meisai.v1.ETCDateStat
+	1,  // 16: etc_meisai.v1.ETCService.CreateETCMeisai:input_type -> etc_meisai.v1.CreateETCMeisaiRequest
+	2,  // 17: etc_meisai.v1.ETCService.GetETCMeisai:input_type -> etc_meisai.v1.GetETCMeisaiRequest
+	3,  // 18: etc_meisai.v1.ETCService.UpdateETCMeisai:input_type -> etc_meisai.v1.UpdateETCMeisaiRequest
+	4,  // 19: etc_meisai.v1.ETCService.DeleteETCMeisai:input_type -> etc_meisai.v1.DeleteETCMeisaiRequest
+	5,  // 20: etc_meisai.v1.ETCService.ListETCMeisai:input_type -> etc_meisai.v1.ListETCMeisaiRequest
+	6,  // 21: etc_meisai.v1.ETCService.StreamETCMeisai:input_type -> etc_meisai.v1.StreamETCMeisaiRequest
+	7,  // 22: etc_meisai.v1.ETCService.BulkCreateETCMeisai:input_type -> etc_meisai.v1.BulkCreateETCMeisaiRequest
+	8,  // 23: etc_meisai.v1.ETCService.BulkUpdateETCMeisai:input_type -> etc_meisai.v1.BulkUpdateETCMeisaiRequest
+	9,  // 24: etc_meisai.v1.ETCService.BulkDeleteETCMeisai:input_type -> etc_meisai.v1.BulkDeleteETCMeisaiRequest
+	10, // 25: etc_meisai.v1.ETCService.GetETCMeisaiByDateRange:input_type -> etc_meisai.v1.GetETCMeisaiByDateRangeRequest
+	11, // 26: etc_meisai.v1.ETCService.GetETCMeisaiByHash:input_type -> etc_meisai.v1.GetETCMeisaiByHashRequest
+	12, // 27: etc_meisai.v1.ETCService.GetUnmappedETCMeisai:input_type -> etc_meisai.v1.GetUnmappedETCMeisaiRequest
+	13, // 28: etc_meisai.v1.ETCService.CheckDuplicatesByHash:input_type -> etc_meisai.v1.CheckDuplicatesByHashRequest
+	14, // 29: etc_meisai.v1.ETCService.DeduplicateETCMeisai:input_type -> etc_meisai.v1.DeduplicateRequest
+	15, // 30: etc_meisai.v1.ETCService.GenerateHash:input_type -> etc_meisai.v1.GenerateHashRequest
+	16, // 31: etc_meisai.v1.ETCService.BatchGenerateHash:input_type -> etc_meisai.v1.BatchGenerateHashRequest
+	17, // 32: etc_meisai.v1.ETCService.GetETCSummary:input_type -> etc_meisai.v1.GetETCSummaryRequest
+	18, // 33: etc_meisai.v1.ETCService.GetMonthlyStats:input_type -> etc_meisai.v1.GetMonthlyStatsRequest
+	19, // 34: etc_meisai.v1.ETCService.GetDailyStats:input_type -> etc_meisai.v1.GetDailyStatsRequest
+	20, // 35: etc_meisai.v1.ETCService.CreateETCMeisai:output_type -> etc_meisai.v1.ETCMeisaiResponse
+	20, // 36: etc_meisai.v1.ETCService.GetETCMeisai:output_type -> etc_meisai.v1.ETCMeisaiResponse
+	20, // 37: etc_meisai.v1.ETCService.UpdateETCMeisai:output_type -> etc_meisai.v1.ETCMeisaiResponse
+	37, // 38: etc_meisai.v1.ETCService.DeleteETCMeisai:output_type -> google.protobuf.Empty
+	21, // 39: etc_meisai.v1.ETCService.ListETCMeisai:output_type -> etc_meisai.v1.ListETCMeisaiResponse
+	29, // 40: etc_meisai.v1.ETCService.StreamETCMeisai:output_type -> etc_meisai.v1.StreamETCMeisaiResponse
+	22, // 41: etc_meisai.v1.ETCService.BulkCreateETCMeisai:output_type -> etc_meisai.v1.BulkCreateETCMeisaiResponse
+	23, // 42: etc_meisai.v1.ETCService.BulkUpdateETCMeisai:output_type -> etc_meisai.v1.BulkUpdateETCMeisaiResponse
+	24, // 43: etc_meisai.v1.ETCService.BulkDeleteETCMeisai:output_type -> etc_meisai.v1.BulkDeleteETCMeisaiResponse
+	21, // 44: etc_meisai.v1.ETCService.GetETCMeisaiByDateRange:output_type -> etc_meisai.v1.ListETCMeisaiResponse
+	20, // 45: etc_meisai.v1.ETCService.GetETCMeisaiByHash:output_type -> etc_meisai.v1.ETCMeisaiResponse
+	21, // 46: etc_meisai.v1.ETCService.GetUnmappedETCMeisai:output_type -> etc_meisai.v1.ListETCMeisaiResponse
+	25, // 47: etc_meisai.v1.ETCService.CheckDuplicatesByHash:output_type -> etc_meisai.v1.CheckDuplicatesResponse
+	26, // 48: etc_meisai.v1.ETCService.DeduplicateETCMeisai:output_type -> etc_meisai.v1.DeduplicateResponse
+	27, // 49: etc_meisai.v1.ETCService.GenerateHash:output_type -> etc_meisai.v1.GenerateHashResponse
+	28, // 50: etc_meisai.v1.ETCService.BatchGenerateHash:output_type -> etc_meisai.v1.BatchGenerateHashResponse
+	30, // 51: etc_meisai.v1.ETCService.GetETCSummary:output_type -> etc_meisai.v1.GetETCSummaryResponse
+	31, // 52: etc_meisai.v1.ETCService.GetMonthlyStats:output_type -> etc_meisai.v1.GetMonthlyStatsResponse
+	34, // 53: etc_meisai.v1.ETCService.GetDailyStats:output_type -> etc_meisai.v1.GetDailyStatsResponse
+	35, // [35:54] is the sub-list for method output_type
+	16, // [16:35] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_etc_service_proto_init() }
@@ -1773,7 +2551,7 @@ func file_etc_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_etc_service_proto_rawDesc), len(file_etc_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
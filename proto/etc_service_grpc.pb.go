@@ -25,15 +25,20 @@ const (
 	ETCService_UpdateETCMeisai_FullMethodName         = "/etc_meisai.v1.ETCService/UpdateETCMeisai"
 	ETCService_DeleteETCMeisai_FullMethodName         = "/etc_meisai.v1.ETCService/DeleteETCMeisai"
 	ETCService_ListETCMeisai_FullMethodName           = "/etc_meisai.v1.ETCService/ListETCMeisai"
+	ETCService_StreamETCMeisai_FullMethodName         = "/etc_meisai.v1.ETCService/StreamETCMeisai"
 	ETCService_BulkCreateETCMeisai_FullMethodName     = "/etc_meisai.v1.ETCService/BulkCreateETCMeisai"
 	ETCService_BulkUpdateETCMeisai_FullMethodName     = "/etc_meisai.v1.ETCService/BulkUpdateETCMeisai"
+	ETCService_BulkDeleteETCMeisai_FullMethodName     = "/etc_meisai.v1.ETCService/BulkDeleteETCMeisai"
 	ETCService_GetETCMeisaiByDateRange_FullMethodName = "/etc_meisai.v1.ETCService/GetETCMeisaiByDateRange"
 	ETCService_GetETCMeisaiByHash_FullMethodName      = "/etc_meisai.v1.ETCService/GetETCMeisaiByHash"
 	ETCService_GetUnmappedETCMeisai_FullMethodName    = "/etc_meisai.v1.ETCService/GetUnmappedETCMeisai"
 	ETCService_CheckDuplicatesByHash_FullMethodName   = "/etc_meisai.v1.ETCService/CheckDuplicatesByHash"
+	ETCService_DeduplicateETCMeisai_FullMethodName    = "/etc_meisai.v1.ETCService/DeduplicateETCMeisai"
 	ETCService_GenerateHash_FullMethodName            = "/etc_meisai.v1.ETCService/GenerateHash"
+	ETCService_BatchGenerateHash_FullMethodName       = "/etc_meisai.v1.ETCService/BatchGenerateHash"
 	ETCService_GetETCSummary_FullMethodName           = "/etc_meisai.v1.ETCService/GetETCSummary"
 	ETCService_GetMonthlyStats_FullMethodName         = "/etc_meisai.v1.ETCService/GetMonthlyStats"
+	ETCService_GetDailyStats_FullMethodName           = "/etc_meisai.v1.ETCService/GetDailyStats"
 )
 
 // ETCServiceClient is the client API for ETCService service.
@@ -48,19 +53,26 @@ type ETCServiceClient interface {
 	UpdateETCMeisai(ctx context.Context, in *UpdateETCMeisaiRequest, opts ...grpc.CallOption) (*ETCMeisaiResponse, error)
 	DeleteETCMeisai(ctx context.Context, in *DeleteETCMeisaiRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListETCMeisai(ctx context.Context, in *ListETCMeisaiRequest, opts ...grpc.CallOption) (*ListETCMeisaiResponse, error)
+	// Streams every record matching the filters, oldest first, so large
+	// exports don't need to page through ListETCMeisai
+	StreamETCMeisai(ctx context.Context, in *StreamETCMeisaiRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamETCMeisaiResponse], error)
 	// Bulk operations
 	BulkCreateETCMeisai(ctx context.Context, in *BulkCreateETCMeisaiRequest, opts ...grpc.CallOption) (*BulkCreateETCMeisaiResponse, error)
 	BulkUpdateETCMeisai(ctx context.Context, in *BulkUpdateETCMeisaiRequest, opts ...grpc.CallOption) (*BulkUpdateETCMeisaiResponse, error)
+	BulkDeleteETCMeisai(ctx context.Context, in *BulkDeleteETCMeisaiRequest, opts ...grpc.CallOption) (*BulkDeleteETCMeisaiResponse, error)
 	// Advanced query operations
 	GetETCMeisaiByDateRange(ctx context.Context, in *GetETCMeisaiByDateRangeRequest, opts ...grpc.CallOption) (*ListETCMeisaiResponse, error)
 	GetETCMeisaiByHash(ctx context.Context, in *GetETCMeisaiByHashRequest, opts ...grpc.CallOption) (*ETCMeisaiResponse, error)
 	GetUnmappedETCMeisai(ctx context.Context, in *GetUnmappedETCMeisaiRequest, opts ...grpc.CallOption) (*ListETCMeisaiResponse, error)
 	// Utility operations
 	CheckDuplicatesByHash(ctx context.Context, in *CheckDuplicatesByHashRequest, opts ...grpc.CallOption) (*CheckDuplicatesResponse, error)
+	DeduplicateETCMeisai(ctx context.Context, in *DeduplicateRequest, opts ...grpc.CallOption) (*DeduplicateResponse, error)
 	GenerateHash(ctx context.Context, in *GenerateHashRequest, opts ...grpc.CallOption) (*GenerateHashResponse, error)
+	BatchGenerateHash(ctx context.Context, in *BatchGenerateHashRequest, opts ...grpc.CallOption) (*BatchGenerateHashResponse, error)
 	// Summary and statistics
 	GetETCSummary(ctx context.Context, in *GetETCSummaryRequest, opts ...grpc.CallOption) (*GetETCSummaryResponse, error)
 	GetMonthlyStats(ctx context.Context, in *GetMonthlyStatsRequest, opts ...grpc.CallOption) (*GetMonthlyStatsResponse, error)
+	GetDailyStats(ctx context.Context, in *GetDailyStatsRequest, opts ...grpc.CallOption) (*GetDailyStatsResponse, error)
 }
 
 type eTCServiceClient struct {
@@ -121,6 +133,25 @@ func (c *eTCServiceClient) ListETCMeisai(ctx context.Context, in *ListETCMeisaiR
 	return out, nil
 }
 
+func (c *eTCServiceClient) StreamETCMeisai(ctx context.Context, in *StreamETCMeisaiRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamETCMeisaiResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ETCService_ServiceDesc.Streams[0], ETCService_StreamETCMeisai_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamETCMeisaiRequest, StreamETCMeisaiResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ETCService_StreamETCMeisaiClient = grpc.ServerStreamingClient[StreamETCMeisaiResponse]
+
 func (c *eTCServiceClient) BulkCreateETCMeisai(ctx context.Context, in *BulkCreateETCMeisaiRequest, opts ...grpc.CallOption) (*BulkCreateETCMeisaiResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BulkCreateETCMeisaiResponse)
@@ -141,6 +172,16 @@ func (c *eTCServiceClient) BulkUpdateETCMeisai(ctx context.Context, in *BulkUpda
 	return out, nil
 }
 
+func (c *eTCServiceClient) BulkDeleteETCMeisai(ctx context.Context, in *BulkDeleteETCMeisaiRequest, opts ...grpc.CallOption) (*BulkDeleteETCMeisaiResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkDeleteETCMeisaiResponse)
+	err := c.cc.Invoke(ctx, ETCService_BulkDeleteETCMeisai_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *eTCServiceClient) GetETCMeisaiByDateRange(ctx context.Context, in *GetETCMeisaiByDateRangeRequest, opts ...grpc.CallOption) (*ListETCMeisaiResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListETCMeisaiResponse)
@@ -181,6 +222,16 @@ func (c *eTCServiceClient) CheckDuplicatesByHash(ctx context.Context, in *CheckD
 	return out, nil
 }
 
+func (c *eTCServiceClient) DeduplicateETCMeisai(ctx context.Context, in *DeduplicateRequest, opts ...grpc.CallOption) (*DeduplicateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeduplicateResponse)
+	err := c.cc.Invoke(ctx, ETCService_DeduplicateETCMeisai_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *eTCServiceClient) GenerateHash(ctx context.Context, in *GenerateHashRequest, opts ...grpc.CallOption) (*GenerateHashResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GenerateHashResponse)
@@ -191,6 +242,16 @@ func (c *eTCServiceClient) GenerateHash(ctx context.Context, in *GenerateHashReq
 	return out, nil
 }
 
+func (c *eTCServiceClient) BatchGenerateHash(ctx context.Context, in *BatchGenerateHashRequest, opts ...grpc.CallOption) (*BatchGenerateHashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGenerateHashResponse)
+	err := c.cc.Invoke(ctx, ETCService_BatchGenerateHash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *eTCServiceClient) GetETCSummary(ctx context.Context, in *GetETCSummaryRequest, opts ...grpc.CallOption) (*GetETCSummaryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetETCSummaryResponse)
@@ -211,6 +272,16 @@ func (c *eTCServiceClient) GetMonthlyStats(ctx context.Context, in *GetMonthlySt
 	return out, nil
 }
 
+func (c *eTCServiceClient) GetDailyStats(ctx context.Context, in *GetDailyStatsRequest, opts ...grpc.CallOption) (*GetDailyStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDailyStatsResponse)
+	err := c.cc.Invoke(ctx, ETCService_GetDailyStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ETCServiceServer is the server API for ETCService service.
 // All implementations must embed UnimplementedETCServiceServer
 // for forward compatibility.
@@ -223,19 +294,26 @@ type ETCServiceServer interface {
 	UpdateETCMeisai(context.Context, *UpdateETCMeisaiRequest) (*ETCMeisaiResponse, error)
 	DeleteETCMeisai(context.Context, *DeleteETCMeisaiRequest) (*emptypb.Empty, error)
 	ListETCMeisai(context.Context, *ListETCMeisaiRequest) (*ListETCMeisaiResponse, error)
+	// Streams every record matching the filters, oldest first, so large
+	// exports don't need to page through ListETCMeisai
+	StreamETCMeisai(*StreamETCMeisaiRequest, grpc.ServerStreamingServer[StreamETCMeisaiResponse]) error
 	// Bulk operations
 	BulkCreateETCMeisai(context.Context, *BulkCreateETCMeisaiRequest) (*BulkCreateETCMeisaiResponse, error)
 	BulkUpdateETCMeisai(context.Context, *BulkUpdateETCMeisaiRequest) (*BulkUpdateETCMeisaiResponse, error)
+	BulkDeleteETCMeisai(context.Context, *BulkDeleteETCMeisaiRequest) (*BulkDeleteETCMeisaiResponse, error)
 	// Advanced query operations
 	GetETCMeisaiByDateRange(context.Context, *GetETCMeisaiByDateRangeRequest) (*ListETCMeisaiResponse, error)
 	GetETCMeisaiByHash(context.Context, *GetETCMeisaiByHashRequest) (*ETCMeisaiResponse, error)
 	GetUnmappedETCMeisai(context.Context, *GetUnmappedETCMeisaiRequest) (*ListETCMeisaiResponse, error)
 	// Utility operations
 	CheckDuplicatesByHash(context.Context, *CheckDuplicatesByHashRequest) (*CheckDuplicatesResponse, error)
+	DeduplicateETCMeisai(context.Context, *DeduplicateRequest) (*DeduplicateResponse, error)
 	GenerateHash(context.Context, *GenerateHashRequest) (*GenerateHashResponse, error)
+	BatchGenerateHash(context.Context, *BatchGenerateHashRequest) (*BatchGenerateHashResponse, error)
 	// Summary and statistics
 	GetETCSummary(context.Context, *GetETCSummaryRequest) (*GetETCSummaryResponse, error)
 	GetMonthlyStats(context.Context, *GetMonthlyStatsRequest) (*GetMonthlyStatsResponse, error)
+	GetDailyStats(context.Context, *GetDailyStatsRequest) (*GetDailyStatsResponse, error)
 	mustEmbedUnimplementedETCServiceServer()
 }
 
@@ -261,12 +339,18 @@ func (UnimplementedETCServiceServer) DeleteETCMeisai(context.Context, *DeleteETC
 func (UnimplementedETCServiceServer) ListETCMeisai(context.Context, *ListETCMeisaiRequest) (*ListETCMeisaiResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListETCMeisai not implemented")
 }
+func (UnimplementedETCServiceServer) StreamETCMeisai(*StreamETCMeisaiRequest, grpc.ServerStreamingServer[StreamETCMeisaiResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamETCMeisai not implemented")
+}
 func (UnimplementedETCServiceServer) BulkCreateETCMeisai(context.Context, *BulkCreateETCMeisaiRequest) (*BulkCreateETCMeisaiResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BulkCreateETCMeisai not implemented")
 }
 func (UnimplementedETCServiceServer) BulkUpdateETCMeisai(context.Context, *BulkUpdateETCMeisaiRequest) (*BulkUpdateETCMeisaiResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BulkUpdateETCMeisai not implemented")
 }
+func (UnimplementedETCServiceServer) BulkDeleteETCMeisai(context.Context, *BulkDeleteETCMeisaiRequest) (*BulkDeleteETCMeisaiResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDeleteETCMeisai not implemented")
+}
 func (UnimplementedETCServiceServer) GetETCMeisaiByDateRange(context.Context, *GetETCMeisaiByDateRangeRequest) (*ListETCMeisaiResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetETCMeisaiByDateRange not implemented")
 }
@@ -279,15 +363,24 @@ func (UnimplementedETCServiceServer) GetUnmappedETCMeisai(context.Context, *GetU
 func (UnimplementedETCServiceServer) CheckDuplicatesByHash(context.Context, *CheckDuplicatesByHashRequest) (*CheckDuplicatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckDuplicatesByHash not implemented")
 }
+func (UnimplementedETCServiceServer) DeduplicateETCMeisai(context.Context, *DeduplicateRequest) (*DeduplicateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeduplicateETCMeisai not implemented")
+}
 func (UnimplementedETCServiceServer) GenerateHash(context.Context, *GenerateHashRequest) (*GenerateHashResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateHash not implemented")
 }
+func (UnimplementedETCServiceServer) BatchGenerateHash(context.Context, *BatchGenerateHashRequest) (*BatchGenerateHashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGenerateHash not implemented")
+}
 func (UnimplementedETCServiceServer) GetETCSummary(context.Context, *GetETCSummaryRequest) (*GetETCSummaryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetETCSummary not implemented")
 }
 func (UnimplementedETCServiceServer) GetMonthlyStats(context.Context, *GetMonthlyStatsRequest) (*GetMonthlyStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMonthlyStats not implemented")
 }
+func (UnimplementedETCServiceServer) GetDailyStats(context.Context, *GetDailyStatsRequest) (*GetDailyStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailyStats not implemented")
+}
 func (UnimplementedETCServiceServer) mustEmbedUnimplementedETCServiceServer() {}
 func (UnimplementedETCServiceServer) testEmbeddedByValue()                    {}
 
@@ -399,6 +492,17 @@ func _ETCService_ListETCMeisai_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ETCService_StreamETCMeisai_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamETCMeisaiRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ETCServiceServer).StreamETCMeisai(m, &grpc.GenericServerStream[StreamETCMeisaiRequest, StreamETCMeisaiResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ETCService_StreamETCMeisaiServer = grpc.ServerStreamingServer[StreamETCMeisaiResponse]
+
 func _ETCService_BulkCreateETCMeisai_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BulkCreateETCMeisaiRequest)
 	if err := dec(in); err != nil {
@@ -435,6 +539,24 @@ func _ETCService_BulkUpdateETCMeisai_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ETCService_BulkDeleteETCMeisai_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteETCMeisaiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETCServiceServer).BulkDeleteETCMeisai(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETCService_BulkDeleteETCMeisai_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETCServiceServer).BulkDeleteETCMeisai(ctx, req.(*BulkDeleteETCMeisaiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ETCService_GetETCMeisaiByDateRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetETCMeisaiByDateRangeRequest)
 	if err := dec(in); err != nil {
@@ -507,6 +629,24 @@ func _ETCService_CheckDuplicatesByHash_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ETCService_DeduplicateETCMeisai_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeduplicateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETCServiceServer).DeduplicateETCMeisai(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETCService_DeduplicateETCMeisai_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETCServiceServer).DeduplicateETCMeisai(ctx, req.(*DeduplicateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ETCService_GenerateHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenerateHashRequest)
 	if err := dec(in); err != nil {
@@ -525,6 +665,24 @@ func _ETCService_GenerateHash_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ETCService_BatchGenerateHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGenerateHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETCServiceServer).BatchGenerateHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETCService_BatchGenerateHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETCServiceServer).BatchGenerateHash(ctx, req.(*BatchGenerateHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ETCService_GetETCSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetETCSummaryRequest)
 	if err := dec(in); err != nil {
@@ -561,6 +719,24 @@ func _ETCService_GetMonthlyStats_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ETCService_GetDailyStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETCServiceServer).GetDailyStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETCService_GetDailyStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETCServiceServer).GetDailyStats(ctx, req.(*GetDailyStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ETCService_ServiceDesc is the grpc.ServiceDesc for ETCService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -596,6 +772,10 @@ var ETCService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BulkUpdateETCMeisai",
 			Handler:    _ETCService_BulkUpdateETCMeisai_Handler,
 		},
+		{
+			MethodName: "BulkDeleteETCMeisai",
+			Handler:    _ETCService_BulkDeleteETCMeisai_Handler,
+		},
 		{
 			MethodName: "GetETCMeisaiByDateRange",
 			Handler:    _ETCService_GetETCMeisaiByDateRange_Handler,
@@ -612,10 +792,18 @@ var ETCService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckDuplicatesByHash",
 			Handler:    _ETCService_CheckDuplicatesByHash_Handler,
 		},
+		{
+			MethodName: "DeduplicateETCMeisai",
+			Handler:    _ETCService_DeduplicateETCMeisai_Handler,
+		},
 		{
 			MethodName: "GenerateHash",
 			Handler:    _ETCService_GenerateHash_Handler,
 		},
+		{
+			MethodName: "BatchGenerateHash",
+			Handler:    _ETCService_BatchGenerateHash_Handler,
+		},
 		{
 			MethodName: "GetETCSummary",
 			Handler:    _ETCService_GetETCSummary_Handler,
@@ -624,7 +812,17 @@ var ETCService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMonthlyStats",
 			Handler:    _ETCService_GetMonthlyStats_Handler,
 		},
+		{
+			MethodName: "GetDailyStats",
+			Handler:    _ETCService_GetDailyStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamETCMeisai",
+			Handler:       _ETCService_StreamETCMeisai_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "etc_service.proto",
 }
@@ -0,0 +1,9 @@
+// Package swagger embeds the OpenAPI specs generated from the service protos
+package swagger
+
+import "embed"
+
+// Files holds the generated *.swagger.json specs
+//
+//go:embed *.swagger.json
+var Files embed.FS
\ No newline at end of file
@@ -329,18 +329,109 @@ func (x *GetTransactionHistoryRequest) GetPageToken() string {
 	return ""
 }
 
+// ListTransactionsRequest filters transactions across all cards. Empty
+// fields don't filter.
+type ListTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only transactions with one of these statuses
+	PaymentStatus []PaymentStatus        `protobuf:"varint,1,rep,packed,name=payment_status,json=paymentStatus,proto3,enum=etc_meisai.v1.PaymentStatus" json:"payment_status,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	CardId        string                 `protobuf:"bytes,4,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_transaction_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_transaction_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTransactionsRequest) GetPaymentStatus() []PaymentStatus {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type TransactionList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
 	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	TotalAmount   int64                  `protobuf:"varint,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	// Number of items matching the request across all pages
+	TotalCount int32 `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Page size applied to this response
+	PageSize      int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransactionList) Reset() {
 	*x = TransactionList{}
-	mi := &file_transaction_proto_msgTypes[3]
+	mi := &file_transaction_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -352,7 +443,7 @@ func (x *TransactionList) String() string {
 func (*TransactionList) ProtoMessage() {}
 
 func (x *TransactionList) ProtoReflect() protoreflect.Message {
-	mi := &file_transaction_proto_msgTypes[3]
+	mi := &file_transaction_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,7 +456,7 @@ func (x *TransactionList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionList.ProtoReflect.Descriptor instead.
 func (*TransactionList) Descriptor() ([]byte, []int) {
-	return file_transaction_proto_rawDescGZIP(), []int{3}
+	return file_transaction_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *TransactionList) GetTransactions() []*Transaction {
@@ -389,6 +480,20 @@ func (x *TransactionList) GetTotalAmount() int64 {
 	return 0
 }
 
+func (x *TransactionList) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *TransactionList) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
 var File_transaction_proto protoreflect.FileDescriptor
 
 const file_transaction_proto_rawDesc = "" +
@@ -420,19 +525,32 @@ const file_transaction_proto_rawDesc = "" +
 	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x1b\n" +
 	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x05 \x01(\tR\tpageToken\"\x9c\x01\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"\xa5\x02\n" +
+	"\x17ListTransactionsRequest\x12C\n" +
+	"\x0epayment_status\x18\x01 \x03(\x0e2\x1c.etc_meisai.v1.PaymentStatusR\rpaymentStatus\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x17\n" +
+	"\acard_id\x18\x04 \x01(\tR\x06cardId\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"\xda\x01\n" +
 	"\x0fTransactionList\x12>\n" +
 	"\ftransactions\x18\x01 \x03(\v2\x1a.etc_meisai.v1.TransactionR\ftransactions\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12!\n" +
-	"\ftotal_amount\x18\x03 \x01(\x03R\vtotalAmount*\x84\x01\n" +
+	"\ftotal_amount\x18\x03 \x01(\x03R\vtotalAmount\x12\x1f\n" +
+	"\vtotal_count\x18\x04 \x01(\x05R\n" +
+	"totalCount\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize*\x84\x01\n" +
 	"\rPaymentStatus\x12\x1e\n" +
 	"\x1aPAYMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16PAYMENT_STATUS_PENDING\x10\x01\x12\x1c\n" +
 	"\x18PAYMENT_STATUS_COMPLETED\x10\x02\x12\x19\n" +
-	"\x15PAYMENT_STATUS_FAILED\x10\x032\x90\x02\n" +
+	"\x15PAYMENT_STATUS_FAILED\x10\x032\xeb\x02\n" +
 	"\x12TransactionService\x12u\n" +
-	"\x0eGetTransaction\x12$.etc_meisai.v1.GetTransactionRequest\x1a\x1a.etc_meisai.v1.Transaction\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/transactions/{id}\x12\x82\x01\n" +
-	"\x15GetTransactionHistory\x12+.etc_meisai.v1.GetTransactionHistoryRequest\x1a\x1e.etc_meisai.v1.TransactionList\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/transactionsB\xaf\x01\n" +
+	"\x0eGetTransaction\x12$.etc_meisai.v1.GetTransactionRequest\x1a\x1a.etc_meisai.v1.Transaction\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/transactions/{id}\x12d\n" +
+	"\x15GetTransactionHistory\x12+.etc_meisai.v1.GetTransactionHistoryRequest\x1a\x1e.etc_meisai.v1.TransactionList\x12x\n" +
+	"\x10ListTransactions\x12&.etc_meisai.v1.ListTransactionsRequest\x1a\x1e.etc_meisai.v1.TransactionList\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/transactionsB\xaf\x01\n" +
 	"\x11com.etc_meisai.v1B\x10TransactionProtoP\x01Z7github.com/yhonda-ohishi/db-handler-server;etc_meisaiv1\xa2\x02\x03EXX\xaa\x02\fEtcMeisai.V1\xca\x02\fEtcMeisai\\V1\xe2\x02\x18EtcMeisai\\V1\\GPBMetadata\xea\x02\rEtcMeisai::V1b\x06proto3"
 
 var (
@@ -448,32 +566,38 @@ func file_transaction_proto_rawDescGZIP() []byte {
 }
 
 var file_transaction_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_transaction_proto_goTypes = []any{
 	(PaymentStatus)(0),                   // 0: etc_meisai.v1.PaymentStatus
 	(*Transaction)(nil),                  // 1: etc_meisai.v1.Transaction
 	(*GetTransactionRequest)(nil),        // 2: etc_meisai.v1.GetTransactionRequest
 	(*GetTransactionHistoryRequest)(nil), // 3: etc_meisai.v1.GetTransactionHistoryRequest
-	(*TransactionList)(nil),              // 4: etc_meisai.v1.TransactionList
-	(*timestamppb.Timestamp)(nil),        // 5: google.protobuf.Timestamp
+	(*ListTransactionsRequest)(nil),      // 4: etc_meisai.v1.ListTransactionsRequest
+	(*TransactionList)(nil),              // 5: etc_meisai.v1.TransactionList
+	(*timestamppb.Timestamp)(nil),        // 6: google.protobuf.Timestamp
 }
 var file_transaction_proto_depIdxs = []int32{
-	5, // 0: etc_meisai.v1.Transaction.entry_time:type_name -> google.protobuf.Timestamp
-	5, // 1: etc_meisai.v1.Transaction.exit_time:type_name -> google.protobuf.Timestamp
-	0, // 2: etc_meisai.v1.Transaction.payment_status:type_name -> etc_meisai.v1.PaymentStatus
-	5, // 3: etc_meisai.v1.Transaction.transaction_date:type_name -> google.protobuf.Timestamp
-	5, // 4: etc_meisai.v1.GetTransactionHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
-	5, // 5: etc_meisai.v1.GetTransactionHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
-	1, // 6: etc_meisai.v1.TransactionList.transactions:type_name -> etc_meisai.v1.Transaction
-	2, // 7: etc_meisai.v1.TransactionService.GetTransaction:input_type -> etc_meisai.v1.GetTransactionRequest
-	3, // 8: etc_meisai.v1.TransactionService.GetTransactionHistory:input_type -> etc_meisai.v1.GetTransactionHistoryRequest
-	1, // 9: etc_meisai.v1.TransactionService.GetTransaction:output_type -> etc_meisai.v1.Transaction
-	4, // 10: etc_meisai.v1.TransactionService.GetTransactionHistory:output_type -> etc_meisai.v1.TransactionList
-	9, // [9:11] is the sub-list for method output_type
-	7, // [7:9] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	6,  // 0: etc_meisai.v1.Transaction.entry_time:type_name -> google.protobuf.Timestamp
+	6,  // 1: etc_meisai.v1.Transaction.exit_time:type_name -> google.protobuf.Timestamp
+	0,  // 2: etc_meisai.v1.Transaction.payment_status:type_name -> etc_meisai.v1.PaymentStatus
+	6,  // 3: etc_meisai.v1.Transaction.transaction_date:type_name -> google.protobuf.Timestamp
+	6,  // 4: etc_meisai.v1.GetTransactionHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
+	6,  // 5: etc_meisai.v1.GetTransactionHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
+	0,  // 6: etc_meisai.v1.ListTransactionsRequest.payment_status:type_name -> etc_meisai.v1.PaymentStatus
+	6,  // 7: etc_meisai.v1.ListTransactionsRequest.start_date:type_name -> google.protobuf.Timestamp
+	6,  // 8: etc_meisai.v1.ListTransactionsRequest.end_date:type_name -> google.protobuf.Timestamp
+	1,  // 9: etc_meisai.v1.TransactionList.transactions:type_name -> etc_meisai.v1.Transaction
+	2,  // 10: etc_meisai.v1.TransactionService.GetTransaction:input_type -> etc_meisai.v1.GetTransactionRequest
+	3,  // 11: etc_meisai.v1.TransactionService.GetTransactionHistory:input_type -> etc_meisai.v1.GetTransactionHistoryRequest
+	4,  // 12: etc_meisai.v1.TransactionService.ListTransactions:input_type -> etc_meisai.v1.ListTransactionsRequest
+	1,  // 13: etc_meisai.v1.TransactionService.GetTransaction:output_type -> etc_meisai.v1.Transaction
+	5,  // 14: etc_meisai.v1.TransactionService.GetTransactionHistory:output_type -> etc_meisai.v1.TransactionList
+	5,  // 15: etc_meisai.v1.TransactionService.ListTransactions:output_type -> etc_meisai.v1.TransactionList
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_transaction_proto_init() }
@@ -487,7 +611,7 @@ func file_transaction_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transaction_proto_rawDesc), len(file_transaction_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
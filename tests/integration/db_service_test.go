@@ -21,7 +21,8 @@ func TestDBServiceIntegration(t *testing.T) {
 	server := grpc.NewServer()
 
 	// Register services using single mode registry
-	registry := services.NewServiceRegistryForSingleMode()
+	registry, err := services.NewServiceRegistryForSingleMode()
+	require.NoError(t, err)
 	registry.RegisterAll(server)
 
 	// Start server
@@ -159,11 +159,11 @@ func TestErrorHandling(t *testing.T) {
 			require.NoError(t, err)
 			defer resp.Body.Close()
 
-			// Should either be 400 or 404, both are acceptable
-			assert.True(t, resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound)
+			// A missing ID is invalid; 404 is reserved for unknown IDs
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 
-			// Missing query parameters
-			req = httptest.NewRequest("GET", "/api/v1/transactions", nil) // Missing card_id
+			// Invalid query parameters
+			req = httptest.NewRequest("GET", "/api/v1/transactions?status=refunded", nil)
 			resp, err = app.Test(req)
 			require.NoError(t, err)
 			defer resp.Body.Close()
@@ -358,8 +358,15 @@ func TestErrorHandling(t *testing.T) {
 			require.NoError(t, err)
 			defer resp.Body.Close()
 
-			// May still work in some implementations, but should handle gracefully
-			assert.True(t, resp.StatusCode < 500) // Should not be server error
+			// The request is rejected as invalid rather than parsed
+			assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+			var result map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			errorObj, ok := result["error"].(map[string]interface{})
+			require.True(t, ok)
+			assert.Equal(t, float64(-32600), errorObj["code"])
+			assert.Contains(t, errorObj["message"], "Content-Type")
 		})
 	})
 
@@ -374,9 +381,12 @@ func TestErrorHandling(t *testing.T) {
 			err = json.NewDecoder(resp.Body).Decode(&result)
 			require.NoError(t, err)
 
-			// Should have an error field
-			assert.Contains(t, result, "error")
-			assert.IsType(t, "", result["error"])
+			// Should have an error envelope with the gRPC code and message
+			require.Contains(t, result, "error")
+			errorObj, ok := result["error"].(map[string]interface{})
+			require.True(t, ok)
+			assert.Equal(t, "NOT_FOUND", errorObj["code"])
+			assert.NotEmpty(t, errorObj["message"])
 		})
 
 		t.Run("JSON-RPC error format", func(t *testing.T) {
@@ -0,0 +1,137 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yhonda-ohishi/db-handler-server/internal/config"
+	"github.com/yhonda-ohishi/db-handler-server/internal/gateway"
+	pb "github.com/yhonda-ohishi/db-handler-server/proto"
+	"google.golang.org/grpc"
+)
+
+// stubBackend is a downstream gRPC server that answers with its own name and
+// counts the calls it receives
+type stubBackend struct {
+	name      string
+	address   string
+	userCalls atomic.Int32
+	etcCalls  atomic.Int32
+}
+
+type stubUserService struct {
+	pb.UnimplementedUserServiceServer
+	backend *stubBackend
+}
+
+func (s *stubUserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	s.backend.userCalls.Add(1)
+	return &pb.User{Id: req.Id, Name: s.backend.name}, nil
+}
+
+type stubETCService struct {
+	pb.UnimplementedETCServiceServer
+	backend *stubBackend
+}
+
+func (s *stubETCService) GetETCMeisai(ctx context.Context, req *pb.GetETCMeisaiRequest) (*pb.ETCMeisaiResponse, error) {
+	s.backend.etcCalls.Add(1)
+	return &pb.ETCMeisaiResponse{EtcMeisai: &pb.ETCMeisai{Id: req.Id, CarNumber: s.backend.name}}, nil
+}
+
+// startStubBackend serves the user and ETC services on a local port
+func startStubBackend(t *testing.T, name string) *stubBackend {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	backend := &stubBackend{name: name, address: listener.Addr().String()}
+	server := grpc.NewServer()
+	pb.RegisterUserServiceServer(server, &stubUserService{backend: backend})
+	pb.RegisterETCServiceServer(server, &stubETCService{backend: backend})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	return backend
+}
+
+// TestSeparateModeRoutesToBackends checks that separate mode sends ETC明細
+// calls to the database backend and user calls to the handlers backend
+func TestSeparateModeRoutesToBackends(t *testing.T) {
+	newGateway := func(t *testing.T, external config.ExternalConfig) *gateway.SimpleGateway {
+		cfg := &config.Config{
+			Deployment: config.DeploymentConfig{Mode: "separate"},
+			Server:     config.ServerConfig{HTTPPort: 8080, GRPCPort: 9090},
+			External:   external,
+		}
+		gw := gateway.NewSimpleGateway(cfg)
+		t.Cleanup(func() { _ = gw.Stop() })
+		require.NoError(t, gw.Initialize())
+		return gw
+	}
+
+	get := func(t *testing.T, gw *gateway.SimpleGateway, path string) map[string]interface{} {
+		resp, err := gw.GetHTTPHandler().Test(httptest.NewRequest("GET", path, nil), 5000)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, 200, resp.StatusCode, "GET %s", path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body
+	}
+
+	t.Run("both backends", func(t *testing.T) {
+		database := startStubBackend(t, "database")
+		handlers := startStubBackend(t, "handlers")
+		gw := newGateway(t, config.ExternalConfig{
+			DatabaseGRPCURL: database.address,
+			HandlersGRPCURL: handlers.address,
+		})
+
+		user := get(t, gw, "/api/v1/users/user-1")
+		assert.Equal(t, "handlers", user["name"])
+		assert.Equal(t, int32(1), handlers.userCalls.Load())
+		assert.Equal(t, int32(0), database.userCalls.Load())
+
+		meisai := get(t, gw, "/api/v1/etc/meisai/7")
+		assert.Equal(t, "database", meisai["car_number"])
+		assert.Equal(t, int32(1), database.etcCalls.Load())
+		assert.Equal(t, int32(0), handlers.etcCalls.Load())
+
+		// Each backend has its own health check
+		var names []string
+		for _, component := range get(t, gw, "/health")["components"].([]interface{}) {
+			names = append(names, component.(map[string]interface{})["name"].(string))
+		}
+		assert.Contains(t, names, "db_service")
+		assert.Contains(t, names, "handlers_service")
+	})
+
+	t.Run("only handlers backend", func(t *testing.T) {
+		handlers := startStubBackend(t, "handlers")
+		gw := newGateway(t, config.ExternalConfig{HandlersGRPCURL: handlers.address})
+
+		assert.Equal(t, "handlers", get(t, gw, "/api/v1/users/user-1")["name"])
+		assert.Equal(t, "handlers", get(t, gw, "/api/v1/etc/meisai/7")["car_number"])
+		assert.Equal(t, int32(1), handlers.etcCalls.Load())
+	})
+
+	t.Run("only database backend", func(t *testing.T) {
+		database := startStubBackend(t, "database")
+		gw := newGateway(t, config.ExternalConfig{DatabaseGRPCURL: database.address})
+
+		assert.Equal(t, "database", get(t, gw, "/api/v1/users/user-1")["name"])
+		assert.Equal(t, "database", get(t, gw, "/api/v1/etc/meisai/7")["car_number"])
+		assert.Equal(t, int32(1), database.userCalls.Load())
+	})
+}
\ No newline at end of file